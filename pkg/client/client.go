@@ -2,6 +2,7 @@
 package client
 
 import (
+	"context"
 	"strconv"
 	"strings"
 
@@ -13,7 +14,9 @@ import (
 type (
 	Client interface {
 		Open() error
-		Execute(statement string) (Response, error)
+		Execute(ctx context.Context, statement string) (Response, error)
+		// ExecuteWithParameter is the same as Execute, and binds the params referred as `$name` in the statement.
+		ExecuteWithParameter(ctx context.Context, statement string, params map[string]any) (Response, error)
 		Close() error
 	}
 
@@ -73,7 +76,32 @@ func (c *defaultClient) Open() error {
 	return nil
 }
 
-func (c *defaultClient) Execute(statement string) (Response, error) {
+func (c *defaultClient) Execute(ctx context.Context, statement string) (Response, error) {
+	return c.execute(ctx, func() (Response, error) {
+		return c.session.Execute(statement)
+	})
+}
+
+func (c *defaultClient) ExecuteWithParameter(ctx context.Context, statement string, params map[string]any) (Response, error) {
+	return c.execute(ctx, func() (Response, error) {
+		return c.session.ExecuteWithParameter(statement, params)
+	})
+}
+
+// execute calls the fnExecute, and retries by the error of the response.
+func (c *defaultClient) execute(ctx context.Context, fnExecute func() (Response, error)) (Response, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if c.noRetry {
+		resp, err := fnExecute()
+		if err != nil {
+			c.logger.WithError(err).Error("execute statement failed")
+		}
+		return resp, err
+	}
+
 	exp := backoff.NewExponentialBackOff()
 	exp.InitialInterval = c.retryInitialInterval
 	exp.MaxInterval = DefaultRetryMaxInterval
@@ -92,7 +120,7 @@ func (c *defaultClient) Execute(statement string) (Response, error) {
 	// * Case 2. retry as much as possible
 	// * Case 3: retry with limit times
 	_ = backoff.Retry(func() error {
-		resp, err = c.session.Execute(statement)
+		resp, err = fnExecute()
 		if err == nil && resp.IsSucceed() {
 			return nil
 		}
@@ -120,7 +148,7 @@ func (c *defaultClient) Execute(statement string) (Response, error) {
 		}
 		retry--
 		return retryErr
-	}, exp)
+	}, backoff.WithContext(exp, ctx))
 	if err != nil {
 		c.logger.WithError(err).Error("execute statement failed")
 	}
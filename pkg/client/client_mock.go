@@ -5,6 +5,7 @@
 package client
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -48,18 +49,33 @@ func (mr *MockClientMockRecorder) Close() *gomock.Call {
 }
 
 // Execute mocks base method.
-func (m *MockClient) Execute(statement string) (Response, error) {
+func (m *MockClient) Execute(ctx context.Context, statement string) (Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", statement)
+	ret := m.ctrl.Call(m, "Execute", ctx, statement)
 	ret0, _ := ret[0].(Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MockClientMockRecorder) Execute(statement interface{}) *gomock.Call {
+func (mr *MockClientMockRecorder) Execute(ctx, statement interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockClient)(nil).Execute), statement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockClient)(nil).Execute), ctx, statement)
+}
+
+// ExecuteWithParameter mocks base method.
+func (m *MockClient) ExecuteWithParameter(ctx context.Context, statement string, params map[string]any) (Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteWithParameter", ctx, statement, params)
+	ret0, _ := ret[0].(Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteWithParameter indicates an expected call of ExecuteWithParameter.
+func (mr *MockClientMockRecorder) ExecuteWithParameter(ctx, statement, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWithParameter", reflect.TypeOf((*MockClient)(nil).ExecuteWithParameter), ctx, statement, params)
 }
 
 // Open mocks base method.
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	stderrors "errors"
 	"sync/atomic"
 	"time"
@@ -152,7 +153,7 @@ var _ = Describe("Clientxxx", func() {
 			mockResponse.EXPECT().GetError().Times(1).Return(stderrors.New("test error"))
 			mockResponse.EXPECT().IsPermanentError().Times(2).Return(true)
 
-			resp, err := c.Execute("test Execute statement")
+			resp, err := c.Execute(context.Background(), "test Execute statement")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).NotTo(BeNil())
 			Expect(resp.IsPermanentError()).To(BeTrue())
@@ -173,7 +174,7 @@ var _ = Describe("Clientxxx", func() {
 			mockResponse.EXPECT().IsPermanentError().Times(retryTimes).Return(false)
 			mockResponse.EXPECT().IsRetryMoreError().Times(retryTimes).Return(true)
 
-			resp, err := c.Execute("test Execute statement")
+			resp, err := c.Execute(context.Background(), "test Execute statement")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).NotTo(BeNil())
 			Expect(resp.IsSucceed()).To(BeTrue())
@@ -183,16 +184,41 @@ var _ = Describe("Clientxxx", func() {
 			// * Case 3: retry with limit times
 			mockSession.EXPECT().Execute("test Execute statement").Times(DefaultRetry+1).Return(nil, stderrors.New("execute failed"))
 
-			resp, err := c.Execute("test Execute statement")
+			resp, err := c.Execute(context.Background(), "test Execute statement")
 			Expect(err).To(HaveOccurred())
 			Expect(resp).To(BeNil())
 		})
 
+		It("no retry", func() {
+			WithNoRetry(true)(c.(*defaultClient).options)
+
+			mockSession.EXPECT().Execute("test Execute statement").Times(1).Return(nil, stderrors.New("execute failed"))
+			resp, err := c.Execute(context.Background(), "test Execute statement")
+			Expect(err).To(HaveOccurred())
+			Expect(resp).To(BeNil())
+
+			mockSession.EXPECT().Execute("test Execute statement").Times(1).Return(mockResponse, nil)
+			mockResponse.EXPECT().IsSucceed().Times(1).Return(false)
+			resp, err = c.Execute(context.Background(), "test Execute statement")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp.IsSucceed()).To(BeFalse())
+		})
+
 		It("successfully", func() {
 			mockSession.EXPECT().Execute("test Execute statement").Times(1).Return(mockResponse, nil)
 			mockResponse.EXPECT().IsSucceed().Times(1).Return(true)
 
-			resp, err := c.Execute("test Execute statement")
+			resp, err := c.Execute(context.Background(), "test Execute statement")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).NotTo(BeNil())
+		})
+
+		It("ExecuteWithParameter successfully", func() {
+			params := map[string]any{"p0": "a"}
+			mockSession.EXPECT().ExecuteWithParameter("test Execute statement $p0", params).Times(1).Return(mockResponse, nil)
+			mockResponse.EXPECT().IsSucceed().Times(1).Return(true)
+
+			resp, err := c.ExecuteWithParameter(context.Background(), "test Execute statement $p0", params)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).NotTo(BeNil())
 		})
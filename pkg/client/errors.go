@@ -2,4 +2,10 @@ package client
 
 import stderrors "errors"
 
-var ErrClosed = stderrors.New("client closed")
+var (
+	ErrClosed = stderrors.New("client closed")
+	// ErrPermanent marks the errors that will never succeed by retrying.
+	ErrPermanent = stderrors.New("permanent error")
+	// ErrTransient marks the errors that are expected to succeed after retrying for a while.
+	ErrTransient = stderrors.New("transient error")
+)
@@ -34,6 +34,7 @@ type (
 		tlsConfig            *tls.Config
 		retry                int
 		retryInitialInterval time.Duration
+		noRetry              bool
 		logger               logger.Logger
 		fnNewSession         NewSessionFunc
 		clientInitFunc       func(Client) error
@@ -98,6 +99,13 @@ func WithRetry(retry int) Option {
 	}
 }
 
+// WithNoRetry executes the statements only once if noRetry, such as the importer retries them by its own policies.
+func WithNoRetry(noRetry bool) Option {
+	return func(o *options) {
+		o.noRetry = noRetry
+	}
+}
+
 func WithRetryInitialInterval(interval time.Duration) Option {
 	return func(o *options) {
 		if interval > 0 {
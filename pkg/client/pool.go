@@ -2,6 +2,7 @@
 package client
 
 import (
+	"context"
 	"sync"
 
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
@@ -29,7 +30,9 @@ type (
 	NewSessionFunc func(HostAddress) Session
 
 	executeData struct {
+		ctx       context.Context
 		statement string
+		params    map[string]any
 		ch        chan<- ExecuteResult
 	}
 
@@ -76,7 +79,16 @@ func (p *defaultPool) Open() error {
 	return nil
 }
 
-func (p *defaultPool) Execute(statement string) (Response, error) {
+func (p *defaultPool) Execute(ctx context.Context, statement string) (Response, error) {
+	return p.execute(ctx, statement, nil)
+}
+
+func (p *defaultPool) ExecuteWithParameter(ctx context.Context, statement string, params map[string]any) (Response, error) {
+	return p.execute(ctx, statement, params)
+}
+
+// execute queues the statement to the workers, and the statement is executed with the params if not nil.
+func (p *defaultPool) execute(ctx context.Context, statement string, params map[string]any) (Response, error) {
 	if p.IsClosed() {
 		return nil, ErrClosed
 	}
@@ -85,12 +97,23 @@ func (p *defaultPool) Execute(statement string) (Response, error) {
 
 	ch := make(chan ExecuteResult, 1)
 	data := executeData{
+		ctx:       ctx,
 		statement: statement,
+		params:    params,
 		ch:        ch,
 	}
-	p.chExecuteDataQueue <- data
-	result := <-ch
-	return result.Response, result.Err
+	select {
+	case p.chExecuteDataQueue <- data:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case result := <-ch:
+		return result.Response, result.Err
+	case <-ctx.Done():
+		// the ch is buffered, so the worker will not be blocked.
+		return nil, ctx.Err()
+	}
 }
 
 func (p *defaultPool) ExecuteChan(statement string) (<-chan ExecuteResult, bool) {
@@ -102,6 +125,7 @@ func (p *defaultPool) ExecuteChan(statement string) (<-chan ExecuteResult, bool)
 
 	ch := make(chan ExecuteResult, 1)
 	data := executeData{
+		ctx:       context.Background(),
 		statement: statement,
 		ch:        ch,
 	}
@@ -198,7 +222,15 @@ func (p *defaultPool) loop(c Client) {
 			if !ok {
 				continue
 			}
-			resp, err := c.Execute(data.statement)
+			var (
+				resp Response
+				err  error
+			)
+			if data.params != nil {
+				resp, err = c.ExecuteWithParameter(data.ctx, data.statement, data.params)
+			} else {
+				resp, err = c.Execute(data.ctx, data.statement)
+			}
 			data.ch <- ExecuteResult{
 				Response: resp,
 				Err:      err,
@@ -5,6 +5,7 @@
 package client
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -48,18 +49,18 @@ func (mr *MockPoolMockRecorder) Close() *gomock.Call {
 }
 
 // Execute mocks base method.
-func (m *MockPool) Execute(statement string) (Response, error) {
+func (m *MockPool) Execute(ctx context.Context, statement string) (Response, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Execute", statement)
+	ret := m.ctrl.Call(m, "Execute", ctx, statement)
 	ret0, _ := ret[0].(Response)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Execute indicates an expected call of Execute.
-func (mr *MockPoolMockRecorder) Execute(statement interface{}) *gomock.Call {
+func (mr *MockPoolMockRecorder) Execute(ctx, statement interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockPool)(nil).Execute), statement)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockPool)(nil).Execute), ctx, statement)
 }
 
 // ExecuteChan mocks base method.
@@ -77,6 +78,21 @@ func (mr *MockPoolMockRecorder) ExecuteChan(statement interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteChan", reflect.TypeOf((*MockPool)(nil).ExecuteChan), statement)
 }
 
+// ExecuteWithParameter mocks base method.
+func (m *MockPool) ExecuteWithParameter(ctx context.Context, statement string, params map[string]any) (Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteWithParameter", ctx, statement, params)
+	ret0, _ := ret[0].(Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteWithParameter indicates an expected call of ExecuteWithParameter.
+func (mr *MockPoolMockRecorder) ExecuteWithParameter(ctx, statement, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWithParameter", reflect.TypeOf((*MockPool)(nil).ExecuteWithParameter), ctx, statement, params)
+}
+
 // GetClient mocks base method.
 func (m *MockPool) GetClient(opts ...Option) (Client, error) {
 	m.ctrl.T.Helper()
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"context"
 	stderrors "errors"
 	"sync"
 	"sync/atomic"
@@ -154,7 +155,7 @@ var _ = Describe("Pool", func() {
 			err = pool.Close()
 			Expect(err).NotTo(HaveOccurred())
 
-			resp, err := pool.Execute("test Execute statement")
+			resp, err := pool.Execute(context.Background(), "test Execute statement")
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(Equal(ErrClosed))
 			Expect(resp).To(BeNil())
@@ -248,7 +249,7 @@ var _ = Describe("Pool", func() {
 			)
 
 			wg.Add(clientOpenTimes)
-			fnExecute := func(_ string) (Response, error) {
+			fnExecute := func(_ context.Context, _ string) (Response, error) {
 				received <- struct{}{}
 				<-filled
 				<-waitToExec
@@ -259,7 +260,7 @@ var _ = Describe("Pool", func() {
 				defer wg.Done()
 				return nil
 			})
-			mockClient.EXPECT().Execute("test ExecuteChan statement").MaxTimes(2).DoAndReturn(fnExecute)
+			mockClient.EXPECT().Execute(gomock.Any(), "test ExecuteChan statement").MaxTimes(2).DoAndReturn(fnExecute)
 			mockClient.EXPECT().Close().Times(clientOpenTimes).Return(nil)
 
 			err := pool.Open()
@@ -330,6 +331,33 @@ var _ = Describe("Pool", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("ExecuteWithParameter", func() {
+			pool := NewPool(
+				WithAddress("127.0.0.1:9669"),
+				WithConcurrencyPerAddress(1),
+				func(o *options) {
+					o.fnNewClientWithOptions = func(o *options) Client {
+						return mockClient
+					}
+				},
+			)
+
+			params := map[string]any{"p0": "a"}
+			mockClient.EXPECT().Open().Times(2).Return(nil)
+			mockClient.EXPECT().ExecuteWithParameter(gomock.Any(), "test Execute statement $p0", params).Return(mockResponse, nil)
+			mockClient.EXPECT().Close().Times(2).Return(nil)
+
+			err := pool.Open()
+			Expect(err).NotTo(HaveOccurred())
+
+			resp, err := pool.ExecuteWithParameter(context.Background(), "test Execute statement $p0", params)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).NotTo(BeNil())
+
+			err = pool.Close()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("concurrency", func() {
 			var (
 				addresses    = []string{"127.0.0.1:9669", "127.0.0.2:9669"}
@@ -357,8 +385,8 @@ var _ = Describe("Pool", func() {
 				defer wg.Done()
 				return nil
 			})
-			mockClient.EXPECT().Execute("test Execute statement").Times(executeTimes).Return(mockResponse, nil)
-			mockClient.EXPECT().Execute("test ExecuteChan statement").Times(executeTimes).Return(mockResponse, nil)
+			mockClient.EXPECT().Execute(gomock.Any(), "test Execute statement").Times(executeTimes).Return(mockResponse, nil)
+			mockClient.EXPECT().Execute(gomock.Any(), "test ExecuteChan statement").Times(executeTimes).Return(mockResponse, nil)
 			mockClient.EXPECT().Close().Times(clientOpenTimes).Return(nil)
 
 			err := pool.Open()
@@ -370,7 +398,7 @@ var _ = Describe("Pool", func() {
 				go func() {
 					defer GinkgoRecover()
 					defer wgExecutes.Done()
-					resp, err := pool.Execute("test Execute statement")
+					resp, err := pool.Execute(context.Background(), "test Execute statement")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(resp).NotTo(BeNil())
 				}()
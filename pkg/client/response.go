@@ -12,4 +12,6 @@ type Response interface {
 	GetError() error
 	IsPermanentError() bool
 	IsRetryMoreError() bool
+	// GetColumnValues returns the values of the column in the nGQL literals, such as `"str"` and `1`.
+	GetColumnValues(column string) ([]string, error)
 }
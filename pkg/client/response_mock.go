@@ -34,6 +34,21 @@ func (m *MockResponse) EXPECT() *MockResponseMockRecorder {
 	return m.recorder
 }
 
+// GetColumnValues mocks base method.
+func (m *MockResponse) GetColumnValues(column string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetColumnValues", column)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetColumnValues indicates an expected call of GetColumnValues.
+func (mr *MockResponseMockRecorder) GetColumnValues(column interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetColumnValues", reflect.TypeOf((*MockResponse)(nil).GetColumnValues), column)
+}
+
 // GetError mocks base method.
 func (m *MockResponse) GetError() error {
 	m.ctrl.T.Helper()
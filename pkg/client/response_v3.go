@@ -2,10 +2,67 @@ package client
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	nebula "github.com/vesoft-inc/nebula-go/v3"
+	nebulatypes "github.com/vesoft-inc/nebula-go/v3/nebula"
+)
+
+var (
+	// permanentErrorCodesV3 are the error codes that will never succeed by retrying,
+	// such as the statement or the schema is wrong.
+	permanentErrorCodesV3 = map[nebula.ErrorCode]struct{}{
+		nebula.ErrorCode_E_SYNTAX_ERROR:                                      {},
+		nebula.ErrorCode_E_SEMANTIC_ERROR:                                    {},
+		nebula.ErrorCode_E_STATEMENT_EMPTY:                                   {},
+		nebula.ErrorCode_E_BAD_PERMISSION:                                    {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_SPACE_NOT_FOUND):            {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_TAG_NOT_FOUND):              {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_EDGE_NOT_FOUND):             {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_INDEX_NOT_FOUND):            {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_TAG_PROP_NOT_FOUND):         {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_EDGE_PROP_NOT_FOUND):        {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_DATA_TYPE_MISMATCH):         {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_INVALID_FIELD_VALUE):        {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_NOT_NULLABLE):               {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_FIELD_UNSET):                {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_OUT_OF_RANGE):               {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_INVALID_VID):                {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_INVALID_SPACEVIDLEN):        {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_IMPROPER_DATA_TYPE):         {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_INVALID_DATA):               {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_CLIENT_SERVER_INCOMPATIBLE): {},
+	}
+
+	// retryMoreErrorCodesV3 are the error codes that are transient in the cluster,
+	// such as rpc failure, leader changed and storage timeout.
+	retryMoreErrorCodesV3 = map[nebula.ErrorCode]struct{}{
+		nebula.ErrorCode_E_RPC_FAILURE:                                   {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_LEADER_CHANGED):         {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_PART_NOT_FOUND):         {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_CONSENSUS_ERROR):        {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_WRITE_STALLED):          {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_RAFT_BUFFER_OVERFLOW):   {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_RAFT_WRITE_BLOCKED):     {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_RAFT_TOO_MANY_REQUESTS): {},
+		nebula.ErrorCode(nebulatypes.ErrorCode_E_LEADER_LEASE_FAILED):    {},
+	}
+
+	// retryMoreErrorMessagesV3 are used to find the transient errors of storage,
+	// which are reported by graphd as E_EXECUTION_ERROR with the message only.
+	retryMoreErrorMessagesV3 = []string{
+		// TODO: compare with E_RAFT_BUFFER_OVERFLOW
+		// Can not get the E_RAFT_BUFFER_OVERFLOW inside storage now.
+		"raft buffer is full",
+		"leader has changed",
+		"E_LEADER_CHANGED",
+		"RPC failure",
+		"E_RPC_FAILURE",
+		"probably timeout",
+		"Storage Error: timeout",
+	}
 )
 
 type defaultResponseV3 struct {
@@ -38,22 +95,37 @@ func (resp defaultResponseV3) GetError() error {
 }
 
 func (resp defaultResponseV3) IsPermanentError() bool {
-	switch resp.ResultSet.GetErrorCode() { //nolint:exhaustive
-	default:
-		return false
-	case nebula.ErrorCode_E_SYNTAX_ERROR:
-	case nebula.ErrorCode_E_SEMANTIC_ERROR:
-	}
-	return true
+	_, ok := permanentErrorCodesV3[resp.ResultSet.GetErrorCode()]
+	return ok
 }
 
 func (resp defaultResponseV3) IsRetryMoreError() bool {
-	switch resp.ResultSet.GetErrorCode() {
-	case nebula.ErrorCode_E_SEMANTIC_ERROR:
+	if _, ok := retryMoreErrorCodesV3[resp.ResultSet.GetErrorCode()]; ok {
 		return true
 	}
 	errorMsg := resp.ResultSet.GetErrorMsg()
-	// TODO: compare with E_RAFT_BUFFER_OVERFLOW
-	// Can not get the E_RAFT_BUFFER_OVERFLOW inside storage now.
-	return strings.Contains(errorMsg, "raft buffer is full")
+	for _, msg := range retryMoreErrorMessagesV3 {
+		if strings.Contains(errorMsg, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func (resp defaultResponseV3) GetColumnValues(column string) ([]string, error) {
+	values, err := resp.ResultSet.GetValuesByColName(column)
+	if err != nil {
+		return nil, err
+	}
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		// The strings are quoted in the same way as the picker.
+		if value.IsString() {
+			str, _ := value.AsString()
+			literals = append(literals, strconv.Quote(str))
+			continue
+		}
+		literals = append(literals, value.String())
+	}
+	return literals, nil
 }
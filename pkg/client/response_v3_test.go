@@ -6,6 +6,7 @@ import (
 	"time"
 
 	nebula "github.com/vesoft-inc/nebula-go/v3"
+	nebulatypes "github.com/vesoft-inc/nebula-go/v3/nebula"
 
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
@@ -56,7 +57,34 @@ var _ = Describe("defaultResponseV3", func() {
 		EntryDescription("%[1]s -> %[2]t"),
 		Entry(nil, nebula.ErrorCode_E_SYNTAX_ERROR, true),
 		Entry(nil, nebula.ErrorCode_E_SEMANTIC_ERROR, true),
+		Entry(nil, nebula.ErrorCode_E_STATEMENT_EMPTY, true),
+		Entry(nil, nebula.ErrorCode(nebulatypes.ErrorCode_E_TAG_NOT_FOUND), true),
+		Entry(nil, nebula.ErrorCode(nebulatypes.ErrorCode_E_EDGE_PROP_NOT_FOUND), true),
 		Entry(nil, nebula.ErrorCode_E_DISCONNECTED, false),
+		Entry(nil, nebula.ErrorCode_E_RPC_FAILURE, false),
+		Entry(nil, nebula.ErrorCode(nebulatypes.ErrorCode_E_LEADER_CHANGED), false),
+	)
+
+	DescribeTable("IsRetryMoreError",
+		func(errorCode nebula.ErrorCode, isRetryMoreError bool) {
+			patches := gomonkey.NewPatches()
+			defer patches.Reset()
+
+			rs := nebula.ResultSet{}
+			resp := newResponseV3(&rs, time.Second)
+
+			patches.ApplyMethodReturn(rs, "GetErrorCode", errorCode)
+			patches.ApplyMethodReturn(rs, "GetErrorMsg", "")
+
+			Expect(resp.IsRetryMoreError()).To(Equal(isRetryMoreError))
+		},
+		EntryDescription("%[1]s -> %[2]t"),
+		Entry(nil, nebula.ErrorCode_E_RPC_FAILURE, true),
+		Entry(nil, nebula.ErrorCode(nebulatypes.ErrorCode_E_LEADER_CHANGED), true),
+		Entry(nil, nebula.ErrorCode(nebulatypes.ErrorCode_E_RAFT_BUFFER_OVERFLOW), true),
+		Entry(nil, nebula.ErrorCode_E_SYNTAX_ERROR, false),
+		Entry(nil, nebula.ErrorCode_E_SEMANTIC_ERROR, false),
+		Entry(nil, nebula.ErrorCode_E_EXECUTION_ERROR, false),
 	)
 
 	DescribeTable("IsPermanentError",
@@ -67,12 +95,16 @@ var _ = Describe("defaultResponseV3", func() {
 			rs := nebula.ResultSet{}
 			resp := newResponseV3(&rs, time.Second)
 
+			patches.ApplyMethodReturn(rs, "GetErrorCode", nebula.ErrorCode_E_EXECUTION_ERROR)
 			patches.ApplyMethodReturn(rs, "GetErrorMsg", errorMsg)
 
 			Expect(resp.IsRetryMoreError()).To(Equal(isPermanentError))
 		},
 		EntryDescription("%[1]s -> %[2]t"),
 		Entry(nil, "x raft buffer is full x", true),
+		Entry(nil, "Storage Error: The leader has changed. Try again later", true),
+		Entry(nil, "Storage Error: RPC failure, probably timeout.", true),
+		Entry(nil, "Storage Error: part: 1, error: E_RPC_FAILURE(-3).", true),
 		Entry(nil, "x x", false),
 	)
 })
@@ -4,5 +4,7 @@ package client
 type Session interface {
 	Open() error
 	Execute(statement string) (Response, error)
+	// ExecuteWithParameter executes the statement with the params, which are referred as `$name` in the statement.
+	ExecuteWithParameter(statement string, params map[string]any) (Response, error)
 	Close() error
 }
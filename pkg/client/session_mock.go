@@ -62,6 +62,21 @@ func (mr *MockSessionMockRecorder) Execute(statement interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockSession)(nil).Execute), statement)
 }
 
+// ExecuteWithParameter mocks base method.
+func (m *MockSession) ExecuteWithParameter(statement string, params map[string]any) (Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteWithParameter", statement, params)
+	ret0, _ := ret[0].(Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteWithParameter indicates an expected call of ExecuteWithParameter.
+func (mr *MockSessionMockRecorder) ExecuteWithParameter(statement, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWithParameter", reflect.TypeOf((*MockSession)(nil).ExecuteWithParameter), statement, params)
+}
+
 // Open mocks base method.
 func (m *MockSession) Open() error {
 	m.ctrl.T.Helper()
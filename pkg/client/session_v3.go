@@ -8,6 +8,7 @@ import (
 
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 	nebula "github.com/vesoft-inc/nebula-go/v3"
+	nebulatypes "github.com/vesoft-inc/nebula-go/v3/nebula"
 )
 
 type (
@@ -73,6 +74,32 @@ func (s *defaultSessionV3) Execute(statement string) (Response, error) {
 	return newResponseV3(rs, time.Since(startTime)), nil
 }
 
+func (s *defaultSessionV3) ExecuteWithParameter(statement string, params map[string]any) (Response, error) {
+	startTime := time.Now()
+	rs, err := s.session.ExecuteWithParameter(statement, paramsV3(params))
+	if err != nil {
+		return nil, err
+	}
+	return newResponseV3(rs, time.Since(startTime)), nil
+}
+
+// paramsV3 converts the int64 and float64 params to the values, which are not converted as expected by the nebula-go,
+// such as the float64 without the fractional part is converted to an integer.
+func paramsV3(params map[string]any) map[string]any {
+	converted := make(map[string]any, len(params))
+	for name, param := range params {
+		switch v := param.(type) {
+		case int64:
+			converted[name] = nebulatypes.Value{IVal: &v}
+		case float64:
+			converted[name] = nebulatypes.Value{FVal: &v}
+		default:
+			converted[name] = param
+		}
+	}
+	return converted
+}
+
 func (s *defaultSessionV3) Close() error {
 	s.session.Release()
 	return nil
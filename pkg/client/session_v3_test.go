@@ -7,6 +7,7 @@ import (
 
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 	nebula "github.com/vesoft-inc/nebula-go/v3"
+	nebulatypes "github.com/vesoft-inc/nebula-go/v3/nebula"
 
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
@@ -14,6 +15,22 @@ import (
 )
 
 var _ = Describe("SessionV3", func() {
+	It("paramsV3", func() {
+		i, f := int64(1), float64(2)
+		params := paramsV3(map[string]any{
+			"int":    int64(1),
+			"float":  float64(2),
+			"string": "a",
+			"null":   nil,
+		})
+		Expect(params).To(Equal(map[string]any{
+			"int":    nebulatypes.Value{IVal: &i},
+			"float":  nebulatypes.Value{FVal: &f},
+			"string": "a",
+			"null":   nil,
+		}))
+	})
+
 	It("success", func() {
 		session := newSessionV3(HostAddress{}, "user", "password", nil, nil)
 		pool := &nebula.ConnectionPool{}
@@ -26,6 +43,7 @@ var _ = Describe("SessionV3", func() {
 		patches.ApplyMethodReturn(pool, "GetSession", nSession, nil)
 
 		patches.ApplyMethodReturn(nSession, "Execute", &nebula.ResultSet{}, nil)
+		patches.ApplyMethodReturn(nSession, "ExecuteWithParameter", &nebula.ResultSet{}, nil)
 		patches.ApplyMethodReturn(nSession, "Release")
 
 		err := session.Open()
@@ -33,6 +51,9 @@ var _ = Describe("SessionV3", func() {
 		resp, err := session.Execute("")
 		Expect(err).NotTo(HaveOccurred())
 		Expect(resp).NotTo(BeNil())
+		resp, err = session.ExecuteWithParameter("", map[string]any{"p0": "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(resp).NotTo(BeNil())
 
 		err = session.Close()
 		Expect(err).NotTo(HaveOccurred())
@@ -65,6 +86,7 @@ var _ = Describe("SessionV3", func() {
 		patches.ApplyMethodReturn(pool, "GetSession", nSession, nil)
 
 		patches.ApplyMethodReturn(nSession, "Execute", nil, stderrors.New("execute failed"))
+		patches.ApplyMethodReturn(nSession, "ExecuteWithParameter", nil, stderrors.New("execute failed"))
 		patches.ApplyMethodReturn(nSession, "Release")
 
 		err = session.Open()
@@ -72,6 +94,9 @@ var _ = Describe("SessionV3", func() {
 		resp, err := session.Execute("")
 		Expect(err).To(HaveOccurred())
 		Expect(resp).To(BeNil())
+		resp, err = session.ExecuteWithParameter("", nil)
+		Expect(err).To(HaveOccurred())
+		Expect(resp).To(BeNil())
 
 		err = session.Close()
 		Expect(err).NotTo(HaveOccurred())
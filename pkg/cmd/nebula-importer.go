@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	"github.com/lucky-xin/nebula-importer/pkg/cmd/common"
@@ -18,19 +22,22 @@ import (
 type (
 	ImporterOptions struct {
 		common.IOStreams
-		Arguments    []string
-		ConfigFile   string
-		cfg          config.Configurator
-		logger       logger.Logger
-		useNopLogger bool // for test
-		pool         client.Pool
-		mgr          manager.Manager
+		Arguments        []string
+		ConfigFile       string
+		SummaryFile      string
+		SummaryMaxErrors int
+		cfg              config.Configurator
+		logger           logger.Logger
+		useNopLogger     bool // for test
+		pool             client.Pool
+		mgr              manager.Manager
 	}
 )
 
 func NewImporterOptions(streams common.IOStreams) *ImporterOptions {
 	return &ImporterOptions{
-		IOStreams: streams,
+		IOStreams:        streams,
+		SummaryMaxErrors: DefaultSummaryMaxErrors,
 	}
 }
 
@@ -48,18 +55,20 @@ func NewImporterCommand(o *ImporterOptions) *cobra.Command {
 		Use:   "nebula-importer",
 		Short: `The NebulaGraph Importer Tool.`,
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			startTime := time.Now()
 			defer func() {
+				l := o.logger
+				if l == nil || o.useNopLogger {
+					l = logger.NopLogger
+				}
 				if err != nil {
-					l := o.logger
-
-					if l == nil || o.useNopLogger {
-						l = logger.NopLogger
-					}
-
 					e := errors.NewImportError(err)
 					fields := logger.MapToFields(e.Fields())
 					l.SkipCaller(1).WithError(e.Cause()).Error("failed to execute", fields...)
 				}
+				if errSummary := o.writeSummary(startTime, err); errSummary != nil {
+					l.WithError(errSummary).Error("failed to write summary file")
+				}
 				if o.pool != nil {
 					_ = o.pool.Close()
 				}
@@ -68,11 +77,16 @@ func NewImporterCommand(o *ImporterOptions) *cobra.Command {
 					_ = o.logger.Close()
 				}
 			}()
+			// Stop the build and import promptly on Ctrl-C or SIGTERM.
+			ctx, stop := signal.NotifyContext(cmdContext(cmd), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			cmd.SetContext(ctx)
+
 			err = o.Complete(cmd, args)
 			if err != nil {
 				return err
 			}
-			err = o.Validate()
+			err = o.Validate(ctx)
 			if err != nil {
 				return err
 			}
@@ -93,18 +107,22 @@ func (*ImporterOptions) Complete(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
-func (o *ImporterOptions) Validate() error {
+func (o *ImporterOptions) Validate(ctx context.Context) error {
 	cfg, err := config.FromFile(o.ConfigFile)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w: %w", errors.ErrInvalidConfig, err)
 	}
 
 	if err = cfg.Optimize(o.ConfigFile); err != nil {
-		return err
+		return fmt.Errorf("%w: %w", errors.ErrInvalidConfig, err)
 	}
 
-	if err = cfg.Build(); err != nil {
-		return err
+	if err = cfg.Build(ctx); err != nil {
+		// Canceled while building, such as loading the lookups, which is not an invalid config.
+		if ctx.Err() != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %w", errors.ErrInvalidConfig, err)
 	}
 
 	o.cfg = cfg
@@ -115,16 +133,20 @@ func (o *ImporterOptions) Validate() error {
 	return nil
 }
 
-func (o *ImporterOptions) Run(_ *cobra.Command, _ []string) error {
-	if err := o.mgr.Start(); err != nil {
+func (o *ImporterOptions) Run(cmd *cobra.Command, _ []string) error {
+	if err := o.mgr.Start(cmdContext(cmd)); err != nil {
 		return err
 	}
 	//revive:disable-next-line:if-return
 	if err := o.mgr.Wait(); err != nil {
 		return err
 	}
-	if o.mgr.Stats().IsFailed() {
-		return fmt.Errorf("failed to import")
+	if s := o.mgr.Stats(); s.IsFailed() {
+		if s.TotalRecords > 0 && s.FailedRecords == s.TotalRecords {
+			return errors.NewImportError(errors.ErrTotalFailure, "failed to import all %d records", s.TotalRecords)
+		}
+		return errors.NewImportError(errors.ErrPartialFailure, "failed to import %d of %d records",
+			s.FailedRecords, s.TotalRecords)
 	}
 	return nil
 }
@@ -132,4 +154,16 @@ func (o *ImporterOptions) Run(_ *cobra.Command, _ []string) error {
 func (o *ImporterOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&o.ConfigFile, "config", "c", o.ConfigFile,
 		"specify nebula-importer configure file")
+	cmd.Flags().StringVar(&o.SummaryFile, "summary-file", o.SummaryFile,
+		"specify the file to write the json summary of the import")
+	cmd.Flags().IntVar(&o.SummaryMaxErrors, "summary-max-errors", o.SummaryMaxErrors,
+		"specify the maximum number of errors in the summary")
+}
+
+// cmdContext returns the context of the command, the background if none.
+func cmdContext(cmd *cobra.Command) context.Context {
+	if cmd != nil && cmd.Context() != nil {
+		return cmd.Context()
+	}
+	return context.Background()
 }
@@ -44,11 +44,11 @@ var _ = Describe("ImporterCommand", func() {
 
 		mockClientPool.EXPECT().GetClient(gomock.Any()).AnyTimes().Return(mockClient, nil)
 		mockClientPool.EXPECT().Open().AnyTimes().Return(nil)
-		mockClientPool.EXPECT().Execute(gomock.Any()).AnyTimes().Return(mockResponse, nil)
+		mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).AnyTimes().Return(mockResponse, nil)
 		mockClientPool.EXPECT().Close().AnyTimes().Return(nil)
 
 		mockClient.EXPECT().Open().AnyTimes().Return(nil)
-		mockClient.EXPECT().Execute(gomock.Any()).AnyTimes().Return(mockResponse, nil)
+		mockClient.EXPECT().Execute(gomock.Any(), gomock.Any()).AnyTimes().Return(mockResponse, nil)
 		mockClient.EXPECT().Close().AnyTimes().Return(nil)
 
 		mockResponse.EXPECT().IsSucceed().AnyTimes().Return(true)
@@ -107,7 +107,7 @@ var _ = Describe("ImporterCommand", func() {
 	It("manager start failed", func() {
 		patches.ApplyFuncReturn(manager.NewWithOpts, mockManager)
 		mockManager.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
-		mockManager.EXPECT().Start().Return(stderrors.New("test error"))
+		mockManager.EXPECT().Start(gomock.Any()).Return(stderrors.New("test error"))
 
 		o := NewImporterOptions(common.IOStreams{
 			In:     os.Stdin,
@@ -127,7 +127,7 @@ var _ = Describe("ImporterCommand", func() {
 	It("manager wait failed", func() {
 		patches.ApplyFuncReturn(manager.NewWithOpts, mockManager)
 		mockManager.EXPECT().Import(gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes().Return(nil)
-		mockManager.EXPECT().Start().Return(nil)
+		mockManager.EXPECT().Start(gomock.Any()).Return(nil)
 		mockManager.EXPECT().Wait().Return(stderrors.New("test error"))
 
 		o := NewImporterOptions(common.IOStreams{
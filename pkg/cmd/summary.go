@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/cmd/util"
+	"github.com/lucky-xin/nebula-importer/pkg/manager"
+	"github.com/lucky-xin/nebula-importer/pkg/stats"
+)
+
+const DefaultSummaryMaxErrors = 10
+
+var summaryStatuses = map[int]string{
+	util.ExitCodeSucceeded:      "succeeded",
+	util.ExitCodeFailed:         "failed",
+	util.ExitCodeAborted:        "aborted",
+	util.ExitCodeInvalidConfig:  "invalidConfig",
+	util.ExitCodeConnectFailed:  "connectFailed",
+	util.ExitCodePartialFailure: "partialFailure",
+	util.ExitCodeTotalFailure:   "totalFailure",
+}
+
+type (
+	// Summary is the machine-readable result of a run, written to the summary file.
+	Summary struct {
+		Status    string                 `json:"status"`
+		ExitCode  int                    `json:"exitCode"`
+		Error     string                 `json:"error,omitempty"`
+		StartTime time.Time              `json:"startTime"`
+		EndTime   time.Time              `json:"endTime"`
+		Duration  string                 `json:"duration"`
+		Stats     *stats.Stats           `json:"stats,omitempty"`
+		Sources   []*manager.SourceStats `json:"sources,omitempty"`
+		Spaces    []*manager.SpaceStats  `json:"spaces,omitempty"`
+		Errors    []string               `json:"errors,omitempty"`
+	}
+)
+
+func (o *ImporterOptions) buildSummary(startTime time.Time, runErr error) *Summary {
+	endTime := time.Now()
+	exitCode := util.ExitCode(runErr)
+	summary := &Summary{
+		Status:    summaryStatuses[exitCode],
+		ExitCode:  exitCode,
+		StartTime: startTime,
+		EndTime:   endTime,
+		Duration:  endTime.Sub(startTime).String(),
+	}
+	if runErr != nil {
+		summary.Error = runErr.Error()
+	}
+	if o.mgr != nil {
+		summary.Stats = o.mgr.Stats()
+		summary.Sources = o.mgr.SourcesStats()
+		summary.Spaces = o.mgr.SpacesStats()
+		for _, e := range o.mgr.Errors() {
+			if len(summary.Errors) >= o.SummaryMaxErrors {
+				break
+			}
+			summary.Errors = append(summary.Errors, e.Error())
+		}
+	}
+	return summary
+}
+
+func (o *ImporterOptions) writeSummary(startTime time.Time, runErr error) error {
+	if o.SummaryFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(o.buildSummary(startTime, runErr), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(o.SummaryFile, data, 0o644)
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/cmd/common"
+	"github.com/lucky-xin/nebula-importer/pkg/cmd/util"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/manager"
+	"github.com/lucky-xin/nebula-importer/pkg/stats"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Summary", func() {
+	var (
+		ctrl        *gomock.Controller
+		mockManager *manager.MockManager
+		o           *ImporterOptions
+	)
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockManager = manager.NewMockManager(ctrl)
+		o = NewImporterOptions(common.IOStreams{
+			In:     os.Stdin,
+			Out:    os.Stdout,
+			ErrOut: os.Stderr,
+		})
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("without manager", func() {
+		summary := o.buildSummary(time.Now(), errors.ErrInvalidConfig)
+		Expect(summary.Status).To(Equal("invalidConfig"))
+		Expect(summary.ExitCode).To(Equal(util.ExitCodeInvalidConfig))
+		Expect(summary.Error).To(Equal(errors.ErrInvalidConfig.Error()))
+		Expect(summary.Stats).To(BeNil())
+		Expect(summary.Sources).To(BeNil())
+		Expect(summary.Spaces).To(BeNil())
+		Expect(summary.Errors).To(BeNil())
+	})
+
+	It("with manager", func() {
+		o.mgr = mockManager
+		o.SummaryMaxErrors = 2
+		mockManager.EXPECT().Stats().Return(&stats.Stats{FailedRecords: 1, TotalRecords: 10})
+		mockManager.EXPECT().SourcesStats().Return([]*manager.SourceStats{
+			{Name: "source1", Stats: &stats.Stats{FailedRecords: 1, TotalRecords: 4}},
+			{Name: "source2", Space: "space2", Stats: &stats.Stats{TotalRecords: 6}},
+		})
+		mockManager.EXPECT().SpacesStats().Return([]*manager.SpaceStats{
+			{Space: "space1", Stats: &stats.Stats{FailedRecords: 1, TotalRecords: 4}},
+			{Space: "space2", Stats: &stats.Stats{TotalRecords: 6}},
+		})
+		mockManager.EXPECT().Errors().Return([]error{
+			stderrors.New("error1"),
+			stderrors.New("error2"),
+			stderrors.New("error3"),
+		})
+
+		summary := o.buildSummary(time.Now(), errors.ErrPartialFailure)
+		Expect(summary.Status).To(Equal("partialFailure"))
+		Expect(summary.ExitCode).To(Equal(util.ExitCodePartialFailure))
+		Expect(summary.Stats.FailedRecords).To(Equal(int64(1)))
+		Expect(summary.Sources).To(HaveLen(2))
+		Expect(summary.Spaces).To(HaveLen(2))
+		Expect(summary.Errors).To(Equal([]string{"error1", "error2"}))
+	})
+
+	It("write summary file", func() {
+		tmpdir, err := os.MkdirTemp("", "test")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(tmpdir)
+
+		o.SummaryFile = filepath.Join(tmpdir, "summary.json")
+		err = o.writeSummary(time.Now(), nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := os.ReadFile(o.SummaryFile)
+		Expect(err).NotTo(HaveOccurred())
+		var summary Summary
+		err = json.Unmarshal(data, &summary)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Status).To(Equal("succeeded"))
+		Expect(summary.ExitCode).To(Equal(util.ExitCodeSucceeded))
+	})
+
+	It("no summary file", func() {
+		err := o.writeSummary(time.Now(), nil)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("Run outcome",
+		func(s *stats.Stats, expectCode int) {
+			o.mgr = mockManager
+			mockManager.EXPECT().Start(gomock.Any()).Return(nil)
+			mockManager.EXPECT().Wait().Return(nil)
+			mockManager.EXPECT().Stats().Return(s)
+
+			err := o.Run(nil, nil)
+			Expect(util.ExitCode(err)).To(Equal(expectCode))
+		},
+		Entry("succeeded", &stats.Stats{TotalRecords: 10}, util.ExitCodeSucceeded),
+		Entry("partial failure", &stats.Stats{FailedRecords: 1, TotalRecords: 10}, util.ExitCodePartialFailure),
+		Entry("total failure", &stats.Stats{FailedRecords: 10, TotalRecords: 10}, util.ExitCodeTotalFailure),
+	)
+})
@@ -1,9 +1,29 @@
 package util
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+const (
+	// ExitCodeSucceeded is the exit code when all the records are imported.
+	ExitCodeSucceeded = 0
+	// ExitCodeFailed is the exit code when the import failed for other reasons.
+	ExitCodeFailed = 1
+	// ExitCodeAborted is the exit code when the import is aborted because of too many failures.
+	ExitCodeAborted = 2
+	// ExitCodeInvalidConfig is the exit code when the config is invalid.
+	ExitCodeInvalidConfig = 3
+	// ExitCodeConnectFailed is the exit code when failed to connect to the graph.
+	ExitCodeConnectFailed = 4
+	// ExitCodePartialFailure is the exit code when some of the records failed to import.
+	ExitCodePartialFailure = 5
+	// ExitCodeTotalFailure is the exit code when all the records failed to import.
+	ExitCodeTotalFailure = 6
 )
 
 var (
@@ -12,12 +32,29 @@ var (
 )
 
 func CheckErr(err error) {
-	switch err.(type) {
-	case nil:
+	if err == nil {
 		return
-	default:
-		fatal(fmt.Sprintf("%+v", err), 1)
 	}
+	fatal(fmt.Sprintf("%+v", err), ExitCode(err))
+}
+
+// ExitCode returns the exit code of the process according to the error.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeSucceeded
+	case stderrors.Is(err, errors.ErrTooManyFailures):
+		return ExitCodeAborted
+	case stderrors.Is(err, errors.ErrInvalidConfig):
+		return ExitCodeInvalidConfig
+	case stderrors.Is(err, errors.ErrConnectFailed):
+		return ExitCodeConnectFailed
+	case stderrors.Is(err, errors.ErrPartialFailure):
+		return ExitCodePartialFailure
+	case stderrors.Is(err, errors.ErrTotalFailure):
+		return ExitCodeTotalFailure
+	}
+	return ExitCodeFailed
 }
 
 func fatal(msg string, code int) {
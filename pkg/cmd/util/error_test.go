@@ -2,8 +2,11 @@ package util
 
 import (
 	stderrors "errors"
+	"fmt"
 	"io"
 
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
 	"github.com/agiledragon/gomonkey/v2"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -34,4 +37,33 @@ var _ = Describe("CheckErr", func() {
 		Expect(isFprintCalled).To(BeTrue())
 		Expect(exitCode).To(Equal(1))
 	})
+
+	It("too many failures", func() {
+		patches := gomonkey.NewPatches()
+		defer patches.Reset()
+
+		var exitCode int
+		patches.ApplyGlobalVar(&fnFprint, func(io.Writer, ...any) (int, error) {
+			return 0, nil
+		})
+		patches.ApplyGlobalVar(&fnExit, func(code int) {
+			exitCode = code
+		})
+
+		CheckErr(errors.NewImportError(errors.ErrTooManyFailures, "abort"))
+		Expect(exitCode).To(Equal(ExitCodeAborted))
+	})
 })
+
+var _ = DescribeTable("ExitCode",
+	func(err error, expectCode int) {
+		Expect(ExitCode(err)).To(Equal(expectCode))
+	},
+	Entry("nil", nil, ExitCodeSucceeded),
+	Entry("unknown", stderrors.New("test error"), ExitCodeFailed),
+	Entry("too many failures", errors.NewImportError(errors.ErrTooManyFailures), ExitCodeAborted),
+	Entry("invalid config", fmt.Errorf("%w: test error", errors.ErrInvalidConfig), ExitCodeInvalidConfig),
+	Entry("connect failed", fmt.Errorf("%w: test error", errors.ErrConnectFailed), ExitCodeConnectFailed),
+	Entry("partial failure", errors.ErrPartialFailure, ExitCodePartialFailure),
+	Entry("total failure", errors.ErrTotalFailure, ExitCodeTotalFailure),
+)
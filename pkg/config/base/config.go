@@ -1,6 +1,8 @@
 package configbase
 
 import (
+	"context"
+
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 	"github.com/lucky-xin/nebula-importer/pkg/manager"
@@ -8,7 +10,8 @@ import (
 
 type Configurator interface {
 	Optimize(configPath string) error
-	Build() error
+	// Build builds the logger, client pool and manager, the ctx cancels the long builds such as loading the lookups.
+	Build(ctx context.Context) error
 	GetLogger() logger.Logger
 	GetClientPool() client.Pool
 	GetManager() manager.Manager
@@ -0,0 +1,144 @@
+package configbase
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/logger"
+	"github.com/lucky-xin/nebula-importer/pkg/lookup"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	"github.com/lucky-xin/nebula-importer/pkg/reader"
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+)
+
+type (
+	// Lookup loads a keyed dictionary from the source, which is available as `lookup(name, key)` in the expressions.
+	Lookup struct {
+		Source       `yaml:",inline" json:",inline"`
+		Name         string  `yaml:"name" json:"name"`
+		KeyIndex     int     `yaml:"keyIndex,omitempty" json:"keyIndex,omitempty,optional"`
+		KeyColumn    string  `yaml:"keyColumn,omitempty" json:"keyColumn,omitempty,optional"`
+		ValueIndex   int     `yaml:"valueIndex,omitempty" json:"valueIndex,omitempty,optional"`
+		ValueColumn  string  `yaml:"valueColumn,omitempty" json:"valueColumn,omitempty,optional"`
+		Storage      string  `yaml:"storage,omitempty" json:"storage,omitempty,optional,default=memory"`
+		MissPolicy   string  `yaml:"missPolicy,omitempty" json:"missPolicy,omitempty,optional,default=error"`
+		DefaultValue *string `yaml:"defaultValue,omitempty" json:"defaultValue,omitempty,optional"`
+	}
+
+	Lookups []Lookup
+)
+
+// Build loads all the lookups, the names must be unique.
+func (ls Lookups) Build(ctx context.Context, l logger.Logger) (picker.Lookups, error) {
+	if len(ls) == 0 {
+		return nil, nil
+	}
+	lookups := make(picker.Lookups, len(ls))
+	for i := range ls {
+		if _, ok := lookups[ls[i].Name]; ok {
+			lookup.CloseAll(lookups)
+			return nil, errors.NewImportError(errors.ErrInvalidConfig, "duplicate lookup %q", ls[i].Name)
+		}
+		lk, err := ls[i].Build(ctx, l)
+		if err != nil {
+			lookup.CloseAll(lookups)
+			return nil, err
+		}
+		lookups[ls[i].Name] = lk
+	}
+	return lookups, nil
+}
+
+func (lc *Lookup) Build(ctx context.Context, l logger.Logger) (*lookup.Lookup, error) {
+	if lc.Name == "" {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "no lookup name")
+	}
+	keyIndex, valueIndex, err := lc.indices()
+	if err != nil {
+		return nil, lc.importError(err, "resolve the columns failed")
+	}
+
+	dict, err := lookup.NewDictionary(lc.Storage)
+	if err != nil {
+		return nil, lc.importError(err)
+	}
+	lk, err := lookup.New(lc.Name, dict,
+		lookup.WithMissPolicy(lc.MissPolicy),
+		lookup.WithDefaultValue(lc.DefaultValue),
+	)
+	if err != nil {
+		_ = dict.Close()
+		return nil, err
+	}
+
+	if err = lc.load(ctx, l, keyIndex, valueIndex, dict); err != nil {
+		_ = dict.Close()
+		return nil, lc.importError(err, "load failed")
+	}
+	l.Info("lookup loaded",
+		logger.Field{Key: "lookup", Value: lc.Name},
+		logger.Field{Key: "size", Value: lk.Len()},
+	)
+	return lk, nil
+}
+
+func (lc *Lookup) load(ctx context.Context, l logger.Logger, keyIndex, valueIndex int, dict lookup.Dictionary) error {
+	src, brr, err := lc.BuildSourceAndReader(reader.WithLogger(l))
+	if err != nil {
+		return err
+	}
+	if err = src.Open(); err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	return lookup.Load(ctx, brr, keyIndex, valueIndex, dict)
+}
+
+// indices returns the key and value indices, resolving the column names by the header.
+func (lc *Lookup) indices() (keyIndex, valueIndex int, err error) {
+	keyIndex, valueIndex = lc.KeyIndex, lc.ValueIndex
+	if lc.KeyColumn == "" && lc.ValueColumn == "" {
+		return keyIndex, valueIndex, nil
+	}
+	header, err := lc.Header()
+	if err != nil {
+		return 0, 0, err
+	}
+	resolve := func(name string, index *int) error {
+		if name == "" {
+			return nil
+		}
+		for i, column := range header {
+			if column == name {
+				*index = i
+				return nil
+			}
+		}
+		return errors.NewImportError(errors.ErrUnknownColumn, "unknown column %q", name)
+	}
+	if err = resolve(lc.KeyColumn, &keyIndex); err != nil {
+		return 0, 0, err
+	}
+	if err = resolve(lc.ValueColumn, &valueIndex); err != nil {
+		return 0, 0, err
+	}
+	return keyIndex, valueIndex, nil
+}
+
+func (lc *Lookup) importError(err error, formatWithArgs ...any) *errors.ImportError {
+	return errors.AsOrNewImportError(err, formatWithArgs...).AppendMessage("lookup %q", lc.Name)
+}
+
+// OptimizePath optimizes relative paths base to the configuration file path
+func (ls Lookups) OptimizePath(configPath string) error {
+	configPathDir := filepath.Dir(configPath)
+	for i := range ls {
+		if ls[i].Local != nil {
+			ls[i].Local.Path = utils.RelativePathBaseOn(configPathDir, ls[i].Local.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package configbase
+
+import (
+	"context"
+	stderrors "errors"
+	"os"
+	"path/filepath"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/logger"
+	"github.com/lucky-xin/nebula-importer/pkg/lookup"
+	"github.com/lucky-xin/nebula-importer/pkg/source"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Lookups", func() {
+	var path string
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "users.csv")
+		Expect(os.WriteFile(path, []byte("id,name\n1,Tom\n2,Jerry\n1,Spike\n"), 0o600)).To(Succeed())
+	})
+
+	newLookup := func(name string) Lookup {
+		lc := Lookup{Name: name}
+		lc.Local = &source.LocalConfig{Path: path}
+		lc.CSV = &source.CSVConfig{Delimiter: ",", WithHeader: true}
+		return lc
+	}
+
+	DescribeTable("Build",
+		func(storage string) {
+			lc := newLookup("users")
+			lc.KeyColumn = "id"
+			lc.ValueColumn = "name"
+			lc.Storage = storage
+			lookups, err := Lookups{lc}.Build(context.Background(), logger.NopLogger)
+			Expect(err).NotTo(HaveOccurred())
+			defer lookup.CloseAll(lookups)
+			Expect(lookups).To(HaveLen(1))
+
+			value, err := lookups["users"].Lookup("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("Tom"))
+
+			value, err = lookups["users"].Lookup("2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal("Jerry"))
+
+			value, err = lookups["users"].Lookup("3")
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrLookupMiss)).To(BeTrue())
+			Expect(value).To(BeNil())
+		},
+		Entry("memory", lookup.StorageMemory),
+		Entry("disk", lookup.StorageDisk),
+	)
+
+	It("Build by indices", func() {
+		lc := newLookup("users")
+		lc.ValueIndex = 1
+		lc.MissPolicy = lookup.MissPolicyNull
+		lookups, err := Lookups{lc}.Build(context.Background(), logger.NopLogger)
+		Expect(err).NotTo(HaveOccurred())
+		value, err := lookups["users"].Lookup("2")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal("Jerry"))
+		value, err = lookups["users"].Lookup("3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(BeNil())
+	})
+
+	It("Build empty", func() {
+		lookups, err := Lookups{}.Build(context.Background(), logger.NopLogger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lookups).To(BeNil())
+	})
+
+	DescribeTable("Build failed",
+		func(fn func(ls Lookups), expectErr error) {
+			ls := Lookups{newLookup("users")}
+			fn(ls)
+			lookups, err := ls.Build(context.Background(), logger.NopLogger)
+			Expect(err).To(HaveOccurred())
+			if expectErr != nil {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+			Expect(lookups).To(BeNil())
+		},
+		Entry("no name", func(ls Lookups) { ls[0].Name = "" }, errors.ErrInvalidConfig),
+		Entry("unknown column", func(ls Lookups) { ls[0].ValueColumn = "age" }, errors.ErrUnknownColumn),
+		Entry("unsupported storage", func(ls Lookups) { ls[0].Storage = "unsupported" }, errors.ErrUnsupportedLookupStorage),
+		Entry("unsupported miss policy", func(ls Lookups) { ls[0].MissPolicy = "unsupported" }, errors.ErrUnsupportedMissPolicy),
+		Entry("no record", func(ls Lookups) { ls[0].ValueIndex = 2 }, errors.ErrNoRecord),
+		Entry("not exists", func(ls Lookups) { ls[0].Local.Path += ".not-exists" }, nil),
+	)
+
+	It("Build duplicate", func() {
+		lookups, err := Lookups{newLookup("users"), newLookup("users")}.Build(context.Background(), logger.NopLogger)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+		Expect(lookups).To(BeNil())
+	})
+
+	It("OptimizePath", func() {
+		lc := Lookup{}
+		lc.Local = &source.LocalConfig{Path: "users.csv"}
+		ls := Lookups{lc, {}}
+		Expect(ls.OptimizePath("/dir/config.yaml")).To(Succeed())
+		Expect(ls[0].Local.Path).To(Equal("/dir/users.csv"))
+	})
+})
@@ -3,6 +3,7 @@ package configbase
 import (
 	"time"
 
+	"github.com/lucky-xin/nebula-importer/pkg/importer"
 	"github.com/lucky-xin/nebula-importer/pkg/manager"
 )
 
@@ -14,5 +15,17 @@ type (
 		StatsInterval       time.Duration `yaml:"statsInterval,omitempty" json:"statsInterval,omitempty,optional,default=10000000000"`
 		Hooks               manager.Hooks `yaml:"hooks,omitempty" json:"hooks,omitempty,optional"`
 		RecordStats         bool          `yaml:"recordStats,omitempty" json:"recordStats,omitempty,optional"`
+		// SplitFailedBatch splits the batch failed with a permanent error to find out the bad records.
+		SplitFailedBatch bool `yaml:"splitFailedBatch,omitempty" json:"splitFailedBatch,omitempty,optional"`
+		// MaxFailedRecords aborts the import once the failed records exceed it, 0 means no limit.
+		MaxFailedRecords int64 `yaml:"maxFailedRecords,omitempty" json:"maxFailedRecords,omitempty,optional"`
+		// MaxFailedRatio aborts the import once the ratio of failed records exceeds it, 0 means no limit.
+		MaxFailedRatio float64 `yaml:"maxFailedRatio,omitempty" json:"maxFailedRatio,omitempty,optional"`
+		// MaxFailedRatioMinRecords is the minimum number of processed records before checking the MaxFailedRatio.
+		MaxFailedRatioMinRecords int64 `yaml:"maxFailedRatioMinRecords,omitempty" json:"maxFailedRatioMinRecords,omitempty,optional"`
+		// Retry is the retry policy for the failed statements which are neither permanent nor transient.
+		Retry *importer.RetryPolicy `yaml:"retry,omitempty" json:"retry,omitempty,optional"`
+		// TransientRetry is the retry policy for the transient errors, such as rpc failure and leader changed.
+		TransientRetry *importer.RetryPolicy `yaml:"transientRetry,omitempty" json:"transientRetry,omitempty,optional"`
 	}
 )
@@ -13,11 +13,12 @@ var sourceNew = source.New
 type (
 	Source struct {
 		source.Config     `yaml:",inline" json:",inline"`
-		Batch             int              `yaml:"batch,omitempty" json:"batch,omitempty,optional,default=200"`
-		DatasourceId      *string          `yaml:"datasourceId,omitempty" json:"datasourceId,optional,omitempty"`
-		DatasourceKeyFile *string          `yaml:"datasourceKeyFile,omitempty" json:"datasourceKeyFile,optional,omitempty"`
-		Convert           string           `yaml:"convert,omitempty" json:"convert,optional,omitempty,default=none"`
-		Convertor         reader.Convertor `yaml:"-" json:"-"`
+		Batch             int                    `yaml:"batch,omitempty" json:"batch,omitempty,optional,default=200"`
+		DatasourceId      *string                `yaml:"datasourceId,omitempty" json:"datasourceId,optional,omitempty"`
+		DatasourceKeyFile *string                `yaml:"datasourceKeyFile,omitempty" json:"datasourceKeyFile,optional,omitempty"`
+		Convert           string                 `yaml:"convert,omitempty" json:"convert,optional,omitempty,default=none"`
+		ConvertOptions    *reader.ConvertOptions `yaml:"convertOptions,omitempty" json:"convertOptions,optional,omitempty"`
+		Convertor         reader.Convertor       `yaml:"-" json:"-"`
 	}
 )
 
@@ -26,6 +27,11 @@ func (s *Source) BuildSourceAndReader(opts ...reader.Option) (
 	reader.BatchRecordReader,
 	error,
 ) {
+	if s.CDC != nil {
+		if err := reader.ValidateCDCConfig(s.CDC); err != nil {
+			return nil, nil, err
+		}
+	}
 	sourceConfig := s.Config
 	src, err := sourceNew(&sourceConfig)
 	if err != nil {
@@ -35,6 +41,19 @@ func (s *Source) BuildSourceAndReader(opts ...reader.Option) (
 		// Override the batch in the manager.
 		opts = append(opts, reader.WithBatch(s.Batch))
 	}
+	switch {
+	case s.Convertor != nil:
+		opts = append(opts, reader.WithConvertor(s.Convertor))
+	case reader.IsBuiltinConvertor(s.Convert):
+		// The built-in convertor is built for the reader, and closed with it.
+		convertor, err := reader.NewConvertor(s.Convert, s.ConvertOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, reader.WithOwnedConvertor(convertor))
+	default:
+		opts = append(opts, reader.WithConvertor(reader.GetConvertor(s.Convert)))
+	}
 	if ss, ok := src.(*source.SQLSource); ok {
 		return ss, reader.NewSQLBatchRecordReader(ss, s.Convert, opts...), nil
 	}
@@ -43,6 +62,32 @@ func (s *Source) BuildSourceAndReader(opts ...reader.Option) (
 	return src, brr, nil
 }
 
+// Header returns the column names of the source, the csv header, the sql columns or the cdc fields.
+func (s *Source) Header() ([]string, error) {
+	if s.CDC != nil {
+		if err := reader.ValidateCDCConfig(s.CDC); err != nil {
+			return nil, err
+		}
+		return reader.CDCHeader(s.CDC), nil
+	}
+	sourceConfig := s.Config
+	src, err := sourceNew(&sourceConfig)
+	if err != nil {
+		return nil, err
+	}
+	if err = src.Open(); err != nil {
+		return nil, err
+	}
+	defer func(src source.Source) {
+		_ = src.Close()
+	}(src)
+
+	if ss, ok := src.(*source.SQLSource); ok {
+		return ss.Columns()
+	}
+	return reader.ReadCSVHeader(src)
+}
+
 func (s *Source) Glob() ([]*Source, bool, error) {
 	sourceConfig := s.Config
 	src, err := sourceNew(&sourceConfig)
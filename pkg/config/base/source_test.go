@@ -1,8 +1,11 @@
 package configbase
 
 import (
+	"context"
 	stderrors "errors"
 
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/reader"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
 
 	"github.com/agiledragon/gomonkey/v2"
@@ -54,7 +57,7 @@ var _ = Describe("Source", func() {
 			Expect(src).NotTo(BeNil())
 			Expect(brr).NotTo(BeNil())
 
-			n, records, err := brr.ReadBatch()
+			n, records, err := brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(records).To(HaveLen(7))
 			Expect(n).To(Equal(6 * 7))
@@ -69,6 +72,43 @@ var _ = Describe("Source", func() {
 			Expect(src).To(BeNil())
 			Expect(brr).To(BeNil())
 		})
+
+		It("convert options failed", func() {
+			patches.ApplyGlobalVar(&sourceNew, func(_ *source.Config) (source.Source, error) {
+				return mockSource, nil
+			})
+			s.Convert = reader.ConvertorExplode
+			s.ConvertOptions = &reader.ConvertOptions{Columns: []int{0, 1}}
+			src, brr, err := s.BuildSourceAndReader()
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+			Expect(src).To(BeNil())
+			Expect(brr).To(BeNil())
+		})
+
+		It("cdc config failed", func() {
+			s.CDC = &source.CDCConfig{Format: "maxwell", Fields: []string{"id"}}
+			src, brr, err := s.BuildSourceAndReader()
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+			Expect(src).To(BeNil())
+			Expect(brr).To(BeNil())
+		})
+	})
+
+	It(".Header cdc", func() {
+		s := &Source{Config: source.Config{
+			Local: &source.LocalConfig{Path: "not-exists.json"},
+			CDC:   &source.CDCConfig{Format: "debezium", Fields: []string{"id", "name"}},
+		}}
+		header, err := s.Header()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(header).To(Equal([]string{"__op", "id", "name"}))
+
+		s.CDC.Fields = nil
+		header, err = s.Header()
+		Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+		Expect(header).To(BeNil())
 	})
 
 	Describe(".Glob", func() {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"context"
 	_ "github.com/go-sql-driver/mysql"
 	"os"
 	"testing"
@@ -15,7 +16,7 @@ func TestLocalSource(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = c.Build()
+	err = c.Build(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
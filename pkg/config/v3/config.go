@@ -1,23 +1,28 @@
 package configv3
 
 import (
+	"context"
 	"fmt"
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
+	"github.com/lucky-xin/nebula-importer/pkg/lookup"
 	"github.com/lucky-xin/nebula-importer/pkg/manager"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
 	"github.com/lucky-xin/nebula-importer/pkg/utils"
 )
 
 var _ configbase.Configurator = (*Config)(nil)
 
 type (
-	Client = configbase.Client
-	Log    = configbase.Log
+	Client  = configbase.Client
+	Log     = configbase.Log
+	Lookups = configbase.Lookups
 
 	Config struct {
 		Client  `yaml:"client" json:"client,omitempty,optional"`
 		Manager `yaml:"manager" json:"manager"`
+		Lookups `yaml:"lookups,omitempty" json:"lookups,omitempty,optional"`
 		Sources `yaml:"sources" json:"sources"`
 		*Log    `yaml:"log,omitempty" json:"log,omitempty,optional"`
 
@@ -40,6 +45,10 @@ func (c *Config) Optimize(configPath string) error {
 		return err
 	}
 
+	if err := c.Lookups.OptimizePath(configPath); err != nil {
+		return err
+	}
+
 	//revive:disable-next-line:if-return
 	if err := c.Sources.OptimizePathWildCard(); err != nil {
 		return err
@@ -48,18 +57,29 @@ func (c *Config) Optimize(configPath string) error {
 	return nil
 }
 
-func (c *Config) Build() error {
+func (c *Config) Build(ctx context.Context) error {
 	var (
-		err  error
-		l    logger.Logger
-		pool client.Pool
-		mgr  manager.Manager
+		err        error
+		l          logger.Logger
+		lookups    picker.Lookups
+		pool       client.Pool
+		spacePools map[string]client.Pool
+		mgr        manager.Manager
 	)
 	defer func() {
 		if err != nil {
+			if lookups != nil {
+				lookup.CloseAll(lookups)
+			}
+			for _, d := range c.Sources.DedupClosers() {
+				_ = d.Close()
+			}
 			if pool != nil {
 				_ = pool.Close()
 			}
+			for _, p := range spacePools {
+				_ = p.Close()
+			}
 			if l != nil {
 				_ = l.Close()
 			}
@@ -69,15 +89,30 @@ func (c *Config) Build() error {
 	if err != nil {
 		return err
 	}
+	lookups, err = c.Lookups.Build(ctx, l)
+	if err != nil {
+		return err
+	}
+	for i := range c.Sources {
+		c.Sources[i].lookups = lookups
+	}
 	pool, err = c.BuildClientPool(
 		client.WithLogger(l),
 		client.WithClientInitFunc(c.clientInitFunc),
+		// The importers retry the statements by the retry policies of the manager.
+		client.WithNoRetry(true),
 	)
 	if err != nil {
 		return err
 	}
-	mgr, err = c.Manager.BuildManager(l, pool, c.Sources,
+	spacePools, err = c.buildSpacePools(l)
+	if err != nil {
+		return err
+	}
+	mgr, err = c.Manager.BuildManager(l, pool, spacePools, c.Sources,
 		manager.WithGetClientOptions(client.WithClientInitFunc(nil)), // clean the USE SPACE in 3.x
+		manager.WithClosers(lookup.Closers(lookups)...),
+		manager.WithClosers(c.Sources.DedupClosers()...),
 	)
 	if err != nil {
 		return err
@@ -102,8 +137,55 @@ func (c *Config) GetManager() manager.Manager {
 	return c.mgr
 }
 
+// buildSpacePools builds a client pool for each space other than the spaceName, which is used by the sources or hooks.
+func (c *Config) buildSpacePools(l logger.Logger) (map[string]client.Pool, error) {
+	var spaces []string
+	for i := range c.Sources {
+		spaces = append(spaces, c.Sources[i].Space)
+	}
+	for _, hooks := range [][]*manager.Hook{c.Manager.Hooks.Before, c.Manager.Hooks.After} {
+		for _, hook := range hooks {
+			if hook != nil {
+				spaces = append(spaces, hook.Space)
+			}
+		}
+	}
+
+	var spacePools map[string]client.Pool
+	for _, space := range spaces {
+		if space == "" || space == c.Manager.GraphName {
+			continue
+		}
+		if _, ok := spacePools[space]; ok {
+			continue
+		}
+		pool, err := c.BuildClientPool(
+			client.WithLogger(l),
+			client.WithClientInitFunc(func(cli client.Client) error {
+				return useSpace(cli, space)
+			}),
+			client.WithNoRetry(true),
+		)
+		if err != nil {
+			for _, p := range spacePools {
+				_ = p.Close()
+			}
+			return nil, err
+		}
+		if spacePools == nil {
+			spacePools = make(map[string]client.Pool)
+		}
+		spacePools[space] = pool
+	}
+	return spacePools, nil
+}
+
 func (c *Config) clientInitFunc(cli client.Client) error {
-	resp, err := cli.Execute(fmt.Sprintf("USE %s", utils.ConvertIdentifier(c.Manager.GraphName)))
+	return useSpace(cli, c.Manager.GraphName)
+}
+
+func useSpace(cli client.Client, space string) error {
+	resp, err := cli.Execute(context.Background(), fmt.Sprintf("USE %s", utils.ConvertIdentifier(space)))
 	if err != nil {
 		return err
 	}
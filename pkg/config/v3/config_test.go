@@ -1,11 +1,15 @@
 package configv3
 
 import (
+	"context"
 	stderrors "errors"
+	"os"
 	"path/filepath"
 
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
+	"github.com/lucky-xin/nebula-importer/pkg/logger"
+	"github.com/lucky-xin/nebula-importer/pkg/manager"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
 	specv3 "github.com/lucky-xin/nebula-importer/pkg/spec/v3"
 
@@ -86,21 +90,35 @@ var _ = Describe("Config", func() {
 			c.Log = &Log{
 				Files: []string{filepath.Join("testdata", "not-exists", "1.log")},
 			}
-			Expect(c.Build()).To(HaveOccurred())
+			Expect(c.Build(context.Background())).To(HaveOccurred())
+		})
+
+		It("canceled while loading lookups", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "users.csv")
+			Expect(os.WriteFile(path, []byte("1,Tom\n2,Jerry\n"), 0o600)).To(Succeed())
+			lc := configbase.Lookup{Name: "users", ValueIndex: 1}
+			lc.Local = &source.LocalConfig{Path: path}
+			c.Lookups = Lookups{lc}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := c.Build(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
 		})
 
 		It("BuildClientPool failed", func() {
 			c.Client.Version = "v"
-			Expect(c.Build()).To(HaveOccurred())
+			Expect(c.Build(context.Background())).To(HaveOccurred())
 		})
 
 		It("BuildManager failed", func() {
 			c.Manager.GraphName = ""
-			Expect(c.Build()).To(HaveOccurred())
+			Expect(c.Build(context.Background())).To(HaveOccurred())
 		})
 
 		It("successfully", func() {
-			Expect(c.Build()).NotTo(HaveOccurred())
+			Expect(c.Build(context.Background())).NotTo(HaveOccurred())
 			Expect(c.GetLogger()).NotTo(BeNil())
 			Expect(c.GetClientPool()).NotTo(BeNil())
 			Expect(c.GetManager()).NotTo(BeNil())
@@ -108,6 +126,51 @@ var _ = Describe("Config", func() {
 	})
 })
 
+var _ = Describe("buildSpacePools", func() {
+	It("spaces of sources and hooks", func() {
+		c := Config{
+			Manager: Manager{
+				GraphName: "graphName",
+			},
+			Sources: Sources{
+				{},
+				{Space: "graphName"},
+				{Space: "space1"},
+				{Space: "space1"},
+			},
+		}
+		c.Manager.Hooks.Before = []*manager.Hook{nil, {Space: "space2"}}
+		c.Manager.Hooks.After = []*manager.Hook{{Space: "space1"}}
+
+		spacePools, err := c.buildSpacePools(logger.NopLogger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spacePools).To(HaveLen(2))
+		Expect(spacePools).To(HaveKey("space1"))
+		Expect(spacePools).To(HaveKey("space2"))
+	})
+
+	It("no space", func() {
+		c := Config{
+			Manager: Manager{
+				GraphName: "graphName",
+			},
+			Sources: Sources{{}},
+		}
+		spacePools, err := c.buildSpacePools(logger.NopLogger)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(spacePools).To(BeNil())
+	})
+
+	It("BuildClientPool failed", func() {
+		c := Config{
+			Sources: Sources{{Space: "space1"}},
+		}
+		c.Client.Version = "v"
+		_, err := c.buildSpacePools(logger.NopLogger)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
 var _ = Describe("clientInitFunc", func() {
 	var (
 		c            Config
@@ -127,19 +190,19 @@ var _ = Describe("clientInitFunc", func() {
 	})
 
 	It("Execute failed", func() {
-		mockClient.EXPECT().Execute("USE `graphName`").Return(nil, stderrors.New("execute error"))
+		mockClient.EXPECT().Execute(gomock.Any(), "USE `graphName`").Return(nil, stderrors.New("execute error"))
 		Expect(c.clientInitFunc(mockClient)).To(HaveOccurred())
 	})
 
 	It("Execute IsSucceed false", func() {
-		mockClient.EXPECT().Execute("USE `graphName`").Return(mockResponse, nil)
+		mockClient.EXPECT().Execute(gomock.Any(), "USE `graphName`").Return(mockResponse, nil)
 		mockResponse.EXPECT().IsSucceed().Return(false)
 		mockResponse.EXPECT().GetError().Return(stderrors.New("execute error"))
 		Expect(c.clientInitFunc(mockClient)).To(HaveOccurred())
 	})
 
 	It("successfully", func() {
-		mockClient.EXPECT().Execute("USE `graphName`").Return(mockResponse, nil)
+		mockClient.EXPECT().Execute(gomock.Any(), "USE `graphName`").Return(mockResponse, nil)
 		mockResponse.EXPECT().IsSucceed().Return(true)
 		Expect(c.clientInitFunc(mockClient)).NotTo(HaveOccurred())
 	})
@@ -3,6 +3,7 @@ package configv3
 import (
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
+	"github.com/lucky-xin/nebula-importer/pkg/importer"
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 	"github.com/lucky-xin/nebula-importer/pkg/manager"
 	"github.com/lucky-xin/nebula-importer/pkg/reader"
@@ -18,11 +19,13 @@ type (
 func (m *Manager) BuildManager(
 	l logger.Logger,
 	pool client.Pool,
+	spacePools map[string]client.Pool,
 	sources Sources,
 	opts ...manager.Option,
 ) (manager.Manager, error) {
-	options := make([]manager.Option, 0, 8+len(opts))
+	options := make([]manager.Option, 0, 13+len(spacePools)+len(opts))
 	options = append(options,
+		manager.WithGraphName(m.GraphName),
 		manager.WithClientPool(pool),
 		manager.WithBatch(m.Batch),
 		manager.WithReaderConcurrency(m.ReaderConcurrency),
@@ -32,7 +35,14 @@ func (m *Manager) BuildManager(
 		manager.WithAfterHooks(m.Hooks.After...),
 		manager.WithLogger(l),
 		manager.WithRecordStats(m.RecordStats),
+		manager.WithSplitFailedBatch(m.SplitFailedBatch),
+		manager.WithMaxFailedRecords(m.MaxFailedRecords),
+		manager.WithMaxFailedRatio(m.MaxFailedRatio),
+		manager.WithMaxFailedRatioMinRecords(m.MaxFailedRatioMinRecords),
 	)
+	for space, spacePool := range spacePools {
+		options = append(options, manager.WithSpacePool(space, spacePool))
+	}
 	options = append(options, opts...)
 
 	mgr := manager.NewWithOpts(options...)
@@ -43,11 +53,19 @@ func (m *Manager) BuildManager(
 		if err != nil {
 			return nil, err
 		}
-		importers, err := s.BuildImporters(m.GraphName, pool)
+		// The source with a space overriding the spaceName imports by the pool of the space.
+		graphName, graphPool := m.GraphName, pool
+		if spacePool, ok := spacePools[s.Space]; ok {
+			graphName, graphPool = s.Space, spacePool
+		}
+		importers, err := s.BuildImporters(graphName, graphPool,
+			importer.WithRetryPolicy(m.Retry),
+			importer.WithTransientRetryPolicy(m.TransientRetry),
+		)
 		if err != nil {
 			return nil, err
 		}
-		if err = mgr.Import(src, brr, importers...); err != nil {
+		if err = mgr.ImportSpace(graphName, src, brr, importers...); err != nil {
 			return nil, err
 		}
 	}
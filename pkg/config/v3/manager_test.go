@@ -1,6 +1,7 @@
 package configv3
 
 import (
+	"context"
 	"path/filepath"
 
 	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
@@ -45,16 +46,16 @@ var _ = Describe("Manager", func() {
 
 		It("BuildImporters failed", func() {
 			c.Manager.GraphName = ""
-			Expect(c.Build()).To(HaveOccurred())
+			Expect(c.Build(context.Background())).To(HaveOccurred())
 		})
 
 		It("Importer failed", func() {
 			c.Sources[0].Config.Local.Path = filepath.Join("testdata", "not-exists.csv")
-			Expect(c.Build()).To(HaveOccurred())
+			Expect(c.Build(context.Background())).To(HaveOccurred())
 		})
 
 		It("successfully", func() {
-			Expect(c.Build()).NotTo(HaveOccurred())
+			Expect(c.Build(context.Background())).NotTo(HaveOccurred())
 		})
 	})
 })
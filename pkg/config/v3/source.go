@@ -1,11 +1,15 @@
 package configv3
 
 import (
+	"io"
 	"path/filepath"
 
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
 	"github.com/lucky-xin/nebula-importer/pkg/importer"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 	specv3 "github.com/lucky-xin/nebula-importer/pkg/spec/v3"
 	"github.com/lucky-xin/nebula-importer/pkg/utils"
 )
@@ -15,13 +19,32 @@ type (
 		configbase.Source `yaml:",inline" json:",inline"`
 		Nodes             specv3.Nodes `yaml:"tags,omitempty" json:"tags,omitempty,optional"`
 		Edges             specv3.Edges `yaml:"edges,omitempty" json:"edges,omitempty,optional"`
+		// GroupTags inserts all the tags of a record in one statement, which share the same id.
+		GroupTags bool `yaml:"groupTags,omitempty" json:"groupTags,omitempty,optional"`
+		// Space overrides the spaceName of the manager to import the source into.
+		Space string `yaml:"space,omitempty" json:"space,omitempty,optional"`
+
+		lookups picker.Lookups
 	}
 
 	Sources []Source
 )
 
 func (s *Source) BuildGraph(graphName string, opts ...specv3.GraphOption) (*specv3.Graph, error) {
-	options := make([]specv3.GraphOption, 0, len(s.Nodes)+len(s.Edges)+len(opts))
+	options := make([]specv3.GraphOption, 0, len(s.Nodes)+len(s.Edges)+len(opts)+1)
+	if s.Nodes.HasColumnNames() || s.Edges.HasColumnNames() {
+		header, err := s.Header()
+		if err != nil {
+			return nil, errors.NewImportError(err, "read the header of source failed")
+		}
+		// The sources expanded from the wildcards share the nodes and edges, but may have different headers.
+		s.Nodes = s.Nodes.Clone()
+		s.Edges = s.Edges.Clone()
+		options = append(options, specv3.WithGraphHeader(specv3.NewHeader(header)))
+	}
+	if s.lookups != nil {
+		options = append(options, specv3.WithGraphLookups(s.lookups))
+	}
 	for i := range s.Nodes {
 		node := s.Nodes[i]
 		options = append(options, specv3.WithGraphNodes(node))
@@ -36,31 +59,69 @@ func (s *Source) BuildGraph(graphName string, opts ...specv3.GraphOption) (*spec
 	if err := graph.Validate(); err != nil {
 		return nil, err
 	}
+	if s.GroupTags {
+		if err := graph.Nodes.ValidateGroup(); err != nil {
+			return nil, errors.AsOrNewImportError(err).SetGraphName(graphName)
+		}
+	}
 	return graph, nil
 }
 
-func (s *Source) BuildImporters(graphName string, pool client.Pool) ([]importer.Importer, error) {
+func (s *Source) BuildImporters(graphName string, pool client.Pool, opts ...importer.Option) ([]importer.Importer, error) {
 	graph, err := s.BuildGraph(graphName)
 	if err != nil {
 		return nil, err
 	}
 	importers := make([]importer.Importer, 0, len(s.Nodes)+len(s.Edges))
-	for k := range s.Nodes {
-		node := s.Nodes[k]
-		builder := graph.NodeStatementBuilder(node)
-		i := importer.New(builder, pool)
+	if s.GroupTags && len(s.Nodes) > 0 {
+		// All the tags are inserted by one importer.
+		builder := graph.NodesStatementBuilder(s.Nodes)
+		i := importer.New(builder, pool, opts...)
 		importers = append(importers, i)
+	} else {
+		for k := range s.Nodes {
+			node := s.Nodes[k]
+			builder := graph.NodeStatementBuilder(node)
+			i := importer.New(builder, pool, opts...)
+			importers = append(importers, i)
+		}
 	}
 
 	for k := range s.Edges {
 		edge := s.Edges[k]
 		builder := graph.EdgeStatementBuilder(edge)
-		i := importer.New(builder, pool)
+		i := importer.New(builder, pool, opts...)
 		importers = append(importers, i)
 	}
 	return importers, nil
 }
 
+// DedupClosers returns the distinct Dedups of the nodes and edges, so that the sets are closed after the import,
+// such as the spilled files are removed. The copies of the nodes and edges share the same Dedup.
+func (ss Sources) DedupClosers() []io.Closer {
+	var closers []io.Closer
+	seen := make(map[*specbase.Dedup]struct{})
+	add := func(d *specbase.Dedup) {
+		if d == nil {
+			return
+		}
+		if _, ok := seen[d]; ok {
+			return
+		}
+		seen[d] = struct{}{}
+		closers = append(closers, d)
+	}
+	for i := range ss {
+		for _, n := range ss[i].Nodes {
+			add(n.Dedup)
+		}
+		for _, e := range ss[i].Edges {
+			add(e.Dedup)
+		}
+	}
+	return closers
+}
+
 // OptimizePath optimizes relative paths base to the configuration file path
 func (ss Sources) OptimizePath(configPath string) error {
 	configPathDir := filepath.Dir(configPath)
@@ -68,6 +129,9 @@ func (ss Sources) OptimizePath(configPath string) error {
 		if ss[i].Local != nil {
 			ss[i].Local.Path = utils.RelativePathBaseOn(configPathDir, ss[i].Local.Path)
 		}
+		if ss[i].ConvertOptions != nil && ss[i].ConvertOptions.Module != "" {
+			ss[i].ConvertOptions.Module = utils.RelativePathBaseOn(configPathDir, ss[i].ConvertOptions.Module)
+		}
 	}
 	return nil
 }
@@ -1,10 +1,15 @@
 package configv3
 
 import (
+	stderrors "errors"
 	"os"
 	"path/filepath"
 
+	configbase "github.com/lucky-xin/nebula-importer/pkg/config/base"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/reader"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 	specv3 "github.com/lucky-xin/nebula-importer/pkg/spec/v3"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -68,6 +73,79 @@ var _ = Describe("Source", func() {
 		})
 	})
 
+	Describe(".BuildGraph with column names", func() {
+		var (
+			tmpdir string
+			s      *Source
+		)
+		BeforeEach(func() {
+			var err error
+			tmpdir, err = os.MkdirTemp("", "test")
+			Expect(err).NotTo(HaveOccurred())
+			file := filepath.Join(tmpdir, "file.csv")
+			err = os.WriteFile(file, []byte("name,id,age\nTom,1,18\n"), 0o600)
+			Expect(err).NotTo(HaveOccurred())
+
+			s = &Source{
+				Nodes: specv3.Nodes{
+					&specv3.Node{
+						Name: "n1",
+						ID: &specv3.NodeID{
+							Name:   "id",
+							Type:   specv3.ValueTypeString,
+							Column: "id",
+						},
+						Props: specv3.Props{
+							&specv3.Prop{Name: "age", Type: specv3.ValueTypeInt, Column: "age"},
+						},
+						Mode: specbase.InsertMode,
+					},
+				},
+			}
+			s.Local = &source.LocalConfig{
+				Path: file,
+			}
+			s.CSV = &source.CSVConfig{
+				WithHeader: true,
+			}
+		})
+		AfterEach(func() {
+			err := os.RemoveAll(tmpdir)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("successfully", func() {
+			nodes := s.Nodes
+			graph, err := s.BuildGraph("graphName")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(graph).NotTo(BeNil())
+			// The nodes are cloned, so the shared nodes are not modified.
+			Expect(nodes[0].ID.Index).To(Equal(0))
+			Expect(s.Nodes[0].ID.Index).To(Equal(1))
+
+			statement, nRecord, err := graph.NodeStatement(s.Nodes[0], specv3.Record{"Tom", "1", "18"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nRecord).To(Equal(1))
+			Expect(statement).To(Equal("INSERT VERTEX `n1`(`age`) VALUES \"1\":(18)"))
+		})
+
+		It("unknown column", func() {
+			s.Nodes[0].Props[0].Column = "unknown"
+			graph, err := s.BuildGraph("graphName")
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrUnknownColumn)).To(BeTrue())
+			Expect(graph).To(BeNil())
+		})
+
+		It("without header", func() {
+			s.CSV.WithHeader = false
+			graph, err := s.BuildGraph("graphName")
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrNoHeader)).To(BeTrue())
+			Expect(graph).To(BeNil())
+		})
+	})
+
 	Describe(".BuildImporters", func() {
 		It("BuildGraph failed", func() {
 			s := &Source{}
@@ -123,6 +201,33 @@ var _ = Describe("Source", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(importers).To(HaveLen(3))
 		})
+
+		It("group tags", func() {
+			newNode := func(name string, index int) *specv3.Node {
+				return specv3.NewNode(
+					name,
+					specv3.WithNodeID(&specv3.NodeID{Type: specv3.ValueTypeString, Index: index}),
+					specv3.WithNodeProps(&specv3.Prop{Name: "p", Type: specv3.ValueTypeString, Index: 2}),
+					specv3.WithNodeMode(specbase.InsertMode),
+				)
+			}
+			s := &Source{
+				Nodes:     specv3.Nodes{newNode("n1", 0), newNode("n2", 0)},
+				GroupTags: true,
+			}
+			importers, err := s.BuildImporters("graphName", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(importers).To(HaveLen(1))
+
+			s = &Source{
+				Nodes:     specv3.Nodes{newNode("n1", 0), newNode("n2", 1)},
+				GroupTags: true,
+			}
+			importers, err = s.BuildImporters("graphName", nil)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrMismatchedNodeID)).To(BeTrue())
+			Expect(importers).To(BeNil())
+		})
 	})
 })
 
@@ -166,6 +271,42 @@ var _ = Describe("Sources", func() {
 		Entry(nil, "d1/f.yaml", []string{"/d10/1.csv", "/d20/2.csv"}, []string{"/d10/1.csv", "/d20/2.csv"}),
 	)
 
+	It(".OptimizePath convert module", func() {
+		sources := Sources{
+			{Source: configbase.Source{ConvertOptions: &reader.ConvertOptions{Module: "convert.wasm"}}},
+			{Source: configbase.Source{ConvertOptions: &reader.ConvertOptions{Module: "/convert.wasm"}}},
+			{Source: configbase.Source{ConvertOptions: &reader.ConvertOptions{}}},
+		}
+		Expect(sources.OptimizePath("d1/f.yaml")).NotTo(HaveOccurred())
+		Expect(sources[0].ConvertOptions.Module).To(Equal("d1/convert.wasm"))
+		Expect(sources[1].ConvertOptions.Module).To(Equal("/convert.wasm"))
+		Expect(sources[2].ConvertOptions.Module).To(Equal(""))
+	})
+
+	It(".DedupClosers", func() {
+		nodeDedup, edgeDedup := &specbase.Dedup{}, &specbase.Dedup{}
+		nodes := specv3.Nodes{
+			specv3.NewNode("node1", specv3.WithNodeDedup(nodeDedup)),
+			specv3.NewNode("node2"),
+		}
+		sources := Sources{
+			{Nodes: nodes},
+			// The sources expanded from the wildcards share the nodes.
+			{Nodes: nodes.Clone(), Edges: specv3.Edges{specv3.NewEdge("edge", specv3.WithEdgeDedup(edgeDedup))}},
+		}
+		closers := sources.DedupClosers()
+		Expect(closers).To(HaveLen(2))
+		Expect(closers[0]).To(BeIdenticalTo(nodeDedup))
+		Expect(closers[1]).To(BeIdenticalTo(edgeDedup))
+
+		Expect(nodeDedup.Build()).NotTo(HaveOccurred())
+		for _, c := range closers {
+			Expect(c.Close()).NotTo(HaveOccurred())
+		}
+
+		Expect(Sources{{}}.DedupClosers()).To(BeEmpty())
+	})
+
 	Describe(".OptimizePathWildCard", func() {
 		var (
 			wd string
@@ -0,0 +1,144 @@
+package dedup
+
+import (
+	"sync"
+)
+
+const (
+	DefaultMaxMemory = 64 << 20
+
+	// The estimated memory of a key besides its bytes, such as the string header and the map bucket.
+	keyOverhead = 64
+)
+
+var _ Set = (*defaultSet)(nil)
+
+type (
+	// Set remembers the keys seen, it is safe for concurrent use.
+	Set interface {
+		// Add adds the key, and reports whether the key is absent before.
+		Add(key string) (bool, error)
+		// Remove removes the keys, so that they are absent again.
+		Remove(keys ...string) error
+		// Len returns the number of the keys remembered.
+		Len() int
+		Close() error
+	}
+
+	Option func(*defaultSet)
+
+	// defaultSet keeps the keys in memory until the budget is exhausted,
+	// then spills the new keys to disk if enabled, otherwise forgets them.
+	defaultSet struct {
+		maxMemory int64
+		spill     bool
+
+		mu     sync.Mutex
+		memory int64
+		keys   map[string]struct{}
+		disk   *diskSet
+	}
+)
+
+// WithMaxMemory sets the memory budget of the keys in bytes, 64MiB by default.
+func WithMaxMemory(maxMemory int64) Option {
+	return func(s *defaultSet) {
+		s.maxMemory = maxMemory
+	}
+}
+
+// WithSpill enables to keep the keys on disk when the memory budget is exhausted.
+func WithSpill(spill bool) Option {
+	return func(s *defaultSet) {
+		s.spill = spill
+	}
+}
+
+func New(opts ...Option) Set {
+	s := &defaultSet{
+		maxMemory: DefaultMaxMemory,
+		keys:      map[string]struct{}{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *defaultSet) Add(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.keys[key]; ok {
+		return false, nil
+	}
+
+	if size := int64(len(key) + keyOverhead); s.memory+size <= s.maxMemory {
+		// The spilled keys are never moved back to memory, so check them first.
+		if s.disk != nil {
+			if ok, err := s.disk.Has(key); err != nil || ok {
+				return false, err
+			}
+		}
+		s.keys[key] = struct{}{}
+		s.memory += size
+		return true, nil
+	}
+
+	if !s.spill {
+		// The budget is exhausted, the key is treated as new but not remembered.
+		return true, nil
+	}
+	if s.disk == nil {
+		disk, err := newDiskSet()
+		if err != nil {
+			return false, err
+		}
+		s.disk = disk
+	}
+	return s.disk.Add(key)
+}
+
+func (s *defaultSet) Remove(keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, key := range keys {
+		if _, ok := s.keys[key]; ok {
+			delete(s.keys, key)
+			s.memory -= int64(len(key) + keyOverhead)
+			continue
+		}
+		if s.disk != nil {
+			if err := s.disk.Remove(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *defaultSet) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.keys)
+	if s.disk != nil {
+		n += s.disk.Len()
+	}
+	return n
+}
+
+func (s *defaultSet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = map[string]struct{}{}
+	s.memory = 0
+	if s.disk != nil {
+		err := s.disk.Close()
+		s.disk = nil
+		return err
+	}
+	return nil
+}
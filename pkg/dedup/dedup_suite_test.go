@@ -0,0 +1,13 @@
+package dedup
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDedup(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pkg dedup Suite")
+}
@@ -0,0 +1,81 @@
+package dedup
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Set", func() {
+	expectAdd := func(s Set, key string, expectAdded bool) {
+		added, err := s.Add(key)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		ExpectWithOffset(1, added).To(Equal(expectAdded), key)
+	}
+
+	It("memory", func() {
+		s := New()
+		defer s.Close()
+
+		expectAdd(s, "a", true)
+		expectAdd(s, "b", true)
+		expectAdd(s, "a", false)
+		Expect(s.Len()).To(Equal(2))
+
+		Expect(s.Remove("a", "c")).NotTo(HaveOccurred())
+		Expect(s.Len()).To(Equal(1))
+		expectAdd(s, "a", true)
+		expectAdd(s, "b", false)
+	})
+
+	It("exhausted without spill", func() {
+		s := New(WithMaxMemory(2 * (1 + keyOverhead)))
+		defer s.Close()
+
+		expectAdd(s, "a", true)
+		expectAdd(s, "b", true)
+		// The key is not remembered once the budget is exhausted.
+		expectAdd(s, "c", true)
+		expectAdd(s, "c", true)
+		expectAdd(s, "a", false)
+		Expect(s.Len()).To(Equal(2))
+	})
+
+	It("exhausted with spill", func() {
+		s := New(WithMaxMemory(2*(1+keyOverhead)), WithSpill(true))
+		defer s.Close()
+
+		expectAdd(s, "a", true)
+		expectAdd(s, "b", true)
+		expectAdd(s, "c", true)
+		expectAdd(s, "d", true)
+		for _, key := range []string{"a", "b", "c", "d"} {
+			expectAdd(s, key, false)
+		}
+		Expect(s.Len()).To(Equal(4))
+
+		// The memory is released, but the spilled keys are still remembered.
+		Expect(s.Remove("a", "c")).NotTo(HaveOccurred())
+		Expect(s.Len()).To(Equal(2))
+		expectAdd(s, "d", false)
+		expectAdd(s, "c", true)
+		expectAdd(s, "a", true)
+		Expect(s.Len()).To(Equal(4))
+
+		path := s.(*defaultSet).disk.db.Path()
+		Expect(path).To(BeAnExistingFile())
+		Expect(s.Close()).NotTo(HaveOccurred())
+		Expect(s.Len()).To(Equal(0))
+		Expect(path).NotTo(BeAnExistingFile())
+	})
+
+	It("spill the empty key", func() {
+		s := New(WithMaxMemory(1), WithSpill(true))
+		defer s.Close()
+
+		expectAdd(s, "", true)
+		expectAdd(s, "", false)
+		Expect(s.Len()).To(Equal(1))
+		Expect(s.Remove("")).NotTo(HaveOccurred())
+		Expect(s.Len()).To(Equal(0))
+	})
+})
@@ -0,0 +1,78 @@
+package dedup
+
+import (
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	diskSetBucket = []byte("dedup")
+	// The value of the keys, which is not empty so that Get tells the present keys.
+	diskSetValue = []byte{1}
+)
+
+// diskSet keeps the keys in a temporary bolt database, which is deleted when closed.
+// It is guarded by the mutex of the defaultSet.
+type diskSet struct {
+	db     *bbolt.DB
+	length int
+}
+
+func newDiskSet() (*diskSet, error) {
+	db, err := utils.OpenTempBolt("nebula-importer-dedup-*.db", diskSetBucket)
+	if err != nil {
+		return nil, err
+	}
+	return &diskSet{db: db}, nil
+}
+
+func (s *diskSet) Has(key string) (ok bool, err error) {
+	err = s.db.View(func(tx *bbolt.Tx) error {
+		ok = tx.Bucket(diskSetBucket).Get(utils.BoltKey(key)) != nil
+		return nil
+	})
+	return ok, err
+}
+
+func (s *diskSet) Add(key string) (added bool, err error) {
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		b, k := tx.Bucket(diskSetBucket), utils.BoltKey(key)
+		if b.Get(k) != nil {
+			return nil
+		}
+		added = true
+		return b.Put(k, diskSetValue)
+	})
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.length++
+	}
+	return added, nil
+}
+
+func (s *diskSet) Remove(key string) error {
+	var removed bool
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b, k := tx.Bucket(diskSetBucket), utils.BoltKey(key)
+		if b.Get(k) == nil {
+			return nil
+		}
+		removed = true
+		return b.Delete(k)
+	})
+	if removed && err == nil {
+		s.length--
+	}
+	return err
+}
+
+func (s *diskSet) Len() int {
+	return s.length
+}
+
+func (s *diskSet) Close() error {
+	return utils.CloseTempBolt(s.db)
+}
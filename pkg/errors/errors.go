@@ -26,6 +26,30 @@ var (
 	ErrUnsupportedConcatItemType = stderrors.New("unsupported concat item type")
 	ErrUnsupportedFunction       = stderrors.New("unsupported function")
 	ErrFilterSyntax              = stderrors.New("filter syntax")
+	ErrValueExprSyntax           = stderrors.New("value expr syntax")
+	ErrNoHeader                  = stderrors.New("no header")
+	ErrUnknownColumn             = stderrors.New("unknown column")
+	ErrInvalidFormat             = stderrors.New("invalid format")
+	ErrInvalidTimezone           = stderrors.New("invalid timezone")
+	ErrInvalidValue              = stderrors.New("invalid value")
+	ErrInvalidRecord             = stderrors.New("invalid record")
+	ErrUnsupportedNullPolicy     = stderrors.New("unsupported null policy")
+	ErrInvalidFunctionOption     = stderrors.New("invalid function option")
+	ErrUnknownLookup             = stderrors.New("unknown lookup")
+	ErrLookupMiss                = stderrors.New("lookup miss")
+	ErrUnsupportedLookupStorage  = stderrors.New("unsupported lookup storage")
+	ErrUnsupportedMissPolicy     = stderrors.New("unsupported miss policy")
 	ErrUnsupportedMode           = stderrors.New("unsupported mode")
+	ErrUnknownOperation          = stderrors.New("unknown operation")
+	ErrUnsupportedDeleteScope    = stderrors.New("unsupported delete scope")
+	ErrUnsupportedUpsertStrategy = stderrors.New("unsupported upsert strategy")
+	ErrMismatchedNodeID          = stderrors.New("mismatched node id")
+	ErrUnsupportedEndpointPolicy = stderrors.New("unsupported endpoints policy")
+	ErrUnsupportedEdgeDirection  = stderrors.New("unsupported edge direction")
 	ErrContinue                  = stderrors.New("continue")
+	ErrTooManyFailures           = stderrors.New("too many failures")
+	ErrInvalidConfig             = stderrors.New("invalid config")
+	ErrConnectFailed             = stderrors.New("connect failed")
+	ErrPartialFailure            = stderrors.New("partial failure")
+	ErrTotalFailure              = stderrors.New("total failure")
 )
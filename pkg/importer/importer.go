@@ -2,17 +2,34 @@
 package importer
 
 import (
-	"github.com/avast/retry-go/v4"
-	"github.com/lucky-xin/nebula-importer/pkg/spec"
+	"context"
+	stderrors "errors"
+	"fmt"
 	"time"
 
 	"github.com/lucky-xin/nebula-importer/pkg/client"
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	"github.com/avast/retry-go/v4"
+)
+
+var (
+	DefaultRetryPolicy = RetryPolicy{
+		Attempts:        5,
+		InitialInterval: time.Millisecond * 500,
+		MaxInterval:     time.Second * 10,
+	}
+	DefaultTransientRetryPolicy = RetryPolicy{
+		Attempts:        20,
+		InitialInterval: time.Second,
+		MaxInterval:     time.Second * 30,
+	}
 )
 
 type (
 	Importer interface {
-		Import(records ...spec.Record) (*ImportResp, error)
+		Import(ctx context.Context, records ...spec.Record) (*ImportResp, error)
 
 		// Add Done Wait for synchronize, similar to sync.WaitGroup.
 		Add(delta int)
@@ -22,8 +39,14 @@ type (
 
 	ImportResp struct {
 		RecordNum int
-		Latency   time.Duration
-		RespTime  time.Duration
+		// The number of the records skipped as duplicates, see spec.DedupStatementBuilder.
+		Duplicates int
+		// The number of the records skipped as unverified, see spec.VerifyStatementBuilder.
+		Unverified int
+		// The number of the records skipped as stale, see spec.VersionStatementBuilder.
+		Stale    int
+		Latency  time.Duration
+		RespTime time.Duration
 	}
 
 	ImportResult struct {
@@ -31,11 +54,20 @@ type (
 		Err  error
 	}
 
+	// RetryPolicy controls how many times and how long to wait to execute a statement again.
+	RetryPolicy struct {
+		Attempts        uint          `yaml:"attempts,omitempty" json:"attempts,omitempty,optional"`
+		InitialInterval time.Duration `yaml:"initialInterval,omitempty" json:"initialInterval,omitempty,optional"`
+		MaxInterval     time.Duration `yaml:"maxInterval,omitempty" json:"maxInterval,omitempty,optional"`
+	}
+
 	Option func(*defaultImporter)
 
 	defaultImporter struct {
-		builder spec.StatementBuilder
-		pool    client.Pool
+		builder              spec.StatementBuilder
+		pool                 client.Pool
+		retryPolicy          RetryPolicy
+		transientRetryPolicy RetryPolicy
 
 		fnAdd  func(delta int)
 		fnDone func()
@@ -51,7 +83,10 @@ func New(builder spec.StatementBuilder, pool client.Pool, opts ...Option) Import
 }
 
 func NewWithOpts(opts ...Option) Importer {
-	i := &defaultImporter{}
+	i := &defaultImporter{
+		retryPolicy:          DefaultRetryPolicy,
+		transientRetryPolicy: DefaultTransientRetryPolicy,
+	}
 	for _, opt := range opts {
 		opt(i)
 	}
@@ -79,6 +114,20 @@ func WithClientPool(p client.Pool) Option {
 	}
 }
 
+// WithRetryPolicy sets the retry policy for the errors that are neither permanent nor transient.
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(i *defaultImporter) {
+		i.retryPolicy = i.retryPolicy.merge(p)
+	}
+}
+
+// WithTransientRetryPolicy sets the retry policy for the transient errors, such as leader changed.
+func WithTransientRetryPolicy(p *RetryPolicy) Option {
+	return func(i *defaultImporter) {
+		i.transientRetryPolicy = i.transientRetryPolicy.merge(p)
+	}
+}
+
 func WithAddFunc(fn func(delta int)) Option {
 	return func(i *defaultImporter) {
 		i.fnAdd = fn
@@ -97,49 +146,189 @@ func WithWaitFunc(fn func()) Option {
 	}
 }
 
-func (i *defaultImporter) Import(records ...spec.Record) (*ImportResp, error) {
-	statement, nRecord, err := i.builder.Build(records...)
-	if err != nil {
-		return nil, err
+func (i *defaultImporter) Import(ctx context.Context, records ...spec.Record) (*ImportResp, error) {
+	var (
+		statement                                string
+		params                                   map[string]any
+		nRecord, nDuplicate, nUnverified, nStale int
+		err                                      error
+	)
+	switch b := i.builder.(type) {
+	case spec.ParamStatementBuilder:
+		statement, params, nRecord, nDuplicate, err = b.BuildParam(records...)
+		if err != nil {
+			i.forget(records...)
+			return nil, newRecordError(err)
+		}
+	case spec.VerifyStatementBuilder:
+		if statement, nRecord, nUnverified, err = i.buildVerified(ctx, b, records...); err != nil {
+			return nil, err
+		}
+	case spec.VersionStatementBuilder:
+		if statement, nRecord, nStale, err = i.buildFresh(ctx, b, records...); err != nil {
+			return nil, err
+		}
+	default:
+		statement, nRecord, nDuplicate, err = i.build(records...)
+		if err != nil {
+			i.forget(records...)
+			// The records can not be built into a statement, and no request is sent.
+			return nil, newRecordError(err)
+		}
 	}
 
 	if nRecord == 0 {
-		return &ImportResp{}, nil
+		return &ImportResp{Duplicates: nDuplicate, Unverified: nUnverified, Stale: nStale}, nil
 	}
 
-	resp, err := retry.DoWithData[client.Response](
+	resp, err := i.executeWithRetry(ctx, statement, params)
+	if err != nil {
+		i.forget(records...)
+		return nil, errors.NewImportError(err).
+			SetStatement(statement)
+	}
+
+	return &ImportResp{
+		RecordNum:  nRecord,
+		Duplicates: nDuplicate,
+		Unverified: nUnverified,
+		Stale:      nStale,
+		RespTime:   resp.GetRespTime(),
+		Latency:    resp.GetLatency(),
+	}, nil
+}
+
+// executeWithRetry executes the statement with the params if not nil, and retries by the policy of the kind of error.
+func (i *defaultImporter) executeWithRetry(ctx context.Context, statement string, params map[string]any) (client.Response, error) {
+	var nRetry, nTransientRetry uint
+	return retry.DoWithData[client.Response](
 		func() (client.Response, error) {
-			r, e := i.pool.Execute(statement)
-			if e != nil {
-				return nil, e
-			}
-			e = r.GetError()
-			if !r.IsSucceed() || e != nil {
-				return nil, e
-			}
-			return r, e
+			return i.execute(ctx, statement, params)
 		},
-		retry.Attempts(5),
+		retry.Context(ctx),
+		retry.WrapContextErrorWithLastError(true),
+		// The attempts are limited in RetryIf according to the kind of error.
+		retry.Attempts(0),
 		retry.RetryIf(func(err error) bool {
-			return err != nil
+			if stderrors.Is(err, client.ErrTransient) {
+				nTransientRetry++
+				return nTransientRetry < i.transientRetryPolicy.Attempts
+			}
+			nRetry++
+			return nRetry < i.retryPolicy.Attempts
+		}),
+		retry.DelayType(func(_ uint, err error, _ *retry.Config) time.Duration {
+			if stderrors.Is(err, client.ErrTransient) {
+				return i.transientRetryPolicy.delay(nTransientRetry)
+			}
+			return i.retryPolicy.delay(nRetry)
 		}),
-		retry.Delay(time.Millisecond*500),
-		retry.MaxDelay(time.Second*10),
 	)
+}
+
+// buildVerified executes the query to verify the records, and builds the statement of the verified ones.
+func (i *defaultImporter) buildVerified(
+	ctx context.Context,
+	b spec.VerifyStatementBuilder,
+	records ...spec.Record,
+) (statement string, nRecord, nUnverified int, err error) {
+	query, columns, err := b.BuildVerify(records...)
 	if err != nil {
-		return nil, errors.NewImportError(err).
-			SetStatement(statement)
+		return "", 0, 0, newRecordError(err)
 	}
-	if !resp.IsSucceed() {
-		return nil, errors.NewImportError(err, "the execute error is %s ", resp.GetError()).
-			SetStatement(statement)
+	values, err := i.queryColumns(ctx, query, columns...)
+	if err != nil {
+		return "", 0, 0, err
 	}
+	statement, nRecord, nUnverified, err = b.BuildVerified(values, records...)
+	if err != nil {
+		return "", 0, 0, newRecordError(err)
+	}
+	return statement, nRecord, nUnverified, nil
+}
 
-	return &ImportResp{
-		RecordNum: nRecord,
-		RespTime:  resp.GetRespTime(),
-		Latency:   resp.GetLatency(),
-	}, nil
+// buildFresh executes the query to find the stale records, and builds the statement of the others.
+func (i *defaultImporter) buildFresh(
+	ctx context.Context,
+	b spec.VersionStatementBuilder,
+	records ...spec.Record,
+) (statement string, nRecord, nStale int, err error) {
+	query, columns, err := b.BuildStaleQuery(records...)
+	if err != nil {
+		return "", 0, 0, newRecordError(err)
+	}
+	existing, err := i.queryColumns(ctx, query, columns...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	statement, nRecord, nStale, err = b.BuildFresh(existing, records...)
+	if err != nil {
+		return "", 0, 0, newRecordError(err)
+	}
+	return statement, nRecord, nStale, nil
+}
+
+// queryColumns executes the query, and returns the values of the columns in order, nil if the query is empty.
+func (i *defaultImporter) queryColumns(ctx context.Context, query string, columns ...string) ([][]string, error) {
+	if query == "" {
+		return nil, nil
+	}
+	resp, err := i.executeWithRetry(ctx, query, nil)
+	if err != nil {
+		return nil, errors.NewImportError(err).SetStatement(query)
+	}
+	values := make([][]string, 0, len(columns))
+	for _, column := range columns {
+		columnValues, err := resp.GetColumnValues(column)
+		if err != nil {
+			return nil, errors.NewImportError(err).SetStatement(query)
+		}
+		values = append(values, columnValues)
+	}
+	return values, nil
+}
+
+func (i *defaultImporter) build(records ...spec.Record) (statement string, nRecord, nDuplicate int, err error) {
+	if b, ok := i.builder.(spec.DedupStatementBuilder); ok {
+		return b.BuildDedup(records...)
+	}
+	statement, nRecord, err = i.builder.Build(records...)
+	return statement, nRecord, 0, err
+}
+
+// forget forgets the failed records, so that they are not skipped as duplicates when imported again, such as the failed batch is split.
+func (i *defaultImporter) forget(records ...spec.Record) {
+	if b, ok := i.builder.(spec.DedupStatementBuilder); ok {
+		// The worst case of failing to forget is that the records are skipped when imported again.
+		_ = b.Forget(records...)
+	}
+}
+
+// execute executes the statement once, and marks the error as permanent or transient by the response.
+func (i *defaultImporter) execute(ctx context.Context, statement string, params map[string]any) (client.Response, error) {
+	var (
+		resp client.Response
+		err  error
+	)
+	if params != nil {
+		resp, err = i.pool.ExecuteWithParameter(ctx, statement, params)
+	} else {
+		resp, err = i.pool.Execute(ctx, statement)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp.IsSucceed() {
+		return resp, nil
+	}
+	err = resp.GetError()
+	switch {
+	case resp.IsPermanentError():
+		return nil, retry.Unrecoverable(fmt.Errorf("%w: %w", client.ErrPermanent, err))
+	case resp.IsRetryMoreError():
+		return nil, fmt.Errorf("%w: %w", client.ErrTransient, err)
+	}
+	return nil, err
 }
 
 func (i *defaultImporter) Add(delta int) {
@@ -153,3 +342,47 @@ func (i *defaultImporter) Done() {
 func (i *defaultImporter) Wait() {
 	i.fnWait()
 }
+
+// IsPermanentError reports whether the import failed with an error that will never succeed by retrying.
+func IsPermanentError(err error) bool {
+	return stderrors.Is(err, client.ErrPermanent) || IsRecordError(err)
+}
+
+// newRecordError wraps the error of building the records as ErrInvalidRecord, such as the value can not be converted.
+// The converters and the statement builders return the errors as they are, and they are wrapped only here.
+func newRecordError(err error) error {
+	return fmt.Errorf("%w: %w", errors.ErrInvalidRecord, err)
+}
+
+// IsRecordError reports whether the import failed before sending the request, such as the value can not be converted.
+func IsRecordError(err error) bool {
+	return stderrors.Is(err, errors.ErrInvalidRecord)
+}
+
+func (p RetryPolicy) merge(o *RetryPolicy) RetryPolicy {
+	if o == nil {
+		return p
+	}
+	if o.Attempts > 0 {
+		p.Attempts = o.Attempts
+	}
+	if o.InitialInterval > 0 {
+		p.InitialInterval = o.InitialInterval
+	}
+	if o.MaxInterval > 0 {
+		p.MaxInterval = o.MaxInterval
+	}
+	return p
+}
+
+// delay returns the exponential backoff delay before the n-th retry, n starts from 1.
+func (p RetryPolicy) delay(n uint) time.Duration {
+	d := p.InitialInterval
+	for ; n > 1 && d < p.MaxInterval; n-- {
+		d *= 2
+	}
+	if p.MaxInterval > 0 && d > p.MaxInterval {
+		d = p.MaxInterval
+	}
+	return d
+}
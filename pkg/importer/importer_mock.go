@@ -5,6 +5,7 @@
 package importer
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -59,9 +60,9 @@ func (mr *MockImporterMockRecorder) Done() *gomock.Call {
 }
 
 // Import mocks base method.
-func (m *MockImporter) Import(records ...spec.Record) (*ImportResp, error) {
+func (m *MockImporter) Import(ctx context.Context, records ...spec.Record) (*ImportResp, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{}
+	varargs := []interface{}{ctx}
 	for _, a := range records {
 		varargs = append(varargs, a)
 	}
@@ -72,9 +73,10 @@ func (m *MockImporter) Import(records ...spec.Record) (*ImportResp, error) {
 }
 
 // Import indicates an expected call of Import.
-func (mr *MockImporterMockRecorder) Import(records ...interface{}) *gomock.Call {
+func (mr *MockImporterMockRecorder) Import(ctx interface{}, records ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImporter)(nil).Import), records...)
+	varargs := append([]interface{}{ctx}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockImporter)(nil).Import), varargs...)
 }
 
 // Wait mocks base method.
@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	stderrors "errors"
 	"sync"
 	"time"
@@ -38,18 +39,23 @@ var _ = Describe("Importer", func() {
 			mockBuilder.EXPECT().Build(gomock.Any()).Return("", 0, errors.ErrNoRecord)
 
 			i := New(mockBuilder, mockClientPool)
-			resp, err := i.Import(spec.Record{})
+			resp, err := i.Import(context.Background(), spec.Record{})
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, errors.ErrNoRecord)).To(BeTrue())
+			Expect(IsRecordError(err)).To(BeTrue())
+			Expect(IsPermanentError(err)).To(BeTrue())
 			Expect(resp).To(BeNil())
 		})
 
 		It("execute failed", func() {
 			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
-			mockClientPool.EXPECT().Execute(gomock.Any()).Return(nil, stderrors.New("test error"))
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(2).Return(nil, stderrors.New("test error"))
 
-			i := New(mockBuilder, mockClientPool)
-			resp, err := i.Import(spec.Record{"id"})
+			i := New(mockBuilder, mockClientPool, WithRetryPolicy(&RetryPolicy{
+				Attempts:        2,
+				InitialInterval: time.Microsecond,
+			}))
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
 			Expect(err).To(HaveOccurred())
 			importError, ok := errors.AsImportError(err)
 			Expect(ok).To(BeTrue())
@@ -59,23 +65,112 @@ var _ = Describe("Importer", func() {
 
 		It("execute IsSucceed false", func() {
 			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
-			mockClientPool.EXPECT().Execute(gomock.Any()).Times(1).Return(mockResponse, nil)
-			mockResponse.EXPECT().IsSucceed().Times(1).Return(false)
-			mockResponse.EXPECT().GetError().Times(1).Return(stderrors.New("status failed"))
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(2).Return(mockResponse, nil)
+			mockResponse.EXPECT().IsSucceed().Times(2).Return(false)
+			mockResponse.EXPECT().GetError().Times(2).Return(stderrors.New("status failed"))
+			mockResponse.EXPECT().IsPermanentError().Times(2).Return(false)
+			mockResponse.EXPECT().IsRetryMoreError().Times(2).Return(false)
 
-			i := New(mockBuilder, mockClientPool)
-			resp, err := i.Import(spec.Record{"id"})
+			i := New(mockBuilder, mockClientPool, WithRetryPolicy(&RetryPolicy{
+				Attempts:        2,
+				InitialInterval: time.Microsecond,
+			}))
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
 			Expect(err).To(HaveOccurred())
 			importError, ok := errors.AsImportError(err)
 			Expect(ok).To(BeTrue())
-			Expect(importError.Messages).To(ContainElement(ContainSubstring("status failed")))
+			Expect(importError.Error()).To(ContainSubstring("status failed"))
 			Expect(importError.Statement()).NotTo(BeEmpty())
+			Expect(IsPermanentError(err)).To(BeFalse())
+			Expect(resp).To(BeNil())
+		})
+
+		It("execute permanent error", func() {
+			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).Return(mockResponse, nil)
+			mockResponse.EXPECT().IsSucceed().Times(1).Return(false)
+			mockResponse.EXPECT().GetError().Times(1).Return(stderrors.New("syntax error"))
+			mockResponse.EXPECT().IsPermanentError().Times(1).Return(true)
+
+			i := New(mockBuilder, mockClientPool)
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("syntax error"))
+			Expect(stderrors.Is(err, client.ErrPermanent)).To(BeTrue())
+			Expect(IsPermanentError(err)).To(BeTrue())
+			Expect(resp).To(BeNil())
+		})
+
+		It("execute transient error", func() {
+			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(4).Return(mockResponse, nil)
+			mockResponse.EXPECT().IsSucceed().Times(4).Return(false)
+			mockResponse.EXPECT().GetError().Times(4).Return(stderrors.New("leader has changed"))
+			mockResponse.EXPECT().IsPermanentError().Times(4).Return(false)
+			mockResponse.EXPECT().IsRetryMoreError().Times(4).Return(true)
+
+			i := New(mockBuilder, mockClientPool,
+				WithRetryPolicy(&RetryPolicy{
+					Attempts:        1,
+					InitialInterval: time.Microsecond,
+				}),
+				WithTransientRetryPolicy(&RetryPolicy{
+					Attempts:        4,
+					InitialInterval: time.Microsecond,
+				}),
+			)
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, client.ErrTransient)).To(BeTrue())
+			Expect(IsPermanentError(err)).To(BeFalse())
+			Expect(resp).To(BeNil())
+		})
+
+		It("execute transient error then successfully", func() {
+			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(2).Return(mockResponse, nil)
+			gomock.InOrder(
+				mockResponse.EXPECT().IsSucceed().Return(false),
+				mockResponse.EXPECT().GetError().Return(stderrors.New("rpc failure")),
+				mockResponse.EXPECT().IsPermanentError().Return(false),
+				mockResponse.EXPECT().IsRetryMoreError().Return(true),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+			)
+			mockResponse.EXPECT().GetLatency().Times(1).Return(time.Microsecond * 10)
+			mockResponse.EXPECT().GetRespTime().Times(1).Return(time.Microsecond * 12)
+
+			i := New(mockBuilder, mockClientPool, WithTransientRetryPolicy(&RetryPolicy{
+				InitialInterval: time.Microsecond,
+			}))
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resp).NotTo(BeNil())
+			Expect(resp.RecordNum).To(Equal(1))
+		})
+
+		It("execute canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			mockBuilder.EXPECT().Build(gomock.Any()).Return("statement", 1, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).DoAndReturn(
+				func(context.Context, string) (client.Response, error) {
+					cancel()
+					return nil, stderrors.New("test error")
+				},
+			)
+
+			i := New(mockBuilder, mockClientPool, WithRetryPolicy(&RetryPolicy{
+				Attempts:        10,
+				InitialInterval: time.Second,
+			}))
+			resp, err := i.Import(ctx, spec.Record{"id"})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
 			Expect(resp).To(BeNil())
 		})
 
 		It("execute successfully", func() {
 			mockBuilder.EXPECT().Build(gomock.Any()).Times(1).Return("statement", 1, nil)
-			mockClientPool.EXPECT().Execute(gomock.Any()).Times(1).Return(mockResponse, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(1).Return(mockResponse, nil)
 			mockResponse.EXPECT().IsSucceed().Times(1).Return(true)
 			mockResponse.EXPECT().GetLatency().Times(1).Return(time.Microsecond * 10)
 			mockResponse.EXPECT().GetRespTime().AnyTimes().Return(time.Microsecond * 12)
@@ -83,16 +178,281 @@ var _ = Describe("Importer", func() {
 			i := New(mockBuilder, mockClientPool)
 			i.Wait()
 			defer i.Done()
-			resp, err := i.Import(spec.Record{"id"})
+			resp, err := i.Import(context.Background(), spec.Record{"id"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).NotTo(BeNil())
 			Expect(resp.Latency).To(Equal(time.Microsecond * time.Duration(10)))
 			Expect(resp.RespTime).To(Equal(time.Microsecond * time.Duration(12)))
 		})
 
+		When("dedup", func() {
+			var mockDedupBuilder *specbase.MockDedupStatementBuilder
+			BeforeEach(func() {
+				mockDedupBuilder = specbase.NewMockDedupStatementBuilder(ctrl)
+			})
+
+			It("all duplicates", func() {
+				mockDedupBuilder.EXPECT().BuildDedup(gomock.Any(), gomock.Any()).Return("", 0, 2, nil)
+
+				i := New(mockDedupBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"}, spec.Record{"id"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{Duplicates: 2}))
+			})
+
+			It("build failed", func() {
+				mockDedupBuilder.EXPECT().BuildDedup(gomock.Any()).Return("", 0, 0, errors.ErrNoRecord)
+				mockDedupBuilder.EXPECT().Forget(spec.Record{"id"}).Return(nil)
+
+				i := New(mockDedupBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("execute failed", func() {
+				mockDedupBuilder.EXPECT().BuildDedup(gomock.Any(), gomock.Any()).Return("statement", 1, 1, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil, stderrors.New("test error"))
+				mockDedupBuilder.EXPECT().Forget(spec.Record{"id"}, spec.Record{"id"}).Return(nil)
+
+				i := New(mockDedupBuilder, mockClientPool, WithRetryPolicy(&RetryPolicy{
+					Attempts:        1,
+					InitialInterval: time.Microsecond,
+				}))
+				resp, err := i.Import(context.Background(), spec.Record{"id"}, spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(resp).To(BeNil())
+			})
+
+			It("execute successfully", func() {
+				mockDedupBuilder.EXPECT().BuildDedup(gomock.Any(), gomock.Any()).Return("statement", 1, 1, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(mockResponse, nil)
+				mockResponse.EXPECT().IsSucceed().Return(true)
+				mockResponse.EXPECT().GetLatency().Return(time.Microsecond * 10)
+				mockResponse.EXPECT().GetRespTime().Return(time.Microsecond * 12)
+
+				i := New(mockDedupBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"}, spec.Record{"id"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{
+					RecordNum:  1,
+					Duplicates: 1,
+					Latency:    time.Microsecond * 10,
+					RespTime:   time.Microsecond * 12,
+				}))
+			})
+		})
+
+		When("verify", func() {
+			var mockVerifyBuilder *specbase.MockVerifyStatementBuilder
+			BeforeEach(func() {
+				mockVerifyBuilder = specbase.NewMockVerifyStatementBuilder(ctrl)
+			})
+
+			It("build verify failed", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any()).Return("", nil, errors.ErrNoRecord)
+
+				i := New(mockVerifyBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("query failed", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any()).Return("query", []string{"tag", "vid"}, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(nil, stderrors.New("test error"))
+
+				i := New(mockVerifyBuilder, mockClientPool, WithRetryPolicy(&RetryPolicy{
+					Attempts:        1,
+					InitialInterval: time.Microsecond,
+				}))
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeFalse())
+				importError, ok := errors.AsImportError(err)
+				Expect(ok).To(BeTrue())
+				Expect(importError.Statement()).To(Equal("query"))
+				Expect(resp).To(BeNil())
+			})
+
+			It("get column values failed", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any()).Return("query", []string{"tag", "vid"}, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(mockResponse, nil)
+				mockResponse.EXPECT().IsSucceed().Return(true)
+				mockResponse.EXPECT().GetColumnValues("tag").Return(nil, stderrors.New("test error"))
+
+				i := New(mockVerifyBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(resp).To(BeNil())
+			})
+
+			It("all unverified", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any(), gomock.Any()).Return("query", []string{"tag", "vid"}, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(mockResponse, nil)
+				mockResponse.EXPECT().IsSucceed().Return(true)
+				mockResponse.EXPECT().GetColumnValues("tag").Return([]string{}, nil)
+				mockResponse.EXPECT().GetColumnValues("vid").Return([]string{}, nil)
+				mockVerifyBuilder.EXPECT().BuildVerified([][]string{{}, {}}, gomock.Any(), gomock.Any()).Return("", 0, 2, nil)
+
+				i := New(mockVerifyBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id1"}, spec.Record{"id2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{Unverified: 2}))
+			})
+
+			It("no query", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any()).Return("", nil, nil)
+				mockVerifyBuilder.EXPECT().BuildVerified(nil, gomock.Any()).Return("", 0, 0, errors.ErrNoRecord)
+
+				i := New(mockVerifyBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("execute successfully", func() {
+				mockVerifyBuilder.EXPECT().BuildVerify(gomock.Any(), gomock.Any()).Return("query", []string{"tag", "vid"}, nil)
+				mockVerifyBuilder.EXPECT().BuildVerified([][]string{{"\"player\"", "\"player\""}, {"1", "2"}}, gomock.Any(), gomock.Any()).Return("statement", 1, 1, nil)
+				gomock.InOrder(
+					mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(mockResponse, nil),
+					mockClientPool.EXPECT().Execute(gomock.Any(), "statement").Return(mockResponse, nil),
+				)
+				mockResponse.EXPECT().IsSucceed().Times(2).Return(true)
+				mockResponse.EXPECT().GetColumnValues("tag").Return([]string{"\"player\"", "\"player\""}, nil)
+				mockResponse.EXPECT().GetColumnValues("vid").Return([]string{"1", "2"}, nil)
+				mockResponse.EXPECT().GetLatency().Return(time.Microsecond * 10)
+				mockResponse.EXPECT().GetRespTime().Return(time.Microsecond * 12)
+
+				i := New(mockVerifyBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id1"}, spec.Record{"id2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{
+					RecordNum:  1,
+					Unverified: 1,
+					Latency:    time.Microsecond * 10,
+					RespTime:   time.Microsecond * 12,
+				}))
+			})
+		})
+
+		When("version", func() {
+			var mockVersionBuilder *specbase.MockVersionStatementBuilder
+			BeforeEach(func() {
+				mockVersionBuilder = specbase.NewMockVersionStatementBuilder(ctrl)
+			})
+
+			It("build stale query failed", func() {
+				mockVersionBuilder.EXPECT().BuildStaleQuery(gomock.Any()).Return("", nil, errors.ErrNoRecord)
+
+				i := New(mockVersionBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("build fresh failed", func() {
+				mockVersionBuilder.EXPECT().BuildStaleQuery(gomock.Any()).Return("", nil, nil)
+				mockVersionBuilder.EXPECT().BuildFresh(nil, gomock.Any()).Return("", 0, 0, errors.ErrNoRecord)
+
+				i := New(mockVersionBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("all stale", func() {
+				mockVersionBuilder.EXPECT().BuildStaleQuery(gomock.Any()).Return("query", []string{"vid", "version"}, nil)
+				mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(mockResponse, nil)
+				mockResponse.EXPECT().IsSucceed().Return(true)
+				mockResponse.EXPECT().GetColumnValues("vid").Return([]string{`"id"`}, nil)
+				mockResponse.EXPECT().GetColumnValues("version").Return([]string{"2"}, nil)
+				mockVersionBuilder.EXPECT().BuildFresh([][]string{{`"id"`}, {"2"}}, gomock.Any()).Return("", 0, 1, nil)
+
+				i := New(mockVersionBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{Stale: 1}))
+			})
+
+			It("execute successfully", func() {
+				mockVersionBuilder.EXPECT().BuildStaleQuery(gomock.Any(), gomock.Any()).Return("query", []string{"vid", "version"}, nil)
+				mockVersionBuilder.EXPECT().BuildFresh([][]string{{`"id2"`}, {"2"}}, gomock.Any(), gomock.Any()).Return("statement", 1, 1, nil)
+				gomock.InOrder(
+					mockClientPool.EXPECT().Execute(gomock.Any(), "query").Return(mockResponse, nil),
+					mockClientPool.EXPECT().Execute(gomock.Any(), "statement").Return(mockResponse, nil),
+				)
+				mockResponse.EXPECT().IsSucceed().Times(2).Return(true)
+				mockResponse.EXPECT().GetColumnValues("vid").Return([]string{`"id2"`}, nil)
+				mockResponse.EXPECT().GetColumnValues("version").Return([]string{"2"}, nil)
+				mockResponse.EXPECT().GetLatency().Return(time.Microsecond * 10)
+				mockResponse.EXPECT().GetRespTime().Return(time.Microsecond * 12)
+
+				i := New(mockVersionBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id1"}, spec.Record{"id2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{
+					RecordNum: 1,
+					Stale:     1,
+					Latency:   time.Microsecond * 10,
+					RespTime:  time.Microsecond * 12,
+				}))
+			})
+		})
+
+		When("param", func() {
+			var mockParamBuilder *specbase.MockParamStatementBuilder
+			BeforeEach(func() {
+				mockParamBuilder = specbase.NewMockParamStatementBuilder(ctrl)
+			})
+
+			It("build failed", func() {
+				mockParamBuilder.EXPECT().BuildParam(gomock.Any()).Return("", nil, 0, 0, errors.ErrNoRecord)
+
+				i := New(mockParamBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).To(HaveOccurred())
+				Expect(IsRecordError(err)).To(BeTrue())
+				Expect(resp).To(BeNil())
+			})
+
+			It("all duplicates", func() {
+				mockParamBuilder.EXPECT().BuildParam(gomock.Any()).Return("", nil, 0, 1, nil)
+
+				i := New(mockParamBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{Duplicates: 1}))
+			})
+
+			It("execute successfully", func() {
+				params := map[string]any{"p0": "a"}
+				mockParamBuilder.EXPECT().BuildParam(gomock.Any(), gomock.Any()).Return("statement $p0", params, 1, 1, nil)
+				mockClientPool.EXPECT().ExecuteWithParameter(gomock.Any(), "statement $p0", params).Return(mockResponse, nil)
+				mockResponse.EXPECT().IsSucceed().Return(true)
+				mockResponse.EXPECT().GetLatency().Return(time.Microsecond * 10)
+				mockResponse.EXPECT().GetRespTime().Return(time.Microsecond * 12)
+
+				i := New(mockParamBuilder, mockClientPool)
+				resp, err := i.Import(context.Background(), spec.Record{"id1"}, spec.Record{"id1"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resp).To(Equal(&ImportResp{
+					RecordNum:  1,
+					Duplicates: 1,
+					Latency:    time.Microsecond * 10,
+					RespTime:   time.Microsecond * 12,
+				}))
+			})
+		})
+
 		It("execute successfully with Add, Wait and Done", func() {
 			mockBuilder.EXPECT().Build(gomock.Any()).Times(2).Return("statement", 1, nil)
-			mockClientPool.EXPECT().Execute(gomock.Any()).Times(2).Return(mockResponse, nil)
+			mockClientPool.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(2).Return(mockResponse, nil)
 			mockResponse.EXPECT().IsSucceed().Times(2).Return(true)
 			mockResponse.EXPECT().GetLatency().Times(2).Return(time.Microsecond * 10)
 			mockResponse.EXPECT().GetRespTime().AnyTimes().Return(time.Microsecond * 12)
@@ -125,7 +485,7 @@ var _ = Describe("Importer", func() {
 			go func() {
 				i1.Wait()
 				defer i1.Done()
-				resp, err := i1.Import(spec.Record{"id"})
+				resp, err := i1.Import(context.Background(), spec.Record{"id"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(resp).NotTo(BeNil())
 				Expect(resp.Latency).To(Equal(time.Microsecond * time.Duration(10)))
@@ -133,7 +493,7 @@ var _ = Describe("Importer", func() {
 
 			i2.Wait()
 			defer i2.Done()
-			resp, err := i2.Import(spec.Record{"id"})
+			resp, err := i2.Import(context.Background(), spec.Record{"id"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(resp).NotTo(BeNil())
 			Expect(resp.Latency).To(Equal(time.Microsecond * time.Duration(10)))
@@ -141,3 +501,31 @@ var _ = Describe("Importer", func() {
 		})
 	})
 })
+
+var _ = Describe("RetryPolicy", func() {
+	It("merge", func() {
+		p := DefaultRetryPolicy.merge(nil)
+		Expect(p).To(Equal(DefaultRetryPolicy))
+
+		p = DefaultRetryPolicy.merge(&RetryPolicy{Attempts: 10})
+		Expect(p.Attempts).To(Equal(uint(10)))
+		Expect(p.InitialInterval).To(Equal(DefaultRetryPolicy.InitialInterval))
+		Expect(p.MaxInterval).To(Equal(DefaultRetryPolicy.MaxInterval))
+	})
+
+	DescribeTable("delay",
+		func(n uint, expect time.Duration) {
+			p := RetryPolicy{
+				InitialInterval: time.Second,
+				MaxInterval:     time.Second * 5,
+			}
+			Expect(p.delay(n)).To(Equal(expect))
+		},
+		EntryDescription("%[1]d -> %[2]s"),
+		Entry(nil, uint(1), time.Second),
+		Entry(nil, uint(2), time.Second*2),
+		Entry(nil, uint(3), time.Second*4),
+		Entry(nil, uint(4), time.Second*5),
+		Entry(nil, uint(100), time.Second*5),
+	)
+})
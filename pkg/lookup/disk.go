@@ -0,0 +1,109 @@
+package lookup
+
+import (
+	"sync"
+
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+
+	"go.etcd.io/bbolt"
+)
+
+// diskDictionaryBatchSize is the number of the values put in one transaction while loading.
+const diskDictionaryBatchSize = 10000
+
+var (
+	_ Dictionary = (*diskDictionary)(nil)
+
+	diskDictionaryBucket = []byte("lookup")
+)
+
+// diskDictionary keeps the values in a temporary bolt database, for the dictionaries too large for memory.
+// The values are put in batches, and the database file is deleted when closed.
+type diskDictionary struct {
+	db *bbolt.DB
+
+	mu      sync.Mutex
+	pending map[string]string
+	length  int
+}
+
+func newDiskDictionary() (*diskDictionary, error) {
+	db, err := utils.OpenTempBolt("nebula-importer-lookup-*.db", diskDictionaryBucket)
+	if err != nil {
+		return nil, err
+	}
+	return &diskDictionary{
+		db:      db,
+		pending: make(map[string]string, diskDictionaryBatchSize),
+	}, nil
+}
+
+func (d *diskDictionary) Get(key string) (value string, ok bool, err error) {
+	if err = d.flush(); err != nil {
+		return "", false, err
+	}
+	err = d.db.View(func(tx *bbolt.Tx) error {
+		if v := tx.Bucket(diskDictionaryBucket).Get(utils.BoltKey(key)); v != nil {
+			value, ok = string(v), true
+		}
+		return nil
+	})
+	return value, ok, err
+}
+
+func (d *diskDictionary) Put(key, value string) error {
+	d.mu.Lock()
+	if _, ok := d.pending[key]; !ok {
+		d.pending[key] = value
+	}
+	n := len(d.pending)
+	d.mu.Unlock()
+
+	if n >= diskDictionaryBatchSize {
+		return d.flush()
+	}
+	return nil
+}
+
+func (d *diskDictionary) Len() int {
+	// The error is returned by the subsequent Get, and the pending values are not counted until then.
+	_ = d.flush()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.length
+}
+
+func (d *diskDictionary) Close() error {
+	return utils.CloseTempBolt(d.db)
+}
+
+// flush puts the pending values in one transaction, the first value wins for the duplicate keys.
+func (d *diskDictionary) flush() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(d.pending) == 0 {
+		return nil
+	}
+	var n int
+	if err := d.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(diskDictionaryBucket)
+		for key, value := range d.pending {
+			k := utils.BoltKey(key)
+			if b.Get(k) != nil {
+				continue
+			}
+			if err := b.Put(k, []byte(value)); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	d.length += n
+	clear(d.pending)
+	return nil
+}
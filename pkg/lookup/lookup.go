@@ -0,0 +1,162 @@
+package lookup
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	"github.com/lucky-xin/nebula-importer/pkg/reader"
+)
+
+const (
+	// The policies on a miss of the key.
+	MissPolicyError   = "error"   // Fail the record.
+	MissPolicyNull    = "null"    // Return nil, which can be checked in the expressions.
+	MissPolicyDefault = "default" // Return the default value.
+
+	// The storages of the dictionaries.
+	StorageMemory = "memory"
+	StorageDisk   = "disk"
+)
+
+var _ picker.Lookup = (*Lookup)(nil)
+
+type (
+	// Dictionary stores the values by the keys, the first value wins for the duplicate keys.
+	Dictionary interface {
+		Get(key string) (value string, ok bool, err error)
+		Put(key, value string) error
+		Len() int
+		Close() error
+	}
+
+	// Lookup finds the values in a dictionary, and handles the misses by the policy.
+	Lookup struct {
+		name         string
+		dict         Dictionary
+		missPolicy   string
+		defaultValue *string
+	}
+
+	Option func(*Lookup)
+)
+
+// NewDictionary returns the dictionary of the storage, memory by default.
+func NewDictionary(storage string) (Dictionary, error) {
+	switch strings.ToLower(storage) {
+	case "", StorageMemory:
+		return newMemoryDictionary(), nil
+	case StorageDisk:
+		return newDiskDictionary()
+	}
+	return nil, errors.NewImportError(errors.ErrUnsupportedLookupStorage, "unsupported lookup storage %q", storage)
+}
+
+func New(name string, dict Dictionary, opts ...Option) (*Lookup, error) {
+	l := &Lookup{
+		name:       name,
+		dict:       dict,
+		missPolicy: MissPolicyError,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	switch l.missPolicy {
+	case MissPolicyError, MissPolicyNull:
+	case MissPolicyDefault:
+		if l.defaultValue == nil {
+			return nil, errors.NewImportError(errors.ErrUnsupportedMissPolicy,
+				"lookup %q: no default value for miss policy %s", name, l.missPolicy)
+		}
+	default:
+		return nil, errors.NewImportError(errors.ErrUnsupportedMissPolicy,
+			"lookup %q: unsupported miss policy %q", name, l.missPolicy)
+	}
+	return l, nil
+}
+
+func WithMissPolicy(policy string) Option {
+	return func(l *Lookup) {
+		if policy != "" {
+			l.missPolicy = strings.ToLower(policy)
+		}
+	}
+}
+
+func WithDefaultValue(defaultValue *string) Option {
+	return func(l *Lookup) {
+		l.defaultValue = defaultValue
+	}
+}
+
+func (l *Lookup) Name() string {
+	return l.name
+}
+
+func (l *Lookup) Len() int {
+	return l.dict.Len()
+}
+
+func (l *Lookup) Lookup(key string) (any, error) {
+	value, ok, err := l.dict.Get(key)
+	if err != nil {
+		return nil, errors.NewImportError(err, "lookup %q: get key %q failed", l.name, key)
+	}
+	if ok {
+		return value, nil
+	}
+	switch l.missPolicy {
+	case MissPolicyNull:
+		return nil, nil
+	case MissPolicyDefault:
+		return *l.defaultValue, nil
+	}
+	return nil, errors.NewImportError(errors.ErrLookupMiss, "lookup %q: miss key %q", l.name, key)
+}
+
+func (l *Lookup) Close() error {
+	return l.dict.Close()
+}
+
+// Load puts the values at valueIndex by the keys at keyIndex of all the records into the dictionary.
+func Load(ctx context.Context, brr reader.BatchRecordReader, keyIndex, valueIndex int, dict Dictionary) error {
+	for {
+		_, records, err := brr.ReadBatch(ctx)
+		if err != nil {
+			if stderrors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		for _, record := range records {
+			if keyIndex >= len(record) || valueIndex >= len(record) {
+				return errors.NewImportError(errors.ErrNoRecord,
+					"record %v has no key index %d or value index %d", record, keyIndex, valueIndex)
+			}
+			if err = dict.Put(record[keyIndex], record[valueIndex]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Closers returns the lookups which are closers, such as the lookups on disk.
+func Closers(lookups picker.Lookups) []io.Closer {
+	closers := make([]io.Closer, 0, len(lookups))
+	for _, l := range lookups {
+		if c, ok := l.(io.Closer); ok {
+			closers = append(closers, c)
+		}
+	}
+	return closers
+}
+
+// CloseAll closes the lookups which are closers.
+func CloseAll(lookups picker.Lookups) {
+	for _, c := range Closers(lookups) {
+		_ = c.Close()
+	}
+}
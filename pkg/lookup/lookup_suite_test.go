@@ -0,0 +1,13 @@
+package lookup
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestLookup(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pkg lookup Suite")
+}
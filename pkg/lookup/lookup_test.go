@@ -0,0 +1,172 @@
+package lookup
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"strconv"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	"github.com/lucky-xin/nebula-importer/pkg/reader"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dictionary", func() {
+	DescribeTable("Get and Put",
+		func(storage string) {
+			dict, err := NewDictionary(storage)
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				Expect(dict.Close()).To(Succeed())
+			}()
+
+			Expect(dict.Put("k1", "v1")).To(Succeed())
+			Expect(dict.Put("k2", "v2")).To(Succeed())
+			Expect(dict.Put("k1", "v3")).To(Succeed())
+			Expect(dict.Len()).To(Equal(2))
+
+			value, ok, err := dict.Get("k1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(value).To(Equal("v1"))
+
+			value, ok, err = dict.Get("k3")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			Expect(value).To(Equal(""))
+		},
+		Entry("default", ""),
+		Entry("memory", StorageMemory),
+		Entry("disk", StorageDisk),
+		Entry("disk upper case", "DISK"),
+	)
+
+	It("disk in batches", func() {
+		dict, err := NewDictionary(StorageDisk)
+		Expect(err).NotTo(HaveOccurred())
+		path := dict.(*diskDictionary).db.Path()
+		Expect(path).To(BeAnExistingFile())
+
+		n := diskDictionaryBatchSize + 10
+		for i := 0; i < n; i++ {
+			Expect(dict.Put(strconv.Itoa(i), "v"+strconv.Itoa(i))).To(Succeed())
+		}
+		Expect(dict.Put("", "empty")).To(Succeed())
+		Expect(dict.Put("0", "v")).To(Succeed())
+		Expect(dict.Len()).To(Equal(n + 1))
+
+		for _, key := range []string{"0", strconv.Itoa(n - 1), ""} {
+			value, ok, err := dict.Get(key)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(value).NotTo(Equal("v"))
+		}
+
+		Expect(dict.Close()).To(Succeed())
+		Expect(path).NotTo(BeAnExistingFile())
+	})
+
+	It("unsupported storage", func() {
+		dict, err := NewDictionary("unsupported")
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnsupportedLookupStorage)).To(BeTrue())
+		Expect(dict).To(BeNil())
+	})
+})
+
+var _ = Describe("Lookup", func() {
+	var dict Dictionary
+	BeforeEach(func() {
+		dict = newMemoryDictionary()
+		Expect(dict.Put("1", "Tom")).To(Succeed())
+	})
+
+	DescribeTable("Lookup",
+		func(opts []Option, key string, expectValue any, expectErr error) {
+			l, err := New("users", dict, opts...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(l.Name()).To(Equal("users"))
+			Expect(l.Len()).To(Equal(1))
+
+			value, err := l.Lookup(key)
+			if expectErr != nil {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+			if expectValue == nil {
+				Expect(value).To(BeNil())
+			} else {
+				Expect(value).To(Equal(expectValue))
+			}
+		},
+		Entry("hit", nil, "1", "Tom", nil),
+		Entry("miss error", nil, "2", nil, errors.ErrLookupMiss),
+		Entry("miss null", []Option{WithMissPolicy(MissPolicyNull)}, "2", nil, nil),
+		Entry("miss default", []Option{WithMissPolicy("DEFAULT"), WithDefaultValue(func() *string { s := "unknown"; return &s }())},
+			"2", "unknown", nil),
+	)
+
+	DescribeTable("New failed",
+		func(opts ...Option) {
+			l, err := New("users", dict, opts...)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrUnsupportedMissPolicy)).To(BeTrue())
+			Expect(l).To(BeNil())
+		},
+		Entry("unsupported", WithMissPolicy("unsupported")),
+		Entry("default without value", WithMissPolicy(MissPolicyDefault)),
+	)
+
+	It("CloseAll", func() {
+		l, err := New("users", dict)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(Closers(picker.Lookups{"users": l})).To(HaveLen(1))
+		CloseAll(picker.Lookups{"users": l})
+	})
+})
+
+var _ = Describe("Load", func() {
+	var (
+		ctrl    *gomock.Controller
+		mockBrr *reader.MockBatchRecordReader
+	)
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		mockBrr = reader.NewMockBatchRecordReader(ctrl)
+	})
+	AfterEach(func() {
+		ctrl.Finish()
+	})
+
+	It("successfully", func() {
+		gomock.InOrder(
+			mockBrr.EXPECT().ReadBatch(gomock.Any()).Return(10, spec.Records{{"1", "Tom"}, {"2", "Jerry"}}, nil),
+			mockBrr.EXPECT().ReadBatch(gomock.Any()).Return(5, spec.Records{{"3", "Spike"}}, nil),
+			mockBrr.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+		)
+		dict := newMemoryDictionary()
+		Expect(Load(context.Background(), mockBrr, 0, 1, dict)).To(Succeed())
+		Expect(dict.m).To(Equal(map[string]string{"1": "Tom", "2": "Jerry", "3": "Spike"}))
+	})
+
+	It("read failed", func() {
+		mockBrr.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, stderrors.New("test error"))
+		err := Load(context.Background(), mockBrr, 0, 1, newMemoryDictionary())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("test error"))
+	})
+
+	It("no record", func() {
+		mockBrr.EXPECT().ReadBatch(gomock.Any()).Return(10, spec.Records{{"1"}}, nil)
+		err := Load(context.Background(), mockBrr, 0, 1, newMemoryDictionary())
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrNoRecord)).To(BeTrue())
+	})
+})
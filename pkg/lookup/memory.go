@@ -0,0 +1,34 @@
+package lookup
+
+var _ Dictionary = (*memoryDictionary)(nil)
+
+// memoryDictionary keeps all the values in memory, which is read only after loaded.
+type memoryDictionary struct {
+	m map[string]string
+}
+
+func newMemoryDictionary() *memoryDictionary {
+	return &memoryDictionary{
+		m: map[string]string{},
+	}
+}
+
+func (d *memoryDictionary) Get(key string) (value string, ok bool, err error) {
+	value, ok = d.m[key]
+	return value, ok, nil
+}
+
+func (d *memoryDictionary) Put(key, value string) error {
+	if _, ok := d.m[key]; !ok {
+		d.m[key] = value
+	}
+	return nil
+}
+
+func (d *memoryDictionary) Len() int {
+	return len(d.m)
+}
+
+func (*memoryDictionary) Close() error {
+	return nil
+}
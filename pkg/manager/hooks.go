@@ -18,5 +18,7 @@ type (
 	Hook struct {
 		Statements []string      `yaml:"statements" json:"statements,omitempty,optional"`
 		Wait       time.Duration `yaml:"wait,omitempty" json:"wait,omitempty,optional"`
+		// Space is the space to execute the statements, empty for the space of the manager.
+		Space string `yaml:"space,omitempty" json:"space,omitempty,optional"`
 	}
 )
@@ -2,6 +2,8 @@
 package manager
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"sync"
@@ -20,27 +22,76 @@ import (
 	"github.com/panjf2000/ants/v2"
 )
 
+var errStopped = stderrors.New("manager stopped")
+
 const (
 	DefaultReaderConcurrency   = 50
 	DefaultImporterConcurrency = 512
 	DefaultStatsInterval       = time.Second * 10
+	// DefaultMaxRecordedErrors is the maximum number of errors recorded for the summary.
+	DefaultMaxRecordedErrors = 100
+	// DefaultMaxFailedRatioMinRecords is the minimum number of processed records before checking the maxFailedRatio.
+	DefaultMaxFailedRatioMinRecords = 1000
 )
 
 type (
 	Manager interface {
 		Import(s source.Source, brr reader.BatchRecordReader, importers ...importer.Importer) error
-		Start() error
+		// ImportSpace is the same as Import, and counts the stats of the source in the space, empty for the default one.
+		ImportSpace(space string, s source.Source, brr reader.BatchRecordReader, importers ...importer.Importer) error
+		// Start starts to import, the import is canceled once the ctx is done.
+		Start(ctx context.Context) error
 		Wait() error
 		Stats() *stats.Stats
 		Stop() error
+		// SourcesStats returns the stats of each source in the order of Import.
+		SourcesStats() []*SourceStats
+		// SpacesStats returns the stats of each space in the order of the first Import into it.
+		SpacesStats() []*SpaceStats
+		// Errors returns the first DefaultMaxRecordedErrors errors occurred.
+		Errors() []error
+	}
+
+	SourceStats struct {
+		Name  string       `json:"name"`
+		Space string       `json:"space,omitempty"`
+		Stats *stats.Stats `json:"stats"`
+	}
+
+	SpaceStats struct {
+		Space string       `json:"space"`
+		Stats *stats.Stats `json:"stats"`
+	}
+
+	sourceStats struct {
+		name       string
+		stats      *stats.ConcurrencyStats
+		spaceStats *spaceStats
+	}
+
+	spaceStats struct {
+		space string
+		stats *stats.ConcurrencyStats
 	}
 
 	defaultManager struct {
 		graphName           string
 		recordStats         bool
+		splitFailedBatch    bool
+		maxFailedRecords    int64
+		maxFailedRatio      float64
+		maxFailedRatioMin   int64
 		pool                client.Pool
+		spacePools          map[string]client.Pool
+		closers             []io.Closer
+		spaces              []string
 		getClientOptions    []client.Option
 		stats               *stats.ConcurrencyStats
+		sourcesStatsMu      sync.Mutex
+		sourcesStats        []*sourceStats
+		spacesStats         []*spaceStats
+		recordedErrorsMu    sync.Mutex
+		recordedErrors      []error
 		batch               int
 		readerConcurrency   int
 		readerWaitGroup     sync.WaitGroup
@@ -51,8 +102,13 @@ type (
 		statsInterval       time.Duration
 		hooks               *Hooks
 		chStart             chan struct{}
+		ctx                 context.Context
+		cancel              context.CancelCauseFunc
 		done                chan struct{}
+		stopped             chan struct{}
 		isStopped           atomic.Bool
+		abortOnce           sync.Once
+		abortErr            atomic.Pointer[errors.ImportError]
 		logger              logger.Logger
 	}
 
@@ -71,9 +127,11 @@ func NewWithOpts(opts ...Option) Manager {
 		readerConcurrency:   DefaultReaderConcurrency,
 		importerConcurrency: DefaultImporterConcurrency,
 		statsInterval:       DefaultStatsInterval,
+		maxFailedRatioMin:   DefaultMaxFailedRatioMinRecords,
 		hooks:               &Hooks{},
 		chStart:             make(chan struct{}),
 		done:                make(chan struct{}),
+		stopped:             make(chan struct{}),
 	}
 
 	for _, opt := range opts {
@@ -102,12 +160,70 @@ func WithClientPool(pool client.Pool) Option {
 	}
 }
 
+// WithSpacePool sets the pool whose sessions use the space, which is opened on Start and closed on Stop.
+// The hooks of the space are executed by the pool.
+func WithSpacePool(space string, pool client.Pool) Option {
+	return func(m *defaultManager) {
+		if m.spacePools == nil {
+			m.spacePools = make(map[string]client.Pool)
+		}
+		if _, ok := m.spacePools[space]; !ok {
+			m.spaces = append(m.spaces, space)
+		}
+		m.spacePools[space] = pool
+	}
+}
+
+// WithClosers sets the resources used by the importers, such as the lookups on disk, which are closed on Stop.
+func WithClosers(closers ...io.Closer) Option {
+	return func(m *defaultManager) {
+		m.closers = append(m.closers, closers...)
+	}
+}
+
 func WithRecordStats(recordStats bool) Option {
 	return func(m *defaultManager) {
 		m.recordStats = recordStats
 	}
 }
 
+// WithSplitFailedBatch enables to split the batch failed with a permanent error recursively,
+// so that only the bad records are reported as failed.
+func WithSplitFailedBatch(splitFailedBatch bool) Option {
+	return func(m *defaultManager) {
+		m.splitFailedBatch = splitFailedBatch
+	}
+}
+
+// WithMaxFailedRecords aborts the import once the failed records exceed maxFailedRecords, 0 means no limit.
+func WithMaxFailedRecords(maxFailedRecords int64) Option {
+	return func(m *defaultManager) {
+		if maxFailedRecords > 0 {
+			m.maxFailedRecords = maxFailedRecords
+		}
+	}
+}
+
+// WithMaxFailedRatio aborts the import once the ratio of failed records to processed records exceeds maxFailedRatio,
+// 0 means no limit.
+func WithMaxFailedRatio(maxFailedRatio float64) Option {
+	return func(m *defaultManager) {
+		if maxFailedRatio > 0 {
+			m.maxFailedRatio = maxFailedRatio
+		}
+	}
+}
+
+// WithMaxFailedRatioMinRecords checks the maxFailedRatio only after minRecords records are processed,
+// so that a failed early batch does not abort the import.
+func WithMaxFailedRatioMinRecords(minRecords int64) Option {
+	return func(m *defaultManager) {
+		if minRecords > 0 {
+			m.maxFailedRatioMin = minRecords
+		}
+	}
+}
+
 func WithGetClientOptions(opts ...client.Option) Option {
 	return func(m *defaultManager) {
 		m.getClientOptions = opts
@@ -164,12 +280,25 @@ func WithLogger(l logger.Logger) Option {
 }
 
 func (m *defaultManager) Import(s source.Source, brr reader.BatchRecordReader, importers ...importer.Importer) error {
+	return m.ImportSpace("", s, brr, importers...)
+}
+
+func (m *defaultManager) ImportSpace(
+	space string,
+	s source.Source,
+	brr reader.BatchRecordReader,
+	importers ...importer.Importer,
+) error {
 	if len(importers) == 0 {
 		return nil
 	}
-	logSourceField := logger.Field{Key: "source", Value: s.Name()}
+	if space == "" {
+		space = m.graphName
+	}
+	name := s.Name()
+	logSourceField := logger.Field{Key: "source", Value: name}
 	if err := s.Open(); err != nil {
-		err = errors.NewImportError(err, "manager: open import source failed").SetGraphName(m.graphName)
+		err = errors.NewImportError(err, "manager: open import source failed").SetGraphName(space)
 		m.logError(err, "", logSourceField)
 		return err
 	}
@@ -177,11 +306,13 @@ func (m *defaultManager) Import(s source.Source, brr reader.BatchRecordReader, i
 	n, err := s.Size()
 	if err != nil {
 		_ = s.Close()
-		err = errors.NewImportError(err, "manager: get size of import source failed").SetGraphName(m.graphName)
+		closeReader(brr)
+		err = errors.NewImportError(err, "manager: get size of import source failed").SetGraphName(space)
 		m.logError(err, "", logSourceField)
 		return err
 	}
 	m.stats.AddTotal(n)
+	ss := m.addSourceStats(name, space, n)
 
 	m.readerWaitGroup.Add(1)
 	for _, i := range importers {
@@ -194,6 +325,7 @@ func (m *defaultManager) Import(s source.Source, brr reader.BatchRecordReader, i
 		}
 		m.readerWaitGroup.Done()
 		_ = s.Close()
+		closeReader(brr)
 	}
 
 	go func() {
@@ -204,7 +336,7 @@ func (m *defaultManager) Import(s source.Source, brr reader.BatchRecordReader, i
 			for _, i := range importers {
 				i.Wait()
 			}
-			_ = m.loopImport(s, brr, importers...)
+			_ = m.loopImport(s, ss, brr, importers...)
 		})
 		if err != nil {
 			cleanup()
@@ -216,18 +348,34 @@ func (m *defaultManager) Import(s source.Source, brr reader.BatchRecordReader, i
 	return nil
 }
 
-func (m *defaultManager) Start() error {
+func (m *defaultManager) Start(ctx context.Context) error {
 	m.logger.Info("manager: starting")
 
+	m.ctx, m.cancel = context.WithCancelCause(ctx)
+
 	if err := m.Before(); err != nil {
 		return err
 	}
 
 	m.stats.Init()
+	m.sourcesStatsMu.Lock()
+	for _, ss := range m.sourcesStats {
+		ss.stats.Init()
+	}
+	for _, ss := range m.spacesStats {
+		ss.stats.Init()
+	}
+	m.sourcesStatsMu.Unlock()
 
 	if err := m.pool.Open(); err != nil {
 		m.logger.WithError(err).Error("manager: start client pool failed")
-		return err
+		return fmt.Errorf("%w: %w", errors.ErrConnectFailed, err)
+	}
+	for _, space := range m.spaces {
+		if err := m.spacePools[space].Open(); err != nil {
+			m.logger.WithError(err).Error("manager: start client pool failed", logger.Field{Key: "space", Value: space})
+			return fmt.Errorf("%w: %w", errors.ErrConnectFailed, err)
+		}
 	}
 
 	close(m.chStart)
@@ -244,18 +392,68 @@ func (m *defaultManager) Wait() error {
 	m.importerWaitGroup.Wait()
 
 	m.logger.Info("manager: wait successfully")
-	return m.Stop()
+
+	// The ctx is canceled by the caller rather than Stop.
+	var errCanceled error
+	if m.ctx != nil {
+		if cause := context.Cause(m.ctx); cause != nil && !stderrors.Is(cause, errStopped) {
+			errCanceled = errors.NewImportError(cause, "manager: canceled").SetGraphName(m.graphName)
+		}
+	}
+
+	if err := m.Stop(); err != nil {
+		return err
+	}
+	if err := m.abortErr.Load(); err != nil {
+		return err
+	}
+	return errCanceled
 }
 
 func (m *defaultManager) Stats() *stats.Stats {
 	return m.stats.Stats()
 }
 
+func (m *defaultManager) SourcesStats() []*SourceStats {
+	m.sourcesStatsMu.Lock()
+	defer m.sourcesStatsMu.Unlock()
+	sourcesStats := make([]*SourceStats, 0, len(m.sourcesStats))
+	for _, ss := range m.sourcesStats {
+		sourcesStats = append(sourcesStats, &SourceStats{
+			Name:  ss.name,
+			Space: ss.spaceStats.space,
+			Stats: ss.stats.Stats(),
+		})
+	}
+	return sourcesStats
+}
+
+func (m *defaultManager) SpacesStats() []*SpaceStats {
+	m.sourcesStatsMu.Lock()
+	defer m.sourcesStatsMu.Unlock()
+	spacesStats := make([]*SpaceStats, 0, len(m.spacesStats))
+	for _, ss := range m.spacesStats {
+		spacesStats = append(spacesStats, &SpaceStats{
+			Space: ss.space,
+			Stats: ss.stats.Stats(),
+		})
+	}
+	return spacesStats
+}
+
+func (m *defaultManager) Errors() []error {
+	m.recordedErrorsMu.Lock()
+	defer m.recordedErrorsMu.Unlock()
+	return append([]error(nil), m.recordedErrors...)
+}
+
 func (m *defaultManager) Stop() (err error) {
-	if m.isStopped.Load() {
+	if !m.isStopped.CompareAndSwap(false, true) {
+		// wait for the first stop to finish
+		<-m.stopped
 		return nil
 	}
-	m.isStopped.Store(true)
+	defer close(m.stopped)
 
 	m.logger.Info("manager: stop")
 	defer func() {
@@ -266,26 +464,56 @@ func (m *defaultManager) Stop() (err error) {
 			m.logger.Info("manager: stop successfully")
 		}
 	}()
+	if m.cancel != nil {
+		// cancel the in-flight reads and executions.
+		m.cancel(errStopped)
+	}
 	close(m.done)
 
 	m.readerWaitGroup.Wait()
 	m.importerWaitGroup.Wait()
 
 	m.logStats()
-	return m.After()
+	err = m.After()
+	for _, space := range m.spaces {
+		_ = m.spacePools[space].Close()
+	}
+	for _, c := range m.closers {
+		_ = c.Close()
+	}
+	return err
 }
 
 func (m *defaultManager) Before() error {
 	m.logger.Info("manager: exec before hook")
-	return m.execHooks(BeforeHook)
+	return m.execHooks(m.ctx, BeforeHook)
 }
 
 func (m *defaultManager) After() error {
 	m.logger.Info("manager: exec after hook")
-	return m.execHooks(AfterHook)
+	ctx := context.Background()
+	if m.ctx != nil {
+		// The after hooks, such as to restore the configs, still need to be executed after canceled.
+		ctx = context.WithoutCancel(m.ctx)
+	}
+	return m.execHooks(ctx, AfterHook)
+}
+
+// waitHook waits for the duration after the hook, or returns the error once the ctx is canceled.
+func (m *defaultManager) waitHook(ctx context.Context, name HookName, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		err := errors.NewImportError(context.Cause(ctx), "manager: canceled while waiting in %s hook", name)
+		m.logError(err, "")
+		return err
+	}
 }
 
-func (m *defaultManager) execHooks(name HookName) error {
+func (m *defaultManager) execHooks(ctx context.Context, name HookName) error {
 	var hooks []*Hook
 	switch name {
 	case BeforeHook:
@@ -297,7 +525,8 @@ func (m *defaultManager) execHooks(name HookName) error {
 		return nil
 	}
 
-	var cli client.Client
+	// The clients by the spaces of the hooks, and the empty space is for the hooks without space.
+	clients := make(map[string]client.Client, 1)
 	for _, hook := range hooks {
 		if hook == nil {
 			continue
@@ -307,14 +536,15 @@ func (m *defaultManager) execHooks(name HookName) error {
 				continue
 			}
 
-			if cli == nil {
+			cli, ok := clients[hook.Space]
+			if !ok {
 				var err error
-				cli, err = m.pool.GetClient(m.getClientOptions...)
-				if err != nil {
+				if cli, err = m.hookClient(hook.Space); err != nil {
 					return err
 				}
+				clients[hook.Space] = cli
 			}
-			resp, err := cli.Execute(statement)
+			resp, err := cli.Execute(ctx, statement)
 			if err != nil {
 				err = errors.NewImportError(err,
 					"manager: exec failed in %s hook", name,
@@ -332,34 +562,69 @@ func (m *defaultManager) execHooks(name HookName) error {
 		}
 		if hook.Wait != 0 {
 			m.logger.Info(fmt.Sprintf("manager: waiting %s", hook.Wait))
-			time.Sleep(hook.Wait)
+			if err := m.waitHook(ctx, name, hook.Wait); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-func (m *defaultManager) loopImport(s source.Source, r reader.BatchRecordReader, importers ...importer.Importer) error {
+// hookClient returns the client to execute the statements of the hooks in the space.
+func (m *defaultManager) hookClient(space string) (client.Client, error) {
+	var (
+		cli client.Client
+		err error
+	)
+	// The hooks are not retried by the importers, so the client retries them.
+	noRetry := client.WithNoRetry(false)
+	switch pool, ok := m.spacePools[space]; {
+	case space == "":
+		cli, err = m.pool.GetClient(append(m.getClientOptions[:len(m.getClientOptions):len(m.getClientOptions)], noRetry)...)
+	case ok:
+		cli, err = pool.GetClient(noRetry)
+	case space == m.graphName:
+		cli, err = m.pool.GetClient(noRetry)
+	default:
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "manager: no client pool for the space of hook").
+			SetGraphName(space)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrConnectFailed, err)
+	}
+	return cli, nil
+}
+
+func (m *defaultManager) loopImport(s source.Source, ss *sourceStats, r reader.BatchRecordReader, importers ...importer.Importer) error {
 	logSourceField := logger.Field{Key: "source", Value: s.Name()}
 	for {
 		select {
 		case <-m.done:
 			return nil
+		case <-m.ctx.Done():
+			return nil
 		default:
-			n, records, err := r.ReadBatch()
+			n, records, err := r.ReadBatch(m.ctx)
 			if err != nil {
+				if m.ctx.Err() != nil {
+					return nil
+				}
 				if err != io.EOF {
-					err = errors.NewImportError(err, "manager: read batch failed").SetGraphName(m.graphName)
+					err = errors.NewImportError(err, "manager: read batch failed").SetGraphName(ss.spaceStats.space)
 					m.logError(err, "", logSourceField)
 					return err
 				}
 				return nil
 			}
-			m.submitImporterTask(n, records, importers...)
+			if cfr, ok := r.(reader.ConvertFailedReader); ok {
+				m.onConvertFailed(ss, cfr.ConvertFailed(), logSourceField)
+			}
+			m.submitImporterTask(ss, n, records, importers...)
 		}
 	}
 }
 
-func (m *defaultManager) submitImporterTask(n int, records spec.Records, importers ...importer.Importer) {
+func (m *defaultManager) submitImporterTask(ss *sourceStats, n int, records spec.Records, importers ...importer.Importer) {
 	importersDone := func() {
 		for _, i := range importers {
 			i.Done() // Done 1 for batch
@@ -390,24 +655,19 @@ func (m *defaultManager) submitImporterTask(n int, records spec.Records, importe
 						end = size
 					}
 					subs := records[start:end]
-					result, err := i.Import(subs...)
-					if err != nil {
-						m.logError(err, "manager: import failed")
-						m.onRequestFailed(subs)
-						faileds = append(faileds, subs...)
-						// do not return, continue the subsequent importer.
-					} else {
-						if result.RecordNum > 0 {
-							m.onRequestSucceeded(result)
-						}
-						succeededs = append(succeededs, subs...)
-					}
+					subFaileds, subSucceededs := m.importRecords(ss, i, subs)
+					// do not return when failed, continue the subsequent importer.
+					faileds = append(faileds, subFaileds...)
+					succeededs = append(succeededs, subSucceededs...)
 				}
 			}
 		}
 		m.logger.Debug(fmt.Sprintf("manager: import %d records, n:%d successfully", size, n))
-		m.onFailed(0, faileds)
-		m.onSucceeded(n, succeededs)
+		m.onFailed(ss, 0, faileds)
+		m.onSucceeded(ss, n, succeededs)
+		if len(faileds) > 0 {
+			m.checkFailed()
+		}
 	}); err != nil {
 		importersDone()
 		m.importerWaitGroup.Done()
@@ -415,6 +675,78 @@ func (m *defaultManager) submitImporterTask(n int, records spec.Records, importe
 	}
 }
 
+// importRecords imports the records and returns the failed and succeeded ones.
+// If splitFailedBatch is enabled, the batch failed with a permanent error is split into two halves
+// and imported again recursively, until the bad records are isolated.
+func (m *defaultManager) importRecords(ss *sourceStats, i importer.Importer, records spec.Records) (faileds, succeededs spec.Records) {
+	result, err := i.Import(m.ctx, records...)
+	if err == nil {
+		if result.RecordNum > 0 {
+			m.onRequestSucceeded(ss, result)
+		}
+		if result.Duplicates > 0 {
+			m.onDuplicated(ss, result.Duplicates)
+		}
+		if result.Unverified > 0 {
+			m.onUnverified(ss, result.Unverified)
+		}
+		if result.Stale > 0 {
+			m.onStale(ss, result.Stale)
+		}
+		return nil, records
+	}
+
+	if m.splitFailedBatch && len(records) > 1 && importer.IsPermanentError(err) {
+		m.logger.Debug(fmt.Sprintf("manager: split the failed batch of %d records", len(records)))
+		mid := len(records) / 2
+		leftFaileds, leftSucceededs := m.importRecords(ss, i, records[:mid])
+		rightFaileds, rightSucceededs := m.importRecords(ss, i, records[mid:])
+		// The halves share the underlying array with records, so do not append to them in place.
+		faileds = make(spec.Records, 0, len(leftFaileds)+len(rightFaileds))
+		succeededs = make(spec.Records, 0, len(leftSucceededs)+len(rightSucceededs))
+		faileds = append(append(faileds, leftFaileds...), rightFaileds...)
+		succeededs = append(append(succeededs, leftSucceededs...), rightSucceededs...)
+		return faileds, succeededs
+	}
+
+	m.logError(err, "manager: import failed")
+	if !importer.IsRecordError(err) {
+		m.onRequestFailed(ss, records)
+	}
+	return records, nil
+}
+
+// checkFailed stops the manager if the failed records exceed the thresholds.
+func (m *defaultManager) checkFailed() {
+	if m.maxFailedRecords <= 0 && m.maxFailedRatio <= 0 {
+		return
+	}
+	s := m.Stats()
+	var exceeded bool
+	if m.maxFailedRecords > 0 && s.FailedRecords > m.maxFailedRecords {
+		exceeded = true
+	}
+	if m.maxFailedRatio > 0 && s.TotalRecords >= m.maxFailedRatioMin && float64(s.FailedRecords)/float64(s.TotalRecords) > m.maxFailedRatio {
+		exceeded = true
+	}
+	if !exceeded {
+		return
+	}
+
+	m.abortOnce.Do(func() {
+		err := errors.NewImportError(errors.ErrTooManyFailures,
+			"manager: abort, %d of %d records failed, maxFailedRecords: %d, maxFailedRatio: %g",
+			s.FailedRecords, s.TotalRecords, m.maxFailedRecords, m.maxFailedRatio,
+		).SetGraphName(m.graphName)
+		m.abortErr.Store(err)
+		m.logError(err, "")
+		// Stop waits for all the importer tasks, including the current one, so do not block here.
+		go func() {
+			_ = m.Stop()
+		}()
+	})
+}
+
 func (m *defaultManager) loopPrintStats() {
 	if m.statsInterval <= 0 {
 		return
@@ -435,23 +767,102 @@ func (m *defaultManager) logStats() {
 	m.logger.Info(m.Stats().String())
 }
 
-func (m *defaultManager) onFailed(nBytes int, records spec.Records) {
+func (m *defaultManager) addSourceStats(name, space string, total int64) *sourceStats {
+	ss := &sourceStats{
+		name:  name,
+		stats: stats.NewConcurrencyStats(m.recordStats),
+	}
+	ss.stats.AddTotal(total)
+	m.sourcesStatsMu.Lock()
+	defer m.sourcesStatsMu.Unlock()
+	for _, spaceStats := range m.spacesStats {
+		if spaceStats.space == space {
+			ss.spaceStats = spaceStats
+			break
+		}
+	}
+	if ss.spaceStats == nil {
+		ss.spaceStats = &spaceStats{
+			space: space,
+			stats: stats.NewConcurrencyStats(m.recordStats),
+		}
+		m.spacesStats = append(m.spacesStats, ss.spaceStats)
+	}
+	ss.spaceStats.stats.AddTotal(total)
+	m.sourcesStats = append(m.sourcesStats, ss)
+	return ss
+}
+
+func (m *defaultManager) onFailed(ss *sourceStats, nBytes int, records spec.Records) {
 	m.stats.Failed(int64(nBytes), int64(len(records)))
+	ss.stats.Failed(int64(nBytes), int64(len(records)))
+	ss.spaceStats.stats.Failed(int64(nBytes), int64(len(records)))
+}
+
+// onConvertFailed counts the records skipped by the reader as failed,
+// such as the malformed CDC envelopes or the WASM module fails to convert them.
+func (m *defaultManager) onConvertFailed(ss *sourceStats, records spec.Records, fields ...logger.Field) {
+	if len(records) == 0 {
+		return
+	}
+	err := errors.NewImportError(errors.ErrInvalidRecord,
+		"manager: %d records failed to read or convert", len(records),
+	).SetGraphName(ss.spaceStats.space)
+	m.logError(err, "", fields...)
+	m.onFailed(ss, 0, records)
+	m.checkFailed()
 }
 
-func (m *defaultManager) onSucceeded(nBytes int, records spec.Records) {
+func (m *defaultManager) onSucceeded(ss *sourceStats, nBytes int, records spec.Records) {
 	m.stats.Succeeded(int64(nBytes), int64(len(records)))
+	ss.stats.Succeeded(int64(nBytes), int64(len(records)))
+	ss.spaceStats.stats.Succeeded(int64(nBytes), int64(len(records)))
 }
 
-func (m *defaultManager) onRequestFailed(records spec.Records) {
+func (m *defaultManager) onRequestFailed(ss *sourceStats, records spec.Records) {
 	m.stats.RequestFailed(int64(len(records)))
+	ss.stats.RequestFailed(int64(len(records)))
+	ss.spaceStats.stats.RequestFailed(int64(len(records)))
 }
 
-func (m *defaultManager) onRequestSucceeded(result *importer.ImportResp) {
+func (m *defaultManager) onRequestSucceeded(ss *sourceStats, result *importer.ImportResp) {
 	m.stats.RequestSucceeded(int64(result.RecordNum), result.Latency, result.RespTime)
+	ss.stats.RequestSucceeded(int64(result.RecordNum), result.Latency, result.RespTime)
+	ss.spaceStats.stats.RequestSucceeded(int64(result.RecordNum), result.Latency, result.RespTime)
+}
+
+func (m *defaultManager) onDuplicated(ss *sourceStats, nDuplicate int) {
+	m.stats.Duplicated(int64(nDuplicate))
+	ss.stats.Duplicated(int64(nDuplicate))
+	ss.spaceStats.stats.Duplicated(int64(nDuplicate))
+}
+
+func (m *defaultManager) onUnverified(ss *sourceStats, nUnverified int) {
+	m.stats.Unverified(int64(nUnverified))
+	ss.stats.Unverified(int64(nUnverified))
+	ss.spaceStats.stats.Unverified(int64(nUnverified))
+}
+
+func (m *defaultManager) onStale(ss *sourceStats, nStale int) {
+	m.stats.Stale(int64(nStale))
+	ss.stats.Stale(int64(nStale))
+	ss.spaceStats.stats.Stale(int64(nStale))
+}
+
+// closeReader releases the reader if it is an io.Closer, such as the convertor of the reader.
+func closeReader(r reader.BatchRecordReader) {
+	if c, ok := r.(io.Closer); ok {
+		_ = c.Close()
+	}
 }
 
 func (m *defaultManager) logError(err error, msg string, fields ...logger.Field) {
+	m.recordedErrorsMu.Lock()
+	if len(m.recordedErrors) < DefaultMaxRecordedErrors {
+		m.recordedErrors = append(m.recordedErrors, err)
+	}
+	m.recordedErrorsMu.Unlock()
+
 	e := errors.AsOrNewImportError(err)
 	fields = append(fields, logger.MapToFields(e.Fields())...)
 	m.logger.SkipCaller(1).WithError(e.Cause()).Error(msg, fields...)
@@ -5,6 +5,7 @@
 package manager
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -37,6 +38,20 @@ func (m *MockManager) EXPECT() *MockManagerMockRecorder {
 	return m.recorder
 }
 
+// Errors mocks base method.
+func (m *MockManager) Errors() []error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Errors")
+	ret0, _ := ret[0].([]error)
+	return ret0
+}
+
+// Errors indicates an expected call of Errors.
+func (mr *MockManagerMockRecorder) Errors() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Errors", reflect.TypeOf((*MockManager)(nil).Errors))
+}
+
 // Import mocks base method.
 func (m *MockManager) Import(s source.Source, brr reader.BatchRecordReader, importers ...importer.Importer) error {
 	m.ctrl.T.Helper()
@@ -56,18 +71,65 @@ func (mr *MockManagerMockRecorder) Import(s, brr interface{}, importers ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockManager)(nil).Import), varargs...)
 }
 
+// ImportSpace mocks base method.
+func (m *MockManager) ImportSpace(space string, s source.Source, brr reader.BatchRecordReader, importers ...importer.Importer) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{space, s, brr}
+	for _, a := range importers {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ImportSpace", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ImportSpace indicates an expected call of ImportSpace.
+func (mr *MockManagerMockRecorder) ImportSpace(space, s, brr interface{}, importers ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{space, s, brr}, importers...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSpace", reflect.TypeOf((*MockManager)(nil).ImportSpace), varargs...)
+}
+
+// SourcesStats mocks base method.
+func (m *MockManager) SourcesStats() []*SourceStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SourcesStats")
+	ret0, _ := ret[0].([]*SourceStats)
+	return ret0
+}
+
+// SourcesStats indicates an expected call of SourcesStats.
+func (mr *MockManagerMockRecorder) SourcesStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourcesStats", reflect.TypeOf((*MockManager)(nil).SourcesStats))
+}
+
+// SpacesStats mocks base method.
+func (m *MockManager) SpacesStats() []*SpaceStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpacesStats")
+	ret0, _ := ret[0].([]*SpaceStats)
+	return ret0
+}
+
+// SpacesStats indicates an expected call of SpacesStats.
+func (mr *MockManagerMockRecorder) SpacesStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpacesStats", reflect.TypeOf((*MockManager)(nil).SpacesStats))
+}
+
 // Start mocks base method.
-func (m *MockManager) Start() error {
+func (m *MockManager) Start(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Start")
+	ret := m.ctrl.Call(m, "Start", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Start indicates an expected call of Start.
-func (mr *MockManagerMockRecorder) Start() *gomock.Call {
+func (mr *MockManagerMockRecorder) Start(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockManager)(nil).Start))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockManager)(nil).Start), ctx)
 }
 
 // Stats mocks base method.
@@ -1,13 +1,16 @@
 package manager
 
 import (
+	"context"
 	stderrors "errors"
+	"fmt"
 	"io"
 	"os"
 	"sync/atomic"
 	"time"
 
 	"github.com/lucky-xin/nebula-importer/pkg/client"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
 	"github.com/lucky-xin/nebula-importer/pkg/importer"
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 	"github.com/lucky-xin/nebula-importer/pkg/reader"
@@ -140,7 +143,7 @@ var _ = Describe("Manager", func() {
 			fnNewBatchRecordReader := func(count int64) reader.BatchRecordReader {
 				mockBatchRecordReader = reader.NewMockBatchRecordReader(ctrl)
 				var currBatchRecordReaderCount int64
-				fnReadBatch := func() (int, spec.Records, error) {
+				fnReadBatch := func(context.Context) (int, spec.Records, error) {
 					if curr := atomic.AddInt64(&currBatchRecordReaderCount, 1); curr > count {
 						return 0, nil, io.EOF
 					}
@@ -150,7 +153,7 @@ var _ = Describe("Manager", func() {
 						[]string{"890"},
 					}, nil
 				}
-				mockBatchRecordReader.EXPECT().ReadBatch().Times(int(count) + 1).DoAndReturn(fnReadBatch)
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Times(int(count) + 1).DoAndReturn(fnReadBatch)
 				return mockBatchRecordReader
 			}
 
@@ -166,7 +169,7 @@ var _ = Describe("Manager", func() {
 			totalBytes := 12345 * int64(loopCountPreFile) * 2
 			totalRecords := totalBatches * 3
 
-			fnImport := func(records ...spec.Record) (*importer.ImportResp, error) {
+			fnImport := func(_ context.Context, records ...spec.Record) (*importer.ImportResp, error) {
 				curr := atomic.AddInt64(&currExecuteTimes, 1)
 				if curr%100 == 0 && curr/100 <= executeFailedTimes {
 					return nil, stderrors.New("import failed")
@@ -180,17 +183,17 @@ var _ = Describe("Manager", func() {
 
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(nil),
 
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("after statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 			)
 
-			mockImporter.EXPECT().Import(gomock.Any()).AnyTimes().DoAndReturn(fnImport)
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(fnImport)
 			mockImporter.EXPECT().Add(1).Times(loopCountPreFile*4 + int(totalBatches)*2)
 			mockImporter.EXPECT().Done().Times(loopCountPreFile*4 + int(totalBatches)*2)
 			mockImporter.EXPECT().Wait().Times(loopCountPreFile * 4)
@@ -212,7 +215,7 @@ var _ = Describe("Manager", func() {
 				Expect(err).NotTo(HaveOccurred())
 			}
 
-			err = m.Start()
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -258,7 +261,7 @@ var _ = Describe("Manager", func() {
 		It("get client failed", func() {
 			mockClientPool.EXPECT().GetClient(gomock.Any()).Return(nil, stderrors.New("test error"))
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("test error"))
 		})
@@ -266,24 +269,40 @@ var _ = Describe("Manager", func() {
 		It("exec before failed", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(nil, stderrors.New("test error")),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(nil, stderrors.New("test error")),
 			)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("test error"))
 		})
 
+		It("canceled while waiting in before hook", func() {
+			gomock.InOrder(
+				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+			)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+			start := time.Now()
+			err := m.Start(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(time.Since(start)).To(BeNumerically("<", time.Second))
+		})
+
 		It("client pool open failed", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(stderrors.New("test error")),
 			)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(ContainSubstring("test error"))
 		})
@@ -291,16 +310,16 @@ var _ = Describe("Manager", func() {
 		It("exec after failed", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(nil),
 
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("after statement").Times(1).Return(nil, stderrors.New("test error")),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(nil, stderrors.New("test error")),
 			)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -311,40 +330,44 @@ var _ = Describe("Manager", func() {
 		It("stop successfully", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(nil),
 
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("after statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 			)
 
-			err := m.Start()
+			closer := &testCloser{}
+			WithClosers(closer)(m.(*defaultManager))
+
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			time.Sleep(100 * time.Millisecond)
 
 			err = m.Stop()
 			Expect(err).NotTo(HaveOccurred())
+			Expect(closer.closed).To(BeTrue())
 		})
 
 		It("stop failed", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(nil),
 
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("after statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(false),
 				mockResponse.EXPECT().GetError().Times(1).Return(stderrors.New("exec failed")),
 			)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			time.Sleep(100 * time.Millisecond)
@@ -358,13 +381,13 @@ var _ = Describe("Manager", func() {
 		It("stop without read finished", func() {
 			gomock.InOrder(
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("before statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 
 				mockClientPool.EXPECT().Open().Return(nil),
 
 				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
-				mockClient.EXPECT().Execute("after statement").Times(1).Return(mockResponse, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(mockResponse, nil),
 				mockResponse.EXPECT().IsSucceed().Return(true),
 			)
 
@@ -373,13 +396,13 @@ var _ = Describe("Manager", func() {
 			mockSource.EXPECT().Size().Return(int64(1024*1024*1024*1024), nil)
 			mockSource.EXPECT().Close().Return(nil)
 
-			mockBatchRecordReader.EXPECT().ReadBatch().AnyTimes().Return(11, spec.Records{
+			mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).AnyTimes().Return(11, spec.Records{
 				[]string{"0123"},
 				[]string{"4567"},
 				[]string{"890"},
 			}, nil)
 
-			mockImporter.EXPECT().Import(gomock.Any()).AnyTimes().Return(&importer.ImportResp{}, nil)
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).AnyTimes().Return(&importer.ImportResp{}, nil)
 			mockImporter.EXPECT().Add(1).MinTimes(1)
 			mockImporter.EXPECT().Done().MinTimes(1)
 			mockImporter.EXPECT().Wait().Times(1)
@@ -391,7 +414,7 @@ var _ = Describe("Manager", func() {
 			)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = m.Start()
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			time.Sleep(100 * time.Millisecond)
@@ -400,13 +423,374 @@ var _ = Describe("Manager", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("canceled by context", func() {
+			gomock.InOrder(
+				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Times(1).Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+
+				mockClientPool.EXPECT().Open().Return(nil),
+
+				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "after statement").Times(1).Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+			)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024*1024*1024*1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).AnyTimes().DoAndReturn(
+				func(ctx context.Context) (int, spec.Records, error) {
+					if err := ctx.Err(); err != nil {
+						return 0, nil, err
+					}
+					return 11, spec.Records{
+						[]string{"0123"},
+						[]string{"4567"},
+						[]string{"890"},
+					}, nil
+				},
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).AnyTimes().Return(&importer.ImportResp{}, nil)
+			mockImporter.EXPECT().Add(1).MinTimes(1)
+			mockImporter.EXPECT().Done().MinTimes(1)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			err := m.Import(
+				mockSource,
+				mockBatchRecordReader,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			ctx, cancel := context.WithCancel(context.Background())
+			err = m.Start(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			time.Sleep(100 * time.Millisecond)
+			cancel()
+
+			err = m.Wait()
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
+		})
+
+		It("split failed batch", func() {
+			m.(*defaultManager).hooks.Before = nil
+			m.(*defaultManager).hooks.After = nil
+			m.(*defaultManager).splitFailedBatch = true
+
+			mockClientPool.EXPECT().Open().Return(nil)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+					[]string{"1"},
+					[]string{"bad"},
+					[]string{"3"},
+					[]string{"4"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			var nImport int64
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+				func(_ context.Context, records ...spec.Record) (*importer.ImportResp, error) {
+					atomic.AddInt64(&nImport, 1)
+					for _, record := range records {
+						if record[0] == "bad" {
+							return nil, fmt.Errorf("%w: bad record", client.ErrPermanent)
+						}
+					}
+					return &importer.ImportResp{RecordNum: len(records)}, nil
+				},
+			)
+			mockImporter.EXPECT().Add(1).Times(2)
+			mockImporter.EXPECT().Done().Times(2)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			err := m.Import(
+				mockSource,
+				mockBatchRecordReader,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+
+			// [0 1 bad 3 4] => [0 1] [bad 3 4] => [bad] [3 4]
+			Expect(atomic.LoadInt64(&nImport)).To(Equal(int64(5)))
+			s := m.Stats()
+			Expect(s.FailedRecords).To(Equal(int64(1)))
+			Expect(s.TotalRecords).To(Equal(int64(5)))
+			Expect(s.FailedRequest).To(Equal(int64(1)))
+			Expect(s.TotalRequest).To(Equal(int64(3)))
+		})
+
+		It("split failed batch not permanent error", func() {
+			m.(*defaultManager).hooks.Before = nil
+			m.(*defaultManager).hooks.After = nil
+			m.(*defaultManager).splitFailedBatch = true
+
+			mockClientPool.EXPECT().Open().Return(nil)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+					[]string{"1"},
+					[]string{"2"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).Times(1).Return(nil, stderrors.New("import failed"))
+			mockImporter.EXPECT().Add(1).Times(2)
+			mockImporter.EXPECT().Done().Times(2)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			err := m.Import(
+				mockSource,
+				mockBatchRecordReader,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+
+			s := m.Stats()
+			Expect(s.FailedRecords).To(Equal(int64(3)))
+			Expect(s.FailedRequest).To(Equal(int64(1)))
+		})
+
+		It("record error", func() {
+			m.(*defaultManager).hooks.Before = nil
+			m.(*defaultManager).hooks.After = nil
+
+			mockClientPool.EXPECT().Open().Return(nil)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+					[]string{"1"},
+					[]string{"2"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).Times(1).
+				Return(nil, fmt.Errorf("%w: %w", errors.ErrInvalidRecord, errors.ErrInvalidValue))
+			mockImporter.EXPECT().Add(1).Times(2)
+			mockImporter.EXPECT().Done().Times(2)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			err := m.Import(
+				mockSource,
+				mockBatchRecordReader,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+
+			s := m.Stats()
+			Expect(s.FailedRecords).To(Equal(int64(3)))
+			Expect(s.FailedRequest).To(Equal(int64(0)))
+		})
+
+		It("convert failed", func() {
+			m.(*defaultManager).hooks.Before = nil
+			m.(*defaultManager).hooks.After = nil
+
+			mockClientPool.EXPECT().Open().Return(nil)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).Times(1).Return(&importer.ImportResp{RecordNum: 1}, nil)
+			mockImporter.EXPECT().Add(1).Times(2)
+			mockImporter.EXPECT().Done().Times(2)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			brr := &convertFailedReader{
+				MockBatchRecordReader: mockBatchRecordReader,
+				failed:                spec.Records{[]string{"1"}, []string{"2"}},
+			}
+			err := m.Import(
+				mockSource,
+				brr,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+
+			s := m.Stats()
+			Expect(s.FailedRecords).To(Equal(int64(2)))
+			Expect(s.TotalRecords).To(Equal(int64(3)))
+			Expect(m.Errors()).To(HaveLen(1))
+			Expect(stderrors.Is(m.Errors()[0], errors.ErrInvalidRecord)).To(BeTrue())
+			Expect(brr.closed).To(BeTrue())
+		})
+
+		It("duplicates", func() {
+			m.(*defaultManager).hooks.Before = nil
+			m.(*defaultManager).hooks.After = nil
+
+			mockClientPool.EXPECT().Open().Return(nil)
+
+			mockSource.EXPECT().Name().Times(2).Return("source name")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+					[]string{"0"},
+					[]string{"1"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).Times(1).
+				Return(&importer.ImportResp{RecordNum: 2, Duplicates: 1, Unverified: 1, Stale: 1}, nil)
+			mockImporter.EXPECT().Add(1).Times(2)
+			mockImporter.EXPECT().Done().Times(2)
+			mockImporter.EXPECT().Wait().Times(1)
+
+			err := m.Import(
+				mockSource,
+				mockBatchRecordReader,
+				mockImporter,
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+
+			s := m.Stats()
+			Expect(s.TotalRecords).To(Equal(int64(3)))
+			Expect(s.TotalProcessed).To(Equal(int64(2)))
+			Expect(s.Duplicates).To(Equal(int64(1)))
+			sourcesStats := m.SourcesStats()
+			Expect(sourcesStats).To(HaveLen(1))
+			Expect(sourcesStats[0].Stats.Duplicates).To(Equal(int64(1)))
+			Expect(s.Unverified).To(Equal(int64(1)))
+			Expect(sourcesStats[0].Stats.Unverified).To(Equal(int64(1)))
+			Expect(s.Stale).To(Equal(int64(1)))
+			Expect(sourcesStats[0].Stats.Stale).To(Equal(int64(1)))
+		})
+
+		DescribeTable("abort with too many failures",
+			func(opts ...Option) {
+				for _, opt := range opts {
+					opt(m.(*defaultManager))
+				}
+				m.(*defaultManager).hooks.Before = nil
+				m.(*defaultManager).hooks.After = nil
+
+				mockClientPool.EXPECT().Open().Return(nil)
+
+				mockSource.EXPECT().Name().Times(2).Return("source name")
+				mockSource.EXPECT().Open().Return(nil)
+				mockSource.EXPECT().Size().Return(int64(1024*1024*1024*1024), nil)
+				mockSource.EXPECT().Close().Return(nil)
+
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).AnyTimes().Return(11, spec.Records{
+					[]string{"0123"},
+					[]string{"4567"},
+					[]string{"890"},
+				}, nil)
+
+				mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, stderrors.New("import failed"))
+				mockImporter.EXPECT().Add(1).MinTimes(1)
+				mockImporter.EXPECT().Done().MinTimes(1)
+				mockImporter.EXPECT().Wait().Times(1)
+
+				err := m.Import(
+					mockSource,
+					mockBatchRecordReader,
+					mockImporter,
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = m.Start(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+
+				err = m.Wait()
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, errors.ErrTooManyFailures)).To(BeTrue())
+				Expect(m.Stats().FailedRecords).To(BeNumerically(">", 0))
+
+				err = m.Stop()
+				Expect(err).NotTo(HaveOccurred())
+			},
+			Entry("maxFailedRecords", WithMaxFailedRecords(10)),
+			Entry("maxFailedRatio", WithMaxFailedRatio(0.5), WithMaxFailedRatioMinRecords(1)),
+		)
+
+		It("maxFailedRatio below the min records", func() {
+			WithMaxFailedRatio(0.5)(m.(*defaultManager))
+			Expect(m.(*defaultManager).maxFailedRatioMin).To(Equal(int64(DefaultMaxFailedRatioMinRecords)))
+
+			m.(*defaultManager).stats.Failed(0, DefaultMaxFailedRatioMinRecords-1)
+			m.(*defaultManager).checkFailed()
+			Expect(m.(*defaultManager).abortErr.Load()).To(BeNil())
+		})
+
 		It("no hooks", func() {
 			m.(*defaultManager).hooks.Before = nil
 			m.(*defaultManager).hooks.After = nil
 
 			mockClientPool.EXPECT().Open().Return(nil)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -425,11 +809,123 @@ var _ = Describe("Manager", func() {
 
 			mockClientPool.EXPECT().Open().Return(nil)
 
-			err := m.Start()
+			err := m.Start(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Wait()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("spaces", func() {
+			mockSpaceClientPool := client.NewMockPool(ctrl)
+			mockSpaceClient := client.NewMockClient(ctrl)
+			mockSource2 := source.NewMockSource(ctrl)
+			mockBatchRecordReader2 := reader.NewMockBatchRecordReader(ctrl)
+			WithGraphName("graphName")(m.(*defaultManager))
+			WithSpacePool("space2", mockSpaceClientPool)(m.(*defaultManager))
+			m.(*defaultManager).hooks.Before = []*Hook{
+				{Statements: []string{"before statement"}},
+				{Statements: []string{"space2 before statement"}, Space: "space2"},
+			}
+			m.(*defaultManager).hooks.After = []*Hook{
+				{Statements: []string{"graphName after statement"}, Space: "graphName"},
+			}
+
+			gomock.InOrder(
+				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "before statement").Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+				mockSpaceClientPool.EXPECT().GetClient(gomock.Any()).Return(mockSpaceClient, nil),
+				mockSpaceClient.EXPECT().Execute(gomock.Any(), "space2 before statement").Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+
+				mockClientPool.EXPECT().Open().Return(nil),
+				mockSpaceClientPool.EXPECT().Open().Return(nil),
+
+				mockClientPool.EXPECT().GetClient(gomock.Any()).Return(mockClient, nil),
+				mockClient.EXPECT().Execute(gomock.Any(), "graphName after statement").Return(mockResponse, nil),
+				mockResponse.EXPECT().IsSucceed().Return(true),
+				mockSpaceClientPool.EXPECT().Close().Return(nil),
+			)
+
+			mockSource.EXPECT().Name().Times(2).Return("source1")
+			mockSource.EXPECT().Open().Return(nil)
+			mockSource.EXPECT().Size().Return(int64(1024), nil)
+			mockSource.EXPECT().Close().Return(nil)
+			mockSource2.EXPECT().Name().Times(2).Return("source2")
+			mockSource2.EXPECT().Open().Return(nil)
+			mockSource2.EXPECT().Size().Return(int64(2048), nil)
+			mockSource2.EXPECT().Close().Return(nil)
+
+			gomock.InOrder(
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(1024, spec.Records{
+					[]string{"0"},
+				}, nil),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+			gomock.InOrder(
+				mockBatchRecordReader2.EXPECT().ReadBatch(gomock.Any()).Return(2048, spec.Records{
+					[]string{"1"},
+					[]string{"2"},
+				}, nil),
+				mockBatchRecordReader2.EXPECT().ReadBatch(gomock.Any()).Return(0, nil, io.EOF),
+			)
+
+			mockImporter.EXPECT().Import(gomock.Any(), gomock.Any()).Times(2).
+				DoAndReturn(func(_ context.Context, records ...spec.Record) (*importer.ImportResp, error) {
+					return &importer.ImportResp{RecordNum: len(records)}, nil
+				})
+			mockImporter.EXPECT().Add(1).Times(4)
+			mockImporter.EXPECT().Done().Times(4)
+			mockImporter.EXPECT().Wait().Times(2)
+
+			err := m.Import(mockSource, mockBatchRecordReader, mockImporter)
+			Expect(err).NotTo(HaveOccurred())
+			err = m.ImportSpace("space2", mockSource2, mockBatchRecordReader2, mockImporter)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
 			Expect(err).NotTo(HaveOccurred())
+
+			Expect(m.Stats().TotalRecords).To(Equal(int64(3)))
+			sourcesStats := m.SourcesStats()
+			Expect(sourcesStats).To(HaveLen(2))
+			Expect(sourcesStats[0].Space).To(Equal("graphName"))
+			Expect(sourcesStats[1].Space).To(Equal("space2"))
+			spacesStats := m.SpacesStats()
+			Expect(spacesStats).To(HaveLen(2))
+			Expect(spacesStats[0].Space).To(Equal("graphName"))
+			Expect(spacesStats[0].Stats.Total).To(Equal(int64(1024)))
+			Expect(spacesStats[0].Stats.TotalRecords).To(Equal(int64(1)))
+			Expect(spacesStats[1].Space).To(Equal("space2"))
+			Expect(spacesStats[1].Stats.Total).To(Equal(int64(2048)))
+			Expect(spacesStats[1].Stats.TotalRecords).To(Equal(int64(2)))
+		})
+
+		It("space pool open failed", func() {
+			mockSpaceClientPool := client.NewMockPool(ctrl)
+			WithSpacePool("space2", mockSpaceClientPool)(m.(*defaultManager))
+			m.(*defaultManager).hooks.Before = nil
+
+			mockClientPool.EXPECT().Open().Return(nil)
+			mockSpaceClientPool.EXPECT().Open().Return(stderrors.New("test error"))
+
+			err := m.Start(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrConnectFailed)).To(BeTrue())
+		})
+
+		It("hook space without pool", func() {
+			m.(*defaultManager).hooks.Before = []*Hook{
+				{Statements: []string{"before statement"}, Space: "unknown"},
+			}
+
+			err := m.Start(context.Background())
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
 		})
 
 		It("disable stats interval", func() {
@@ -439,7 +935,7 @@ var _ = Describe("Manager", func() {
 
 			mockClientPool.EXPECT().Open().Return(nil)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -453,7 +949,7 @@ var _ = Describe("Manager", func() {
 
 			mockClientPool.EXPECT().Open().Return(nil)
 
-			err := m.Start()
+			err := m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			time.Sleep(100 * time.Millisecond)
@@ -490,7 +986,7 @@ var _ = Describe("Manager", func() {
 			)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = m.Start()
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -508,12 +1004,12 @@ var _ = Describe("Manager", func() {
 			mockSource.EXPECT().Close().Times(2).Return(nil)
 
 			gomock.InOrder(
-				mockBatchRecordReader.EXPECT().ReadBatch().Times(2).Return(11, spec.Records{
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Times(2).Return(11, spec.Records{
 					[]string{"0123"},
 					[]string{"4567"},
 					[]string{"890"},
 				}, nil),
-				mockBatchRecordReader.EXPECT().ReadBatch().Times(2).Return(0, spec.Records(nil), io.EOF),
+				mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Times(2).Return(0, spec.Records(nil), io.EOF),
 			)
 
 			mockClientPool.EXPECT().Open().Return(nil)
@@ -535,7 +1031,7 @@ var _ = Describe("Manager", func() {
 			)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = m.Start()
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -578,7 +1074,7 @@ var _ = Describe("Manager", func() {
 			mockSource.EXPECT().Size().Times(2).Return(int64(1024), nil)
 			mockSource.EXPECT().Close().Times(2).Return(nil)
 
-			mockBatchRecordReader.EXPECT().ReadBatch().Times(2).Return(0, spec.Records(nil), stderrors.New("test error"))
+			mockBatchRecordReader.EXPECT().ReadBatch(gomock.Any()).Times(2).Return(0, spec.Records(nil), stderrors.New("test error"))
 
 			mockImporter.EXPECT().Add(1).Times(2)
 			mockImporter.EXPECT().Done().Times(2)
@@ -597,7 +1093,7 @@ var _ = Describe("Manager", func() {
 			)
 			Expect(err).NotTo(HaveOccurred())
 
-			err = m.Start()
+			err = m.Start(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			err = m.Wait()
@@ -605,3 +1101,30 @@ var _ = Describe("Manager", func() {
 		})
 	})
 })
+
+type testCloser struct {
+	closed bool
+}
+
+func (c *testCloser) Close() error {
+	c.closed = true
+	return nil
+}
+
+// convertFailedReader reports the records failed to convert only in the first batch.
+type convertFailedReader struct {
+	*reader.MockBatchRecordReader
+	failed spec.Records
+	closed bool
+}
+
+func (r *convertFailedReader) ConvertFailed() spec.Records {
+	failed := r.failed
+	r.failed = nil
+	return failed
+}
+
+func (r *convertFailedReader) Close() error {
+	r.closed = true
+	return nil
+}
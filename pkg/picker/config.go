@@ -9,22 +9,32 @@ import (
 // Config is the configuration to build Picker
 // The priority is as follows:
 //
-//	ConcatItems > Indices
+//	ValueExpr > ConcatItems > Indices
 //	Nullable
 //	DefaultValue
 //	NullValue, if set to null, subsequent conversions will be skipped.
+//	Format, Timezone
+//	NullPolicy, only for DATE, TIME, DATETIME and TIMESTAMP
+//	Function, only for the client functions, such as xxhash64, murmur3, uuidv5 and prefix
 //	Type
-//	Function
+//	Function, for the others which are called in graphd
 //	CheckOnPost
 type Config struct {
-	ConcatItems  []any              // Concat index column, constant, or mixed. int for index column, string for constant.
-	Indices      []int              // Set index columns, the first non-null.
-	Nullable     func(string) bool  // Determine whether it is null. Optional.
-	NullValue    string             // Set null value when it is null. Optional.
-	DefaultValue *string            // Set default value when it is null. Optional.
-	Type         string             // Set the type of value.
-	Function     *string            // Set the conversion function of value.
-	CheckOnPost  func(*Value) error // Set the value check function on post.
+	ValueExpr       *string            // Evaluate the expression over the record to get the value. Optional.
+	Columns         map[string]int     // Set the named columns which are available in ValueExpr. Optional.
+	Lookups         Lookups            // Set the lookups which are available in ValueExpr. Optional.
+	ConcatItems     []any              // Concat index column, constant, or mixed. int for index column, string for constant.
+	Indices         []int              // Set index columns, the first non-null.
+	Nullable        func(string) bool  // Determine whether it is null. Optional.
+	NullValue       string             // Set null value when it is null. Optional.
+	DefaultValue    *string            // Set default value when it is null. Optional.
+	Format          string             // Set the format to parse the DATE, TIME, DATETIME and TIMESTAMP value. Optional.
+	Timezone        string             // Set the timezone to parse the DATE, TIME, DATETIME and TIMESTAMP value. Optional.
+	NullPolicy      string             // Set the policy for the null values of DATE, TIME, DATETIME and TIMESTAMP. Optional.
+	Type            string             // Set the type of value.
+	Function        *string            // Set the conversion function of value.
+	FunctionOptions FunctionOptions    // Set the options of the client function. Optional.
+	CheckOnPost     func(*Value) error // Set the value check function on post.
 }
 
 //revive:disable-next-line:cyclomatic
@@ -37,6 +47,12 @@ func (c *Config) Build() (Picker, error) {
 	var retPicker Picker
 	var nullHandled bool
 	switch {
+	case c.ValueExpr != nil && *c.ValueExpr != "":
+		exprPicker, err := NewExprPicker(*c.ValueExpr, c.Columns, c.Lookups)
+		if err != nil {
+			return nil, err
+		}
+		retPicker = exprPicker
 	case len(c.ConcatItems) > 0:
 		concatItems := ConcatItems{}
 		if err := concatItems.Add(c.ConcatItems...); err != nil {
@@ -89,13 +105,43 @@ func (c *Config) Build() (Picker, error) {
 			})
 		}
 	}
+	if c.Format != "" || c.Timezone != "" {
+		timeConverter, err := NewTimeFormatConverter(c.Type, c.Format, c.Timezone)
+		if err != nil {
+			return nil, err
+		}
+		converters = append(converters, timeConverter)
+	}
+
+	isTemporalType := IsTemporalType(c.Type)
+	if isTemporalType || c.NullPolicy != "" {
+		nullValue := c.NullValue
+		if nullValue == "" {
+			nullValue = "NULL"
+		}
+		nullPolicyConverter, err := NewNullPolicyConverter(c.Type, c.NullPolicy, nullValue, c.DefaultValue)
+		if err != nil {
+			return nil, err
+		}
+		converters = append(converters, nullPolicyConverter)
+	}
+
+	isClientFunction := c.Function != nil && IsClientFunction(*c.Function)
+	if isClientFunction {
+		functionConverter, err := NewClientFunctionConverter(*c.Function, c.Type, c.FunctionOptions)
+		if err != nil {
+			return nil, err
+		}
+		converters = append(converters, functionConverter)
+	}
+
 	typeConverter, err := NewTypeConverter(c.Type)
 	if err != nil {
 		return nil, err
 	}
 	converters = append(converters, typeConverter)
 
-	if c.Function != nil && *c.Function != "" {
+	if c.Function != nil && *c.Function != "" && !isClientFunction {
 		var functionConverter Converter = FunctionConverter{
 			Name: *c.Function,
 		}
@@ -118,7 +164,7 @@ func (c *Config) Build() (Picker, error) {
 	}
 
 	var converter Converter = Converters(converters)
-	if c.Nullable != nil {
+	if c.Nullable != nil || isTemporalType {
 		converter = NullableConverters(converters)
 	}
 
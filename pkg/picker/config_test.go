@@ -190,7 +190,7 @@ var _ = Describe("Config", func() {
 					},
 					{
 						record:    []string{""},
-						wantValue: &Value{Val: "TIMESTAMP(\"\")", IsNull: false},
+						wantValue: &Value{Val: "NULL", IsNull: true, isSetNull: true},
 					},
 					{
 						record:    []string{"0"},
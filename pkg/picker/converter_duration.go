@@ -0,0 +1,165 @@
+package picker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+// The keys of the map accepted by the duration function of graphd, in order.
+var durationKeys = []string{"years", "months", "days", "hours", "minutes", "seconds", "microseconds"}
+
+// TypeDurationConverter converts the ISO-8601 durations, such as `P1Y2M3DT4H5M6.5S`,
+// and the Go durations, such as `1h30m`, to the duration function of graphd.
+type TypeDurationConverter struct {
+	Name string
+}
+
+func (tc TypeDurationConverter) Convert(v *Value) (*Value, error) {
+	fields, err := parseDuration(strings.TrimSpace(v.Val))
+	if err != nil {
+		v.Release()
+		return nil, err
+	}
+	v.Val = getFuncValue(tc.Name, formatDuration(fields))
+	return v, nil
+}
+
+// parseDuration returns the values of durationKeys.
+func parseDuration(s string) ([]int64, error) {
+	negative := false
+	trimmed := s
+	if trimmed != "" && (trimmed[0] == '-' || trimmed[0] == '+') {
+		negative = trimmed[0] == '-'
+		trimmed = trimmed[1:]
+	}
+
+	var (
+		fields []int64
+		err    error
+	)
+	if trimmed != "" && (trimmed[0] == 'P' || trimmed[0] == 'p') {
+		fields, err = parseISO8601Duration(trimmed[1:])
+	} else {
+		fields, err = parseGoDuration(trimmed)
+	}
+	if err != nil {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "invalid duration %q", s)
+	}
+
+	if negative {
+		for i := range fields {
+			fields[i] = -fields[i]
+		}
+	}
+	return fields, nil
+}
+
+func parseISO8601Duration(s string) ([]int64, error) {
+	fields := make([]int64, len(durationKeys))
+	if s == "" {
+		return nil, errors.ErrInvalidValue
+	}
+
+	inTime := false
+	hasValue := false
+	for s != "" {
+		if s[0] == 'T' || s[0] == 't' {
+			if inTime {
+				return nil, errors.ErrInvalidValue
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+
+		i := 0
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '.' || s[i] == ',') {
+			i++
+		}
+		if i == 0 || i == len(s) {
+			return nil, errors.ErrInvalidValue
+		}
+		number, designator := strings.ReplaceAll(s[:i], ",", "."), s[i]
+		s = s[i+1:]
+		hasValue = true
+
+		if designator == 'S' || designator == 's' {
+			if !inTime {
+				return nil, errors.ErrInvalidValue
+			}
+			seconds, err := strconv.ParseFloat(number, 64)
+			if err != nil {
+				return nil, err
+			}
+			micro := int64(seconds*1e6 + 0.5)
+			fields[5] += micro / 1e6
+			fields[6] += micro % 1e6
+			continue
+		}
+
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case !inTime && (designator == 'Y' || designator == 'y'):
+			fields[0] += n
+		case !inTime && (designator == 'M' || designator == 'm'):
+			fields[1] += n
+		case !inTime && (designator == 'W' || designator == 'w'):
+			fields[2] += n * 7
+		case !inTime && (designator == 'D' || designator == 'd'):
+			fields[2] += n
+		case inTime && (designator == 'H' || designator == 'h'):
+			fields[3] += n
+		case inTime && (designator == 'M' || designator == 'm'):
+			fields[4] += n
+		default:
+			return nil, errors.ErrInvalidValue
+		}
+	}
+	if !hasValue {
+		return nil, errors.ErrInvalidValue
+	}
+	return fields, nil
+}
+
+func parseGoDuration(s string) ([]int64, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]int64, len(durationKeys))
+	fields[3] = int64(d / time.Hour)
+	d %= time.Hour
+	fields[4] = int64(d / time.Minute)
+	d %= time.Minute
+	fields[5] = int64(d / time.Second)
+	d %= time.Second
+	fields[6] = int64(d / time.Microsecond)
+	return fields, nil
+}
+
+func formatDuration(fields []int64) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, n := range fields {
+		if n == 0 {
+			continue
+		}
+		if sb.Len() > 1 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(durationKeys[i])
+		sb.WriteString(": ")
+		sb.WriteString(strconv.FormatInt(n, 10))
+	}
+	if sb.Len() == 1 {
+		sb.WriteString("seconds: 0")
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
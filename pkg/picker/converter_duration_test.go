@@ -0,0 +1,57 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TypeDurationConverter", func() {
+	DescribeTable("Convert",
+		func(val, expectValue string) {
+			converter, err := NewTypeConverter("DURATION")
+			Expect(err).NotTo(HaveOccurred())
+			v, err := converter.Convert(&Value{Val: val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(&Value{Val: expectValue}))
+		},
+		Entry("iso8601", "P1Y2M3DT4H5M6S", "duration({years: 1, months: 2, days: 3, hours: 4, minutes: 5, seconds: 6})"),
+		Entry("iso8601 weeks", "P2W", "duration({days: 14})"),
+		Entry("iso8601 time", "PT36H", "duration({hours: 36})"),
+		Entry("iso8601 fraction", "PT1.5S", "duration({seconds: 1, microseconds: 500000})"),
+		Entry("iso8601 comma fraction", "PT0,25S", "duration({microseconds: 250000})"),
+		Entry("iso8601 negative", "-P1DT1M", "duration({days: -1, minutes: -1})"),
+		Entry("iso8601 zero", "PT0S", "duration({seconds: 0})"),
+		Entry("go", "1h30m", "duration({hours: 1, minutes: 30})"),
+		Entry("go fraction", "1.5s", "duration({seconds: 1, microseconds: 500000})"),
+		Entry("go micro", "2m3s4ms5us", "duration({minutes: 2, seconds: 3, microseconds: 4005})"),
+		Entry("go negative", "-90m", "duration({hours: -1, minutes: -30})"),
+		Entry("go zero", "0", "duration({seconds: 0})"),
+		Entry("spaces", " PT1M ", "duration({minutes: 1})"),
+	)
+
+	DescribeTable("Convert failed",
+		func(val string) {
+			converter, err := NewTypeConverter("DURATION")
+			Expect(err).NotTo(HaveOccurred())
+			v, err := converter.Convert(&Value{Val: val})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+			Expect(v).To(BeNil())
+		},
+		Entry("empty", ""),
+		Entry("iso8601 empty", "P"),
+		Entry("iso8601 empty time", "PT"),
+		Entry("iso8601 no designator", "P1"),
+		Entry("iso8601 hours without time", "P1H"),
+		Entry("iso8601 seconds without time", "P1S"),
+		Entry("iso8601 days in time", "PT1D"),
+		Entry("iso8601 fraction days", "P1.5D"),
+		Entry("iso8601 double time", "PT1HT1M"),
+		Entry("go invalid", "1x"),
+		Entry("text", "abc"),
+	)
+})
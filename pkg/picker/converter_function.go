@@ -2,7 +2,7 @@ package picker
 
 import (
 	"strconv"
-	"time"
+	"strings"
 )
 
 var (
@@ -42,10 +42,7 @@ func (fc FunctionStringConverter) Convert(v *Value) (*Value, error) {
 }
 
 func (fc FunctionDateTimeConverter) Convert(v *Value) (*Value, error) {
-	if v.Val == "null" || v.Val == "NULL" || v.Val == "" {
-		v.Val = time.Now().Format(time.RFC3339Nano)
-	}
-	if string(v.Val[len(v.Val)-1]) == "Z" {
+	if strings.HasSuffix(v.Val, "Z") {
 		v.Val = v.Val[:len(v.Val)-1] + "+00:00"
 	}
 	v.Val = getFuncValue(fc.Name, strconv.Quote(v.Val))
@@ -53,18 +50,12 @@ func (fc FunctionDateTimeConverter) Convert(v *Value) (*Value, error) {
 }
 
 func (fc FunctionDateConverter) Convert(v *Value) (*Value, error) {
-	if v.Val == "null" || v.Val == "NULL" || v.Val == "" {
-		v.Val = "2000-01-01"
-	}
 	v.Val = getFuncValue(fc.Name, strconv.Quote(v.Val))
 	return v, nil
 }
 
 func (fc FunctionTimeConverter) Convert(v *Value) (*Value, error) {
-	if v.Val == "null" || v.Val == "NULL" || v.Val == "" {
-		v.Val = "00:00:00.000000"
-	}
-	if string(v.Val[len(v.Val)-1]) == "Z" {
+	if strings.HasSuffix(v.Val, "Z") {
 		v.Val = v.Val[:len(v.Val)-1] + "+00:00"
 	}
 	v.Val = getFuncValue(fc.Name, strconv.Quote(v.Val))
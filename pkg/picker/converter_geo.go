@@ -0,0 +1,136 @@
+package picker
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+const (
+	GeometryTypePoint      = "POINT"
+	GeometryTypeLineString = "LINESTRING"
+	GeometryTypePolygon    = "POLYGON"
+)
+
+type (
+	// TypeGeoConverter converts the WKT or GeoJSON values to the geography function of graphd.
+	// The GeoJSON values, which starts with `{`, are converted to WKT at first,
+	// and must be the GeometryType if it is set.
+	TypeGeoConverter struct {
+		Name         string
+		GeometryType string
+	}
+
+	geoJSON struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometry    *geoJSON        `json:"geometry"`
+	}
+)
+
+func (tc TypeGeoConverter) Convert(v *Value) (*Value, error) {
+	if s := strings.TrimSpace(v.Val); strings.HasPrefix(s, "{") {
+		wkt, err := GeoJSONToWKT(s, tc.GeometryType)
+		if err != nil {
+			v.Release()
+			return nil, err
+		}
+		v.Val = wkt
+	}
+	v.Val = getFuncValue(tc.Name, strconv.Quote(v.Val))
+	return v, nil
+}
+
+// GeoJSONToWKT converts the GeoJSON geometry or feature of Point, LineString and Polygon to WKT.
+// The geometry type is checked if geometryType is not empty.
+func GeoJSONToWKT(s, geometryType string) (string, error) {
+	var g geoJSON
+	if err := json.Unmarshal([]byte(s), &g); err != nil {
+		return "", errors.NewImportError(errors.ErrInvalidValue, "invalid geojson %q: %s", s, err)
+	}
+	if strings.EqualFold(g.Type, "Feature") {
+		if g.Geometry == nil {
+			return "", errors.NewImportError(errors.ErrInvalidValue, "no geometry in geojson feature %q", s)
+		}
+		g = *g.Geometry
+	}
+
+	t := strings.ToUpper(g.Type)
+	if geometryType != "" && t != strings.ToUpper(geometryType) {
+		return "", errors.NewImportError(errors.ErrInvalidValue, "geojson type %q mismatch %s", g.Type, geometryType)
+	}
+
+	var (
+		sb  strings.Builder
+		err error
+	)
+	sb.WriteString(t)
+	switch t {
+	case GeometryTypePoint:
+		var point []float64
+		if err = json.Unmarshal(g.Coordinates, &point); err == nil {
+			sb.WriteByte('(')
+			err = writeWKTPoint(&sb, point)
+			sb.WriteByte(')')
+		}
+	case GeometryTypeLineString:
+		var points [][]float64
+		if err = json.Unmarshal(g.Coordinates, &points); err == nil {
+			err = writeWKTPoints(&sb, points)
+		}
+	case GeometryTypePolygon:
+		var rings [][][]float64
+		if err = json.Unmarshal(g.Coordinates, &rings); err == nil {
+			if len(rings) == 0 {
+				err = errors.ErrInvalidValue
+				break
+			}
+			sb.WriteByte('(')
+			for i, ring := range rings {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				if err = writeWKTPoints(&sb, ring); err != nil {
+					break
+				}
+			}
+			sb.WriteByte(')')
+		}
+	default:
+		return "", errors.NewImportError(errors.ErrInvalidValue, "unsupported geojson type %q", g.Type)
+	}
+	if err != nil {
+		return "", errors.NewImportError(errors.ErrInvalidValue, "invalid geojson coordinates %s", string(g.Coordinates))
+	}
+	return sb.String(), nil
+}
+
+func writeWKTPoints(sb *strings.Builder, points [][]float64) error {
+	if len(points) == 0 {
+		return errors.ErrInvalidValue
+	}
+	sb.WriteByte('(')
+	for i, point := range points {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if err := writeWKTPoint(sb, point); err != nil {
+			return err
+		}
+	}
+	sb.WriteByte(')')
+	return nil
+}
+
+func writeWKTPoint(sb *strings.Builder, point []float64) error {
+	// The altitude is ignored, graphd only supports the longitude and latitude.
+	if len(point) < 2 {
+		return errors.ErrInvalidValue
+	}
+	sb.WriteString(strconv.FormatFloat(point[0], 'f', -1, 64))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatFloat(point[1], 'f', -1, 64))
+	return nil
+}
@@ -0,0 +1,58 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TypeGeoConverter", func() {
+	DescribeTable("Convert",
+		func(t, val, expectValue string) {
+			converter, err := NewTypeConverter(t)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := converter.Convert(&Value{Val: val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(&Value{Val: expectValue}))
+		},
+		Entry("wkt", "GEOGRAPHY(POINT)", "Point(1 2)", `ST_GeogFromText("Point(1 2)")`),
+		Entry("point", "GEOGRAPHY(POINT)",
+			`{"type": "Point", "coordinates": [102.5, 0.5]}`,
+			`ST_GeogFromText("POINT(102.5 0.5)")`),
+		Entry("point altitude", "GEOGRAPHY",
+			`{"type": "Point", "coordinates": [102.5, 0.5, 10]}`,
+			`ST_GeogFromText("POINT(102.5 0.5)")`),
+		Entry("linestring", "GEOGRAPHY(LINESTRING)",
+			`{"type": "LineString", "coordinates": [[0, 1], [179.99, 89.99]]}`,
+			`ST_GeogFromText("LINESTRING(0 1, 179.99 89.99)")`),
+		Entry("polygon", "GEOGRAPHY(POLYGON)",
+			`{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 10], [0, 0]], [[1, 1], [2, 1], [2, 2], [1, 1]]]}`,
+			`ST_GeogFromText("POLYGON((0 0, 10 0, 10 10, 0 0), (1 1, 2 1, 2 2, 1 1))")`),
+		Entry("feature", "GEOGRAPHY",
+			` {"type": "Feature", "properties": {"name": "a"}, "geometry": {"type": "Point", "coordinates": [1, 2]}}`,
+			`ST_GeogFromText("POINT(1 2)")`),
+	)
+
+	DescribeTable("Convert failed",
+		func(t, val string) {
+			converter, err := NewTypeConverter(t)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := converter.Convert(&Value{Val: val})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+			Expect(v).To(BeNil())
+		},
+		Entry("invalid json", "GEOGRAPHY", `{"type": "Point"`),
+		Entry("type mismatch", "GEOGRAPHY(POINT)", `{"type": "LineString", "coordinates": [[0, 1], [2, 3]]}`),
+		Entry("unsupported type", "GEOGRAPHY", `{"type": "MultiPoint", "coordinates": [[0, 1], [2, 3]]}`),
+		Entry("feature without geometry", "GEOGRAPHY", `{"type": "Feature", "properties": {}}`),
+		Entry("point invalid coordinates", "GEOGRAPHY", `{"type": "Point", "coordinates": [1]}`),
+		Entry("linestring invalid coordinates", "GEOGRAPHY", `{"type": "LineString", "coordinates": [1, 2]}`),
+		Entry("linestring empty coordinates", "GEOGRAPHY", `{"type": "LineString", "coordinates": []}`),
+		Entry("polygon empty coordinates", "GEOGRAPHY", `{"type": "Polygon", "coordinates": []}`),
+		Entry("polygon empty ring", "GEOGRAPHY", `{"type": "Polygon", "coordinates": [[]]}`),
+	)
+})
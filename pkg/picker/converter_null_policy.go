@@ -0,0 +1,95 @@
+package picker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+var _ Converter = NullPolicyConverter{}
+
+const (
+	// NullPolicyNull sets the null values to NULL, it is the default policy.
+	NullPolicyNull = "null"
+	// NullPolicyDefault sets the null values to the default value.
+	NullPolicyDefault = "default"
+	// NullPolicyNow sets the null values to the current time.
+	NullPolicyNow = "now"
+	// NullPolicyReject rejects the records with the null values.
+	NullPolicyReject = "reject"
+)
+
+var nowTimeLayouts = map[string]string{
+	"DATE":     "2006-01-02",
+	"TIME":     "15:04:05.000000-07:00",
+	"DATETIME": "2006-01-02T15:04:05.000000-07:00",
+}
+
+// NullPolicyConverter handles the empty, null and NULL values of DATE, TIME, DATETIME and TIMESTAMP.
+type NullPolicyConverter struct {
+	Type         string
+	Policy       string
+	NullValue    string
+	DefaultValue string
+}
+
+// IsTemporalType reports whether the type is DATE, TIME, DATETIME or TIMESTAMP.
+func IsTemporalType(t string) bool {
+	switch strings.ToUpper(t) {
+	case "DATE", "TIME", "DATETIME", "TIMESTAMP":
+		return true
+	}
+	return false
+}
+
+func NewNullPolicyConverter(t, policy, nullValue string, defaultValue *string) (*NullPolicyConverter, error) {
+	t = strings.ToUpper(t)
+	if !IsTemporalType(t) {
+		return nil, errors.NewImportError(errors.ErrUnsupportedValueType, "null policy is not supported by %s", t)
+	}
+	nc := &NullPolicyConverter{
+		Type:      t,
+		Policy:    strings.ToLower(policy),
+		NullValue: nullValue,
+	}
+	switch nc.Policy {
+	case "":
+		nc.Policy = NullPolicyNull
+	case NullPolicyNull, NullPolicyNow, NullPolicyReject:
+	case NullPolicyDefault:
+		if defaultValue == nil {
+			return nil, errors.NewImportError(errors.ErrUnsupportedNullPolicy, "null policy %s requires the default value", nc.Policy)
+		}
+		nc.DefaultValue = *defaultValue
+	default:
+		return nil, errors.NewImportError(errors.ErrUnsupportedNullPolicy, "unsupported null policy %s", policy)
+	}
+	return nc, nil
+}
+
+func (nc NullPolicyConverter) Convert(v *Value) (*Value, error) {
+	if v.Val != "" && v.Val != "null" && v.Val != "NULL" {
+		return v, nil
+	}
+	switch nc.Policy {
+	case NullPolicyDefault:
+		v.Val = nc.DefaultValue
+	case NullPolicyNow:
+		now := time.Now()
+		if nc.Type == "TIMESTAMP" {
+			v.Val = strconv.FormatInt(now.Unix(), 10)
+		} else {
+			v.Val = now.Format(nowTimeLayouts[nc.Type])
+		}
+	case NullPolicyReject:
+		v.Release()
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "null value of %s is rejected", nc.Type)
+	default:
+		v.Val = nc.NullValue
+		v.IsNull = true
+		v.isSetNull = true
+	}
+	return v, nil
+}
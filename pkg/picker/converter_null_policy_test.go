@@ -0,0 +1,117 @@
+package picker
+
+import (
+	stderrors "errors"
+	"strconv"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NullPolicyConverter", func() {
+	var defaultValue = "2000-01-01"
+
+	DescribeTable("NewNullPolicyConverter failed",
+		func(t, policy string, defaultValue *string, expectErr error) {
+			nc, err := NewNullPolicyConverter(t, policy, "NULL", defaultValue)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			Expect(nc).To(BeNil())
+		},
+		Entry("unsupported type", "INT", NullPolicyNull, nil, errors.ErrUnsupportedValueType),
+		Entry("unsupported policy", "DATE", "unknown", nil, errors.ErrUnsupportedNullPolicy),
+		Entry("default without value", "DATE", NullPolicyDefault, nil, errors.ErrUnsupportedNullPolicy),
+	)
+
+	DescribeTable("Convert",
+		func(policy, val string, expectValue *Value) {
+			nc, err := NewNullPolicyConverter("DATE", policy, "NULL", &defaultValue)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := nc.Convert(&Value{Val: val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(expectValue))
+		},
+		Entry("not null", NullPolicyReject, "2023-01-01", &Value{Val: "2023-01-01"}),
+		Entry("default policy", "", "", &Value{Val: "NULL", IsNull: true, isSetNull: true}),
+		Entry("null", NullPolicyNull, "null", &Value{Val: "NULL", IsNull: true, isSetNull: true}),
+		Entry("default", NullPolicyDefault, "NULL", &Value{Val: "2000-01-01"}),
+		Entry("default upper case", "DEFAULT", "", &Value{Val: "2000-01-01"}),
+	)
+
+	It("reject", func() {
+		nc, err := NewNullPolicyConverter("DATETIME", NullPolicyReject, "NULL", nil)
+		Expect(err).NotTo(HaveOccurred())
+		v, err := nc.Convert(&Value{Val: ""})
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+		Expect(v).To(BeNil())
+	})
+
+	DescribeTable("now",
+		func(t, layout string) {
+			nc, err := NewNullPolicyConverter(t, NullPolicyNow, "NULL", nil)
+			Expect(err).NotTo(HaveOccurred())
+			before := time.Now().Add(-time.Second)
+			v, err := nc.Convert(&Value{Val: ""})
+			Expect(err).NotTo(HaveOccurred())
+			var now time.Time
+			if layout == "" {
+				sec, err := strconv.ParseInt(v.Val, 10, 64)
+				Expect(err).NotTo(HaveOccurred())
+				now = time.Unix(sec, 0)
+			} else {
+				now, err = time.Parse(layout, v.Val)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			if t != "TIME" {
+				Expect(now).To(BeTemporally(">=", before.Truncate(24*time.Hour)))
+			}
+		},
+		Entry("DATE", "DATE", "2006-01-02"),
+		Entry("TIME", "TIME", "15:04:05.000000-07:00"),
+		Entry("DATETIME", "DATETIME", "2006-01-02T15:04:05.000000-07:00"),
+		Entry("TIMESTAMP", "TIMESTAMP", ""),
+	)
+
+	DescribeTable("Config",
+		func(c Config, val string, expectValue *Value, expectErr error) {
+			p, err := c.Build()
+			Expect(err).NotTo(HaveOccurred())
+			v, err := p.Pick([]string{val})
+			if expectErr != nil {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(expectValue))
+		},
+		Entry("date null", Config{Indices: []int{0}, Type: "DATE"}, "",
+			&Value{Val: "NULL", IsNull: true, isSetNull: true}, nil),
+		Entry("time null", Config{Indices: []int{0}, Type: "TIME"}, "NULL",
+			&Value{Val: "NULL", IsNull: true, isSetNull: true}, nil),
+		Entry("datetime default", Config{Indices: []int{0}, Type: "DATETIME", NullPolicy: NullPolicyDefault, DefaultValue: &defaultValue}, "",
+			&Value{Val: `DATETIME("2000-01-01")`}, nil),
+		Entry("date reject", Config{Indices: []int{0}, Type: "DATE", NullPolicy: NullPolicyReject}, "",
+			nil, errors.ErrInvalidValue),
+		Entry("date format reject", Config{Indices: []int{0}, Type: "DATE", Format: "%Y%m%d", NullPolicy: NullPolicyReject}, "",
+			nil, errors.ErrInvalidValue),
+		Entry("date nullable", Config{
+			Indices:   []int{0},
+			Type:      "DATE",
+			Nullable:  func(s string) bool { return s == "N/A" },
+			NullValue: "NULL",
+		}, "N/A", &Value{Val: "NULL", IsNull: true, isSetNull: true}, nil),
+	)
+
+	It("Config unsupported type", func() {
+		c := Config{Indices: []int{0}, Type: "INT", NullPolicy: NullPolicyNull}
+		p, err := c.Build()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnsupportedValueType)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+})
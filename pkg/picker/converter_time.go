@@ -0,0 +1,209 @@
+package picker
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+var _ Converter = TimeFormatConverter{}
+
+const (
+	// The formats to parse the epoch values.
+	TimeFormatUnix      = "unix"
+	TimeFormatUnixMilli = "unixmilli"
+	TimeFormatUnixMicro = "unixmicro"
+	TimeFormatUnixNano  = "unixnano"
+)
+
+var (
+	// The layouts to parse the values when only the timezone is set.
+	defaultTimeLayouts = map[string][]string{
+		"DATE":      {"2006-01-02", time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999"},
+		"TIME":      {"15:04:05.999999999Z07:00", "15:04:05.999999999"},
+		"DATETIME":  {time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02"},
+		"TIMESTAMP": {time.RFC3339Nano, "2006-01-02T15:04:05.999999999", "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02"},
+	}
+
+	// The layouts to format the parsed values, which are accepted by the functions of graphd.
+	outputTimeLayouts = map[string]string{
+		"DATE":     "2006-01-02",
+		"TIME":     "15:04:05.000000-07:00",
+		"DATETIME": "2006-01-02T15:04:05.000000-07:00",
+	}
+
+	strftimeDirectives = map[byte]string{
+		'Y': "2006",
+		'y': "06",
+		'm': "01",
+		'd': "02",
+		'e': "_2",
+		'j': "002",
+		'H': "15",
+		'I': "03",
+		'M': "04",
+		'S': "05",
+		'f': "000000",
+		'p': "PM",
+		'b': "Jan",
+		'h': "Jan",
+		'B': "January",
+		'a': "Mon",
+		'A': "Monday",
+		'z': "-0700",
+		'Z': "MST",
+		'F': "2006-01-02",
+		'T': "15:04:05",
+		'D': "01/02/06",
+		'%': "%",
+	}
+
+	// strftimeCheckTime is the time to check the layout converted from the strftime format,
+	// whose fields are formatted unlike the Go layout tokens, such as the year 2009 and the hour 09 AM.
+	strftimeCheckTime = time.Date(2009, time.November, 10, 9, 8, 7, 123456789, time.FixedZone("XYZ", 90*60))
+)
+
+// TimeFormatConverter parses the value by the format in the location,
+// and normalizes it to the value accepted by graphd for the type.
+type TimeFormatConverter struct {
+	Type     string
+	Format   string
+	Location *time.Location
+
+	layouts []string
+}
+
+// NewTimeFormatConverter returns the converter for DATE, TIME, DATETIME and TIMESTAMP.
+// The format is a Go layout, a strftime format with `%`, or one of unix, unixmilli, unixmicro and unixnano.
+// The values without offset are parsed in the timezone, which is UTC by default.
+func NewTimeFormatConverter(t, format, timezone string) (*TimeFormatConverter, error) {
+	t = strings.ToUpper(t)
+	defaultLayouts, ok := defaultTimeLayouts[t]
+	if !ok {
+		return nil, errors.NewImportError(errors.ErrUnsupportedValueType, "format and timezone are not supported by %s", t)
+	}
+
+	location := time.UTC
+	if timezone != "" {
+		var err error
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, errors.NewImportError(errors.ErrInvalidTimezone, "%s", err)
+		}
+		if t == "TIME" {
+			// The time without date is in year 0, use the current offset of the timezone rather than the historical one.
+			_, offset := time.Now().In(location).Zone()
+			location = time.FixedZone(timezone, offset)
+		}
+	}
+
+	tc := &TimeFormatConverter{
+		Type:     t,
+		Format:   format,
+		Location: location,
+	}
+	switch {
+	case format == "":
+		tc.layouts = defaultLayouts
+	case isUnixTimeFormat(format):
+	case strings.Contains(format, "%"):
+		layout, err := StrftimeToLayout(format)
+		if err != nil {
+			return nil, err
+		}
+		tc.layouts = []string{layout}
+	default:
+		tc.layouts = []string{format}
+	}
+	return tc, nil
+}
+
+func (tc TimeFormatConverter) Convert(v *Value) (*Value, error) {
+	// The null values are left to the subsequent converters.
+	if v.Val == "" || v.Val == "null" || v.Val == "NULL" {
+		return v, nil
+	}
+	t, err := tc.parse(v.Val)
+	if err != nil {
+		v.Release()
+		return nil, err
+	}
+	t = t.In(tc.Location)
+	if tc.Type == "TIMESTAMP" {
+		v.Val = strconv.FormatInt(t.Unix(), 10)
+	} else {
+		v.Val = t.Format(outputTimeLayouts[tc.Type])
+	}
+	return v, nil
+}
+
+func (tc TimeFormatConverter) parse(val string) (time.Time, error) {
+	if isUnixTimeFormat(tc.Format) {
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return time.Time{}, errors.NewImportError(errors.ErrInvalidValue, "%q is not a %s time", val, tc.Format)
+		}
+		switch tc.Format {
+		case TimeFormatUnix:
+			return time.Unix(n, 0), nil
+		case TimeFormatUnixMilli:
+			return time.UnixMilli(n), nil
+		case TimeFormatUnixMicro:
+			return time.UnixMicro(n), nil
+		}
+		return time.Unix(0, n), nil
+	}
+	for _, layout := range tc.layouts {
+		if t, err := time.ParseInLocation(layout, val, tc.Location); err == nil {
+			return t, nil
+		}
+	}
+	if tc.Format == "" {
+		return time.Time{}, errors.NewImportError(errors.ErrInvalidValue, "%q is not a valid %s", val, tc.Type)
+	}
+	return time.Time{}, errors.NewImportError(errors.ErrInvalidValue, "%q does not match the format %q", val, tc.Format)
+}
+
+func isUnixTimeFormat(format string) bool {
+	switch format {
+	case TimeFormatUnix, TimeFormatUnixMilli, TimeFormatUnixMicro, TimeFormatUnixNano:
+		return true
+	}
+	return false
+}
+
+// StrftimeToLayout converts the strftime format to the Go layout, such as `%Y-%m-%d` to `2006-01-02`.
+// The Go layout can not escape the literal text, so the formats whose literal text is read as the Go layout tokens,
+// such as `Mon`, `Jan`, `2006`, `PM` or the digits next to the directives, are rejected.
+func StrftimeToLayout(format string) (string, error) {
+	// The expected is the check time formatted by the directives one by one, with the literal text as-is.
+	var sb, expected strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			_ = sb.WriteByte(format[i])
+			_ = expected.WriteByte(format[i])
+			continue
+		}
+		i++
+		if i >= len(format) {
+			return "", errors.NewImportError(errors.ErrInvalidFormat, "%q ends with %%", format)
+		}
+		layout, ok := strftimeDirectives[format[i]]
+		if !ok {
+			return "", errors.NewImportError(errors.ErrInvalidFormat, "%q has unsupported directive %%%c", format, format[i])
+		}
+		_, _ = sb.WriteString(layout)
+		if format[i] == 'f' {
+			// The "000000" is the fractional second only after the '.' or ','.
+			_, _ = expected.WriteString(strconv.Itoa(strftimeCheckTime.Nanosecond()/1000 + 1000000)[1:])
+		} else {
+			_, _ = expected.WriteString(strftimeCheckTime.Format(layout))
+		}
+	}
+	layout := sb.String()
+	if strftimeCheckTime.Format(layout) != expected.String() {
+		return "", errors.NewImportError(errors.ErrInvalidFormat, "%q has the literal text read as the Go layout", format)
+	}
+	return layout, nil
+}
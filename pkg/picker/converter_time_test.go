@@ -0,0 +1,116 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TimeFormatConverter", func() {
+	DescribeTable("NewTimeFormatConverter failed",
+		func(t, format, timezone string, expectErr error) {
+			tc, err := NewTimeFormatConverter(t, format, timezone)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			Expect(tc).To(BeNil())
+		},
+		Entry("unsupported type", "STRING", "2006", "", errors.ErrUnsupportedValueType),
+		Entry("invalid timezone", "DATETIME", "", "Unknown/Zone", errors.ErrInvalidTimezone),
+		Entry("strftime ends with %", "DATETIME", "%Y%", "", errors.ErrInvalidFormat),
+		Entry("strftime unsupported directive", "DATETIME", "%Q", "", errors.ErrInvalidFormat),
+		Entry("strftime literal weekday", "DATETIME", "Mon %Y-%m-%d", "", errors.ErrInvalidFormat),
+		Entry("strftime literal month", "DATETIME", "%d Jan %Y", "", errors.ErrInvalidFormat),
+		Entry("strftime literal year", "DATETIME", "2006/%m/%d", "", errors.ErrInvalidFormat),
+		Entry("strftime literal PM", "DATETIME", "%Y-%m-%d %H PM", "", errors.ErrInvalidFormat),
+		Entry("strftime literal digit", "DATETIME", "%Y1%m%d", "", errors.ErrInvalidFormat),
+		Entry("strftime fraction without dot", "DATETIME", "%S%f", "", errors.ErrInvalidFormat),
+	)
+
+	DescribeTable("Convert",
+		func(t, format, timezone, val, expectVal string) {
+			tc, err := NewTimeFormatConverter(t, format, timezone)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := tc.Convert(&Value{Val: val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(&Value{Val: expectVal}))
+		},
+		Entry("go layout", "DATETIME", "02/01/2006 15:04", "", "13/05/2024 08:30", "2024-05-13T08:30:00.000000+00:00"),
+		Entry("go layout with timezone", "DATETIME", "02/01/2006 15:04", "Asia/Shanghai", "13/05/2024 08:30", "2024-05-13T08:30:00.000000+08:00"),
+		Entry("strftime", "DATETIME", "%d/%m/%Y %H:%M:%S.%f", "", "13/05/2024 08:30:01.000123", "2024-05-13T08:30:01.000123+00:00"),
+		Entry("strftime with literal text", "DATETIME", "at %Y-%m-%dT%H:%M %% UTC", "", "at 2024-05-13T08:30 % UTC", "2024-05-13T08:30:00.000000+00:00"),
+		Entry("strftime with names", "DATETIME", "%a, %d %b %Y %I:%M %p", "", "Mon, 13 May 2024 08:30 PM", "2024-05-13T20:30:00.000000+00:00"),
+		Entry("offset in value", "DATETIME", "", "Asia/Shanghai", "2024-05-13T00:30:00Z", "2024-05-13T08:30:00.000000+08:00"),
+		Entry("local without offset", "DATETIME", "", "America/New_York", "2024-05-13 08:30:00", "2024-05-13T08:30:00.000000-04:00"),
+		Entry("unix", "DATETIME", "unix", "", "1715589000", "2024-05-13T08:30:00.000000+00:00"),
+		Entry("unixmilli", "DATETIME", "unixmilli", "Asia/Shanghai", "1715589000123", "2024-05-13T16:30:00.123000+08:00"),
+		Entry("unixmicro", "DATE", "unixmicro", "", "1715589000123456", "2024-05-13"),
+		Entry("unixnano", "TIMESTAMP", "unixnano", "", "1715589000123456789", "1715589000"),
+		Entry("date", "DATE", "%Y%m%d", "", "20240513", "2024-05-13"),
+		Entry("date in timezone", "DATE", "unix", "Asia/Shanghai", "1715619600", "2024-05-14"),
+		Entry("time", "TIME", "%H:%M", "", "08:30", "08:30:00.000000+00:00"),
+		Entry("time with timezone", "TIME", "", "Etc/GMT-8", "08:30:00", "08:30:00.000000+08:00"),
+		Entry("timestamp", "TIMESTAMP", "2006-01-02 15:04:05", "Asia/Shanghai", "2024-05-13 16:30:00", "1715589000"),
+		Entry("timestamp zero", "TIMESTAMP", "unix", "", "0", "0"),
+		Entry("timestamp negative", "TIMESTAMP", "2006-01-02", "", "1969-12-31", "-86400"),
+		Entry("timestamp negative unix", "TIMESTAMP", "unixmilli", "", "-86400000", "-86400"),
+		Entry("empty", "DATETIME", "unix", "", "", ""),
+		Entry("null", "DATETIME", "unix", "", "NULL", "NULL"),
+	)
+
+	DescribeTable("Convert failed",
+		func(t, format, val string) {
+			tc, err := NewTimeFormatConverter(t, format, "")
+			Expect(err).NotTo(HaveOccurred())
+			v, err := tc.Convert(&Value{Val: val})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+			Expect(v).To(BeNil())
+		},
+		Entry("unix", "DATETIME", "unix", "abc"),
+		Entry("layout", "DATETIME", "02/01/2006", "2024-05-13"),
+		Entry("default layouts", "DATE", "", "13/05/2024"),
+	)
+
+	DescribeTable("Config TIMESTAMP",
+		func(format, val, expectVal string) {
+			c := Config{
+				Indices: []int{0},
+				Type:    "TIMESTAMP",
+				Format:  format,
+			}
+			p, err := c.Build()
+			Expect(err).NotTo(HaveOccurred())
+			v, err := p.Pick([]string{val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Val).To(Equal(expectVal))
+			v.Release()
+		},
+		Entry("positive", "unix", "1715589000", "TIMESTAMP(1715589000)"),
+		Entry("zero", "unix", "0", "TIMESTAMP(0)"),
+		Entry("negative", "unix", "-86400", "TIMESTAMP(-86400)"),
+		Entry("negative layout", "2006-01-02", "1969-12-31", "TIMESTAMP(-86400)"),
+	)
+
+	It("Config", func() {
+		c := Config{
+			Indices:  []int{0},
+			Type:     "DATETIME",
+			Format:   "unix",
+			Timezone: "UTC",
+		}
+		p, err := c.Build()
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"1715589000"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Val).To(Equal(`DATETIME("2024-05-13T08:30:00.000000+00:00")`))
+
+		c.Type = "INT"
+		p, err = c.Build()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnsupportedValueType)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+})
@@ -18,6 +18,7 @@ var (
 	_ Converter = TypeTimeConverter{}
 	_ Converter = TypeDatetimeConverter{}
 	_ Converter = TypeTimestampConverter{}
+	_ Converter = TypeDurationConverter{}
 	_ Converter = TypeGeoConverter{}
 	_ Converter = TypeGeoPointConverter{}
 	_ Converter = TypeGeoLineStringConverter{}
@@ -47,13 +48,11 @@ type (
 		fsc FunctionStringConverter
 	}
 
-	TypeGeoConverter = FunctionStringConverter
+	TypeGeoPointConverter = TypeGeoConverter
 
-	TypeGeoPointConverter = FunctionStringConverter
+	TypeGeoLineStringConverter = TypeGeoConverter
 
-	TypeGeoLineStringConverter = FunctionStringConverter
-
-	TypeGeoPolygonConverter = FunctionStringConverter
+	TypeGeoPolygonConverter = TypeGeoConverter
 )
 
 func NewTypeConverter(t string) (Converter, error) {
@@ -99,21 +98,28 @@ func NewTypeConverter(t string) (Converter, error) {
 				Name: "TIMESTAMP",
 			},
 		}, nil
+	case "DURATION":
+		return TypeDurationConverter{
+			Name: "duration",
+		}, nil
 	case "GEOGRAPHY":
 		return TypeGeoConverter{
 			Name: "ST_GeogFromText",
 		}, nil
 	case "GEOGRAPHY(POINT)":
 		return TypeGeoPointConverter{
-			Name: "ST_GeogFromText",
+			Name:         "ST_GeogFromText",
+			GeometryType: GeometryTypePoint,
 		}, nil
 	case "GEOGRAPHY(LINESTRING)":
 		return TypeGeoLineStringConverter{
-			Name: "ST_GeogFromText",
+			Name:         "ST_GeogFromText",
+			GeometryType: GeometryTypeLineString,
 		}, nil
 	case "GEOGRAPHY(POLYGON)":
 		return TypeGeoPolygonConverter{
-			Name: "ST_GeogFromText",
+			Name:         "ST_GeogFromText",
+			GeometryType: GeometryTypePolygon,
 		}, nil
 	}
 	return nil, errors.ErrUnsupportedValueType
@@ -125,7 +131,8 @@ func (TypeStringConverter) Convert(v *Value) (*Value, error) {
 }
 
 func (tc TypeTimestampConverter) Convert(v *Value) (*Value, error) {
-	if utils.IsUnsignedInteger(v.Val) {
+	// The negative values are the timestamps before 1970.
+	if utils.IsInteger(v.Val) {
 		return tc.fc.Convert(v)
 	}
 	return tc.fsc.Convert(v)
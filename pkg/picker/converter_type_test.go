@@ -132,6 +132,14 @@ var _ = Describe("TypeConverter", func() {
 		Expect(v).To(Equal(&Value{
 			Val: "TIMESTAMP(1578770903)",
 		}))
+
+		v, err = converter.Convert(&Value{
+			Val: "-1578770903",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(&Value{
+			Val: "TIMESTAMP(-1578770903)",
+		}))
 	})
 
 	It("GEOGRAPHY", func() {
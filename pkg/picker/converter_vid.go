@@ -0,0 +1,162 @@
+package picker
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/google/uuid"
+	"github.com/spaolacci/murmur3"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+var (
+	_ Converter = HashFunctionConverter{}
+	_ Converter = UUIDv5FunctionConverter{}
+	_ Converter = PrefixFunctionConverter{}
+)
+
+// The functions which generate the values in the client, so no graphd function is required.
+const (
+	FunctionXXHash64 = "xxhash64"
+	FunctionMurmur3  = "murmur3"
+	FunctionUUIDv5   = "uuidv5"
+	FunctionPrefix   = "prefix"
+)
+
+var uuidNamespaces = map[string]uuid.UUID{
+	"DNS":  uuid.NameSpaceDNS,
+	"URL":  uuid.NameSpaceURL,
+	"OID":  uuid.NameSpaceOID,
+	"X500": uuid.NameSpaceX500,
+}
+
+type (
+	// FunctionOptions are the options of the client functions.
+	FunctionOptions struct {
+		Seed      uint64 // The seed of xxhash64 and murmur3, murmur3 only accepts 32 bits.
+		Namespace string // The namespace of uuidv5, a UUID or one of dns, url, oid and x500.
+		Prefix    string // The prefix of prefix.
+	}
+
+	// HashFunctionConverter replaces the value with its 64 bits hash as a signed integer,
+	// which is the same as the INT64 VID.
+	HashFunctionConverter struct {
+		Hash func(string) uint64
+	}
+
+	// UUIDv5FunctionConverter replaces the value with the UUIDv5 of it in the namespace.
+	UUIDv5FunctionConverter struct {
+		Namespace uuid.UUID
+	}
+
+	// PrefixFunctionConverter prepends the prefix to the value.
+	PrefixFunctionConverter struct {
+		Prefix  string
+		Integer bool // Whether the result must be an integer.
+	}
+)
+
+// IsClientFunction reports whether the function generates the values in the client.
+func IsClientFunction(function string) bool {
+	switch strings.ToLower(function) {
+	case FunctionXXHash64, FunctionMurmur3, FunctionUUIDv5, FunctionPrefix:
+		return true
+	}
+	return false
+}
+
+// NewClientFunctionConverter returns the converter of the client function for the type of value.
+func NewClientFunctionConverter(function, t string, opts FunctionOptions) (Converter, error) {
+	isInteger := isIntegerType(t)
+	switch strings.ToLower(function) {
+	case FunctionXXHash64:
+		seed := opts.Seed
+		return HashFunctionConverter{
+			Hash: func(s string) uint64 {
+				if seed == 0 {
+					return xxhash.Sum64String(s)
+				}
+				d := xxhash.NewWithSeed(seed)
+				_, _ = d.WriteString(s)
+				return d.Sum64()
+			},
+		}, nil
+	case FunctionMurmur3:
+		if opts.Seed > 1<<32-1 {
+			return nil, errors.NewImportError(errors.ErrInvalidFunctionOption,
+				"the seed %d of murmur3 overflows uint32", opts.Seed)
+		}
+		seed := uint32(opts.Seed)
+		return HashFunctionConverter{
+			Hash: func(s string) uint64 {
+				return murmur3.Sum64WithSeed([]byte(s), seed)
+			},
+		}, nil
+	case FunctionUUIDv5:
+		if isInteger {
+			return nil, errors.NewImportError(errors.ErrUnsupportedFunction,
+				"function %s is unsupported for type %s", function, t)
+		}
+		namespace, ok := uuidNamespaces[strings.ToUpper(opts.Namespace)]
+		if !ok {
+			var err error
+			if namespace, err = uuid.Parse(opts.Namespace); err != nil {
+				return nil, errors.NewImportError(errors.ErrInvalidFunctionOption,
+					"invalid namespace %q of uuidv5", opts.Namespace)
+			}
+		}
+		return UUIDv5FunctionConverter{
+			Namespace: namespace,
+		}, nil
+	case FunctionPrefix:
+		if isInteger && opts.Prefix != "" && !isInteger64(opts.Prefix) {
+			return nil, errors.NewImportError(errors.ErrInvalidFunctionOption,
+				"the prefix %q is not an integer for type %s", opts.Prefix, t)
+		}
+		return PrefixFunctionConverter{
+			Prefix:  opts.Prefix,
+			Integer: isInteger,
+		}, nil
+	}
+	return nil, errors.NewImportError(errors.ErrUnsupportedFunction, "unsupported function %s", function)
+}
+
+func (fc HashFunctionConverter) Convert(v *Value) (*Value, error) {
+	v.Val = strconv.FormatInt(int64(fc.Hash(v.Val)), 10)
+	return v, nil
+}
+
+func (fc UUIDv5FunctionConverter) Convert(v *Value) (*Value, error) {
+	v.Val = uuid.NewSHA1(fc.Namespace, []byte(v.Val)).String()
+	return v, nil
+}
+
+func (fc PrefixFunctionConverter) Convert(v *Value) (*Value, error) {
+	val := fc.Prefix + v.Val
+	if fc.Integer {
+		// Format it again, the leading zeros of the integers mean octal in graphd.
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			v.Release()
+			return nil, errors.NewImportError(errors.ErrInvalidValue, "%q is not an integer", val)
+		}
+		val = strconv.FormatInt(n, 10)
+	}
+	v.Val = val
+	return v, nil
+}
+
+func isIntegerType(t string) bool {
+	switch strings.ToUpper(t) {
+	case "INT", "INT8", "INT16", "INT32", "INT64":
+		return true
+	}
+	return false
+}
+
+func isInteger64(s string) bool {
+	_, err := strconv.ParseInt(s, 10, 64)
+	return err == nil
+}
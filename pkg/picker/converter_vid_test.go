@@ -0,0 +1,79 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ClientFunctionConverter", func() {
+	DescribeTable("IsClientFunction",
+		func(function string, expectResult bool) {
+			Expect(IsClientFunction(function)).To(Equal(expectResult))
+		},
+		Entry(nil, "xxhash64", true),
+		Entry(nil, "XXHASH64", true),
+		Entry(nil, "murmur3", true),
+		Entry(nil, "uuidv5", true),
+		Entry(nil, "prefix", true),
+		Entry(nil, "hash", false),
+		Entry(nil, "", false),
+	)
+
+	// The golden values must never change, the VIDs are reproduced by them in other systems.
+	DescribeTable("golden",
+		func(function, t string, opts FunctionOptions, val, expectValue string) {
+			c := Config{
+				Indices:         []int{0},
+				Type:            t,
+				Function:        &function,
+				FunctionOptions: opts,
+			}
+			p, err := c.Build()
+			Expect(err).NotTo(HaveOccurred())
+			v, err := p.Pick([]string{val})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Val).To(Equal(expectValue))
+		},
+		Entry("xxhash64 empty", "xxhash64", "INT64", FunctionOptions{}, "", "-1205034819632174695"),
+		Entry("xxhash64", "xxhash64", "INT64", FunctionOptions{}, "hello", "2794345569481354659"),
+		Entry("xxhash64 seed", "xxhash64", "INT", FunctionOptions{Seed: 42}, "hello", "-4367754540140381902"),
+		Entry("xxhash64 string", "XXHASH64", "STRING", FunctionOptions{}, "hello", `"2794345569481354659"`),
+		Entry("murmur3", "murmur3", "INT64", FunctionOptions{}, "hello", "-3758069500696749310"),
+		Entry("murmur3 seed", "murmur3", "INT64", FunctionOptions{Seed: 42}, "hello", "-4271466569069007096"),
+		Entry("uuidv5 dns", "uuidv5", "FIXED_STRING", FunctionOptions{Namespace: "dns"},
+			"www.example.com", `"2ed6657d-e927-568b-95e1-2665a8aea6a2"`),
+		Entry("uuidv5 namespace", "uuidv5", "STRING", FunctionOptions{Namespace: "6ba7b811-9dad-11d1-80b4-00c04fd430c8"},
+			"https://example.com", `"4fd35a71-71ef-5a55-a9d9-aa75c889a6d0"`),
+		Entry("prefix", "prefix", "STRING", FunctionOptions{Prefix: "user_"}, "1", `"user_1"`),
+		Entry("prefix int", "prefix", "INT64", FunctionOptions{Prefix: "10"}, "0023", "100023"),
+		Entry("prefix int leading zeros", "prefix", "INT64", FunctionOptions{Prefix: "00"}, "12", "12"),
+	)
+
+	DescribeTable("NewClientFunctionConverter failed",
+		func(function, t string, opts FunctionOptions, expectErr error) {
+			c, err := NewClientFunctionConverter(function, t, opts)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			Expect(c).To(BeNil())
+		},
+		Entry("unsupported function", "hash", "INT64", FunctionOptions{}, errors.ErrUnsupportedFunction),
+		Entry("murmur3 seed overflow", "murmur3", "INT64", FunctionOptions{Seed: 1 << 32}, errors.ErrInvalidFunctionOption),
+		Entry("uuidv5 int", "uuidv5", "INT64", FunctionOptions{Namespace: "dns"}, errors.ErrUnsupportedFunction),
+		Entry("uuidv5 no namespace", "uuidv5", "STRING", FunctionOptions{}, errors.ErrInvalidFunctionOption),
+		Entry("uuidv5 invalid namespace", "uuidv5", "STRING", FunctionOptions{Namespace: "x"}, errors.ErrInvalidFunctionOption),
+		Entry("prefix not integer", "prefix", "INT64", FunctionOptions{Prefix: "u"}, errors.ErrInvalidFunctionOption),
+	)
+
+	It("prefix not integer value", func() {
+		c, err := NewClientFunctionConverter("prefix", "INT64", FunctionOptions{Prefix: "1"})
+		Expect(err).NotTo(HaveOccurred())
+		v, err := c.Convert(&Value{Val: "a"})
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+		Expect(v).To(BeNil())
+	})
+})
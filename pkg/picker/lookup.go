@@ -0,0 +1,81 @@
+package picker
+
+import (
+	"fmt"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+const exprLookupName = "lookup"
+
+type (
+	// Lookup finds the value of the key in a dictionary.
+	// It handles the misses by its policy, such as returning nil, a default value or an error.
+	Lookup interface {
+		Lookup(key string) (any, error)
+	}
+
+	// Lookups are the dictionaries by name, which are available as `lookup(name, key)` in the expressions.
+	Lookups map[string]Lookup
+
+	lookupVisitor struct {
+		lookups Lookups
+		err     error
+	}
+)
+
+// CompileExpr compiles the expression with the lookups,
+// the names of the lookups must be known if they are constants.
+func CompileExpr(input string, lookups Lookups, opts ...expr.Option) (*vm.Program, error) {
+	if len(lookups) > 0 {
+		opts = append(opts, expr.Function(exprLookupName, lookups.call, new(func(string, any) any)))
+	}
+	program, err := expr.Compile(input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	v := &lookupVisitor{lookups: lookups}
+	node := program.Node()
+	ast.Walk(&node, v)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return program, nil
+}
+
+func (ls Lookups) call(params ...any) (any, error) {
+	name, _ := params[0].(string)
+	l, ok := ls[name]
+	if !ok {
+		return nil, errors.NewImportError(errors.ErrUnknownLookup, "unknown lookup %q", name)
+	}
+	var key string
+	switch k := params[1].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		key = k
+	default:
+		key = fmt.Sprint(k)
+	}
+	return l.Lookup(key)
+}
+
+func (v *lookupVisitor) Visit(node *ast.Node) {
+	n, ok := (*node).(*ast.CallNode)
+	if !ok || v.err != nil || len(n.Arguments) == 0 {
+		return
+	}
+	if callee, ok := n.Callee.(*ast.IdentifierNode); !ok || callee.Value != exprLookupName {
+		return
+	}
+	if name, ok := n.Arguments[0].(*ast.StringNode); ok {
+		if _, ok = v.lookups[name.Value]; !ok {
+			v.err = errors.NewImportError(errors.ErrUnknownLookup, "unknown lookup %q", name.Value)
+		}
+	}
+}
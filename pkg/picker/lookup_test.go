@@ -0,0 +1,88 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type testLookup map[string]string
+
+func (l testLookup) Lookup(key string) (any, error) {
+	if v, ok := l[key]; ok {
+		return v, nil
+	}
+	if key == "error" {
+		return nil, errors.ErrLookupMiss
+	}
+	return nil, nil
+}
+
+var _ = Describe("Lookups", func() {
+	lookups := Lookups{
+		"users": testLookup{"1": "Tom", "2": "Jerry"},
+	}
+
+	DescribeTable("ExprPicker",
+		func(valueExpr string, record []string, expectValue string, expectErr error) {
+			p, err := NewExprPicker(valueExpr, map[string]int{"uid": 1}, lookups)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := p.Pick(record)
+			if expectErr != nil {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+				Expect(v).To(BeNil())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v.Val).To(Equal(expectValue))
+		},
+		Entry("hit", `lookup("users", Record[1])`, []string{"a", "1"}, "Tom", nil),
+		Entry("column", `lookup("users", uid)`, []string{"a", "2"}, "Jerry", nil),
+		Entry("miss", `lookup("users", uid)`, []string{"a", "3"}, "", nil),
+		Entry("miss default", `lookup("users", uid) ?? "unknown"`, []string{"a", "3"}, "unknown", nil),
+		Entry("not constant name", `lookup(Record[0], uid)`, []string{"users", "1"}, "Tom", nil),
+		Entry("int key", `lookup("users", 1)`, []string{"a", "b"}, "Tom", nil),
+		Entry("nil key", `lookup("users", nil)`, []string{"a", "b"}, "", nil),
+		Entry("error", `lookup("users", "error")`, []string{"a", "b"}, "", errors.ErrLookupMiss),
+		Entry("unknown lookup at runtime", `lookup(Record[0], uid)`, []string{"a", "1"}, "", errors.ErrUnknownLookup),
+	)
+
+	DescribeTable("CompileExpr failed",
+		func(input string, lookups Lookups, expectErr error) {
+			program, err := CompileExpr(input, lookups)
+			Expect(err).To(HaveOccurred())
+			if expectErr != nil {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+			Expect(program).To(BeNil())
+		},
+		Entry("unknown lookup", `lookup("groups", "1")`, lookups, errors.ErrUnknownLookup),
+		Entry("no lookups", `lookup("users", "1")`, nil, nil),
+		Entry("wrong arguments", `lookup("users")`, lookups, nil),
+	)
+
+	It("Config", func() {
+		valueExpr := `lookup("users", Record[0])`
+		c := Config{
+			ValueExpr: &valueExpr,
+			Lookups:   lookups,
+			Type:      "STRING",
+		}
+		p, err := c.Build()
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v.Val).To(Equal(`"Tom"`))
+
+		valueExpr = `lookup("groups", Record[0])`
+		p, err = c.Build()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrValueExprSyntax)).To(BeTrue())
+		Expect(stderrors.Is(err, errors.ErrUnknownLookup)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+})
@@ -0,0 +1,115 @@
+package picker
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+const exprRecordName = "Record"
+
+var _ Picker = (*ExprPicker)(nil)
+
+// ExprPicker picks the value by evaluating an expression over the whole record.
+// The record is available as `Record`, the named columns are available by their names,
+// and the lookups are available as `lookup(name, key)`.
+// Only the named columns referenced by the expression are required to be in the record.
+type ExprPicker struct {
+	columns map[string]int
+	program *vm.Program
+}
+
+func NewExprPicker(valueExpr string, columns map[string]int, lookups Lookups) (*ExprPicker, error) {
+	env := make(map[string]any, len(columns)+1)
+	env[exprRecordName] = []string{}
+	for name, index := range columns {
+		if index < 0 {
+			return nil, errors.ErrInvalidIndex
+		}
+		if name == exprRecordName {
+			continue
+		}
+		env[name] = ""
+	}
+	program, err := CompileExpr(valueExpr, lookups, expr.Env(env), expr.Function("sprintf", exprSprintf))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrValueExprSyntax, err)
+	}
+
+	v := &identifierVisitor{
+		columns: columns,
+		used:    make(map[string]int, len(columns)),
+	}
+	node := program.Node()
+	ast.Walk(&node, v)
+	return &ExprPicker{
+		columns: v.used,
+		program: program,
+	}, nil
+}
+
+func (ep *ExprPicker) Pick(record []string) (*Value, error) {
+	env := make(map[string]any, len(ep.columns)+1)
+	env[exprRecordName] = record
+	for name, index := range ep.columns {
+		if index >= len(record) {
+			return nil, errors.ErrNoRecord
+		}
+		env[name] = record[index]
+	}
+	out, err := expr.Run(ep.program, env)
+	if err != nil {
+		return nil, err
+	}
+	return NewValue(exprOutString(out)), nil
+}
+
+// identifierVisitor collects the named columns referenced by the expression.
+type identifierVisitor struct {
+	columns map[string]int
+	used    map[string]int
+}
+
+func (v *identifierVisitor) Visit(node *ast.Node) {
+	n, ok := (*node).(*ast.IdentifierNode)
+	if !ok || n.Value == exprRecordName {
+		return
+	}
+	if index, ok := v.columns[n.Value]; ok {
+		v.used[n.Value] = index
+	}
+}
+
+func exprSprintf(params ...any) (any, error) {
+	if len(params) == 0 {
+		return nil, fmt.Errorf("sprintf: missing format")
+	}
+	format, ok := params[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("sprintf: format must be a string, got %T", params[0])
+	}
+	return fmt.Sprintf(format, params[1:]...), nil
+}
+
+func exprOutString(out any) string {
+	switch v := out.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(out)
+}
@@ -0,0 +1,88 @@
+package picker
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExprPicker", func() {
+	It("syntax failed", func() {
+		p, err := NewExprPicker("Record[", nil, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrValueExprSyntax)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+
+	It("unknown column", func() {
+		p, err := NewExprPicker("name", nil, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrValueExprSyntax)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+
+	It("invalid column index", func() {
+		p, err := NewExprPicker("name", map[string]int{"name": -1}, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidIndex)).To(BeTrue())
+		Expect(p).To(BeNil())
+	})
+
+	It("column no record", func() {
+		p, err := NewExprPicker("name", map[string]int{"name": 1}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"0"})
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrNoRecord)).To(BeTrue())
+		Expect(v).To(BeNil())
+	})
+
+	It("short record with unused column", func() {
+		p, err := NewExprPicker("name", map[string]int{"name": 0, "age": 5}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(v).To(Equal(&Value{Val: "a"}))
+		v.Release()
+	})
+
+	It("run failed", func() {
+		p, err := NewExprPicker("Record[1]", nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"0"})
+		Expect(err).To(HaveOccurred())
+		Expect(v).To(BeNil())
+	})
+
+	DescribeTable("Pick",
+		func(valueExpr string, columns map[string]int, record []string, expectValue string) {
+			p, err := NewExprPicker(valueExpr, columns, nil)
+			Expect(err).NotTo(HaveOccurred())
+			v, err := p.Pick(record)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(v).To(Equal(&Value{Val: expectValue}))
+			v.Release()
+		},
+		Entry("record", "Record[1]", nil, []string{"0", "1"}, "1"),
+		Entry("column", "name + age", map[string]int{"name": 0, "age": 1}, []string{"a", "1"}, "a1"),
+		Entry("column named Record", "Record[0]", map[string]int{"Record": 1}, []string{"a", "b"}, "a"),
+		Entry("lower", "lower(Record[0])", nil, []string{"ABC"}, "abc"),
+		Entry("sprintf", `sprintf("%s-%05d", Record[0], int(Record[1]))`, nil, []string{"a", "12"}, "a-00012"),
+		Entry("int", "int(Record[0]) * 2", nil, []string{"21"}, "42"),
+		Entry("float", "float(Record[0]) / 2", nil, []string{"3"}, "1.5"),
+		Entry("bool", `Record[0] == "1"`, nil, []string{"1"}, "true"),
+		Entry("nil", "nil", nil, []string{"1"}, ""),
+		Entry("conditional", `Record[0] == "1" ? "yes" : "no"`, nil, []string{"0"}, "no"),
+	)
+
+	It("sprintf failed", func() {
+		p, err := NewExprPicker("sprintf(1)", nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		v, err := p.Pick([]string{"0"})
+		Expect(err).To(HaveOccurred())
+		Expect(v).To(BeNil())
+	})
+})
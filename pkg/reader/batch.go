@@ -2,6 +2,7 @@
 package reader
 
 import (
+	"context"
 	"database/sql"
 	stderrors "errors"
 	"fmt"
@@ -15,7 +16,13 @@ type (
 	BatchRecordReader interface {
 		Source() source.Source
 		source.Sizer
-		ReadBatch() (int, spec.Records, error)
+		ReadBatch(ctx context.Context) (int, spec.Records, error)
+	}
+
+	// ConvertFailedReader is implemented by the batch readers which skip the records failed to read or convert,
+	// ConvertFailed returns the ones skipped in the last ReadBatch, so that they are counted as failed.
+	ConvertFailedReader interface {
+		ConvertFailed() spec.Records
 	}
 
 	Convertor interface {
@@ -27,12 +34,15 @@ type (
 
 	continueError struct {
 		Err error
+		// Record is the record skipped by the error, which is counted as failed if not nil.
+		Record spec.Record
 	}
 
 	defaultBatchReader struct {
 		*options
-		rr RecordReader
-		c  Convertor
+		rr     RecordReader
+		c      Convertor
+		failed spec.Records
 	}
 
 	sqlBatchReader struct {
@@ -41,16 +51,22 @@ type (
 		total  int64
 		lastId string
 		c      Convertor
+		failed spec.Records
 	}
 )
 
 var (
+	_ ConvertFailedReader = (*defaultBatchReader)(nil)
+	_ ConvertFailedReader = (*sqlBatchReader)(nil)
+	_ io.Closer           = (*defaultBatchReader)(nil)
+	_ io.Closer           = (*sqlBatchReader)(nil)
+
 	converts map[string]Convertor
 )
 
 func init() {
 	converts = map[string]Convertor{}
-	converts["none"] = &NoneConvertor{}
+	converts[ConvertorNone] = &NoneConvertor{}
 }
 
 func RegistryConvertor(name string, convert Convertor) {
@@ -72,8 +88,8 @@ func NewBatchRecordReader(rr RecordReader, c string, opts ...Option) BatchRecord
 	brr := &defaultBatchReader{
 		options: newOptions(opts...),
 		rr:      rr,
-		c:       GetConvertor(c),
 	}
+	brr.c = brr.getConvertor(c)
 	brr.logger = brr.logger.With(logger.Field{Key: "source", Value: rr.Source().Name()})
 	return brr
 }
@@ -82,8 +98,8 @@ func NewSQLBatchRecordReader(s *source.SQLSource, c string, opts ...Option) Batc
 	brr := &sqlBatchReader{
 		options: newOptions(opts...),
 		s:       s,
-		c:       GetConvertor(c),
 	}
+	brr.c = brr.getConvertor(c)
 	brr.logger = brr.logger.With(logger.Field{Key: "source", Value: s.Name()})
 	return brr
 }
@@ -94,6 +110,14 @@ func NewContinueError(err error) error {
 	}
 }
 
+// NewFailedRecordError is the same as NewContinueError, but the record is counted as failed, such as a malformed line.
+func NewFailedRecordError(err error, record spec.Record) error {
+	return &continueError{
+		Err:    err,
+		Record: record,
+	}
+}
+
 func (*NoneConvertor) Apply(s source.Source, values []string) (spec.Records, error) {
 	return spec.Records{values}, nil
 }
@@ -106,19 +130,30 @@ func (r *defaultBatchReader) Size() (int64, error) {
 	return r.rr.Size()
 }
 
-func (r *defaultBatchReader) ReadBatch() (int, spec.Records, error) {
+func (r *defaultBatchReader) ReadBatch(ctx context.Context) (int, spec.Records, error) {
 	var (
 		totalBytes int
 		records    = make(spec.Records, 0, r.batch)
 	)
+	r.failed = nil
 
 	for batch := 0; batch < r.batch; {
+		if err := ctx.Err(); err != nil {
+			// The records already read are returned, so that the progress and stats match what is consumed.
+			if totalBytes > 0 {
+				break
+			}
+			return 0, nil, err
+		}
 		n, record, err := r.rr.Read()
 		totalBytes += n
 		if err != nil {
 			// case1: Read continue error.
 			if ce := new(continueError); stderrors.As(err, &ce) {
 				r.logger.WithError(ce.Err).Error("read source failed")
+				if ce.Record != nil {
+					r.failed = append(r.failed, ce.Record)
+				}
 				continue
 			}
 
@@ -133,6 +168,11 @@ func (r *defaultBatchReader) ReadBatch() (int, spec.Records, error) {
 		batch++
 		result, err := r.c.Apply(r.Source(), record)
 		if err != nil {
+			if ce := new(continueError); stderrors.As(err, &ce) {
+				r.logger.WithError(ce.Err).Error("convert record failed")
+				r.failed = append(r.failed, record)
+				continue
+			}
 			return 0, nil, err
 		}
 		records = append(records, result...)
@@ -140,6 +180,15 @@ func (r *defaultBatchReader) ReadBatch() (int, spec.Records, error) {
 	return totalBytes, records, nil
 }
 
+func (r *defaultBatchReader) ConvertFailed() spec.Records {
+	return r.failed
+}
+
+// Close releases the convertor set by WithOwnedConvertor if it is an io.Closer, such as the WASM convertor.
+func (r *defaultBatchReader) Close() error {
+	return r.closeConvertor()
+}
+
 func (ce *continueError) Error() string {
 	return ce.Err.Error()
 }
@@ -160,19 +209,23 @@ func (r *sqlBatchReader) Size() (int64, error) {
 	return r.s.Size()
 }
 
-func (r *sqlBatchReader) ReadBatch() (n int, records spec.Records, err error) {
+func (r *sqlBatchReader) ReadBatch(ctx context.Context) (n int, records spec.Records, err error) {
 	querySql := r.s.BuildQuerySQL(r.lastId, r.batch)
 	r.logger.Debug(fmt.Sprintf("query sql: %s", querySql))
-	rows, err := r.s.Db.Query(querySql)
+	rows, err := r.s.Db.QueryContext(ctx, querySql)
 	if err != nil {
 		return 0, nil, err
 	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
 	cols, err := rows.Columns()
 	if err != nil {
 		r.logger.Error(fmt.Sprintf("query error: %s", err.Error()))
 		return 0, nil, err
 	}
 	var lastId string
+	r.failed = nil
 	for rows.Next() {
 		values := make([]interface{}, len(cols))
 		for i := range values {
@@ -199,17 +252,32 @@ func (r *sqlBatchReader) ReadBatch() (n int, records spec.Records, err error) {
 		lastId = vals[r.s.Config().SQL.DbTable.Id.Index]
 		result, err := r.c.Apply(r.Source(), vals)
 		if err != nil {
+			if ce := new(continueError); stderrors.As(err, &ce) {
+				r.logger.WithError(ce.Err).Error("convert record failed")
+				r.failed = append(r.failed, vals)
+				continue
+			}
 			return 0, nil, err
 		}
 		records = append(records, result...)
 	}
+	// The query may be interrupted, such as canceled by the ctx, then the rows already read are returned like above.
+	if err = rows.Err(); err != nil && (ctx.Err() == nil || n == 0) {
+		return 0, nil, err
+	}
 	r.lastId = lastId
-	defer func(rows *sql.Rows) {
-		_ = rows.Close()
-	}(rows)
 	if n == 0 {
 		r.logger.Debug("not found data")
 		return n, nil, io.EOF
 	}
 	return n, records, nil
 }
+
+func (r *sqlBatchReader) ConvertFailed() spec.Records {
+	return r.failed
+}
+
+// Close releases the convertor set by WithOwnedConvertor if it is an io.Closer, such as the WASM convertor.
+func (r *sqlBatchReader) Close() error {
+	return r.closeConvertor()
+}
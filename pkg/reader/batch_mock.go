@@ -5,6 +5,7 @@
 package reader
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -36,9 +37,9 @@ func (m *MockBatchRecordReader) EXPECT() *MockBatchRecordReaderMockRecorder {
 }
 
 // ReadBatch mocks base method.
-func (m *MockBatchRecordReader) ReadBatch() (int, spec.Records, error) {
+func (m *MockBatchRecordReader) ReadBatch(ctx context.Context) (int, spec.Records, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadBatch")
+	ret := m.ctrl.Call(m, "ReadBatch", ctx)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(spec.Records)
 	ret2, _ := ret[2].(error)
@@ -46,9 +47,9 @@ func (m *MockBatchRecordReader) ReadBatch() (int, spec.Records, error) {
 }
 
 // ReadBatch indicates an expected call of ReadBatch.
-func (mr *MockBatchRecordReaderMockRecorder) ReadBatch() *gomock.Call {
+func (mr *MockBatchRecordReaderMockRecorder) ReadBatch(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBatch", reflect.TypeOf((*MockBatchRecordReader)(nil).ReadBatch))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadBatch", reflect.TypeOf((*MockBatchRecordReader)(nil).ReadBatch), ctx)
 }
 
 // Size mocks base method.
@@ -79,3 +80,41 @@ func (mr *MockBatchRecordReaderMockRecorder) Source() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Source", reflect.TypeOf((*MockBatchRecordReader)(nil).Source))
 }
+
+// MockConvertor is a mock of Convertor interface.
+type MockConvertor struct {
+	ctrl     *gomock.Controller
+	recorder *MockConvertorMockRecorder
+}
+
+// MockConvertorMockRecorder is the mock recorder for MockConvertor.
+type MockConvertorMockRecorder struct {
+	mock *MockConvertor
+}
+
+// NewMockConvertor creates a new mock instance.
+func NewMockConvertor(ctrl *gomock.Controller) *MockConvertor {
+	mock := &MockConvertor{ctrl: ctrl}
+	mock.recorder = &MockConvertorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConvertor) EXPECT() *MockConvertorMockRecorder {
+	return m.recorder
+}
+
+// Apply mocks base method.
+func (m *MockConvertor) Apply(s source.Source, values []string) (spec.Records, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Apply", s, values)
+	ret0, _ := ret[0].(spec.Records)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Apply indicates an expected call of Apply.
+func (mr *MockConvertorMockRecorder) Apply(s, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Apply", reflect.TypeOf((*MockConvertor)(nil).Apply), s, values)
+}
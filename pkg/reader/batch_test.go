@@ -1,6 +1,7 @@
 package reader
 
 import (
+	"context"
 	stderrors "errors"
 	"io"
 
@@ -49,7 +50,7 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(33)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(33))
 			Expect(records).To(Equal([]spec.Record{
@@ -59,13 +60,41 @@ var _ = Describe("BatchRecordReader", func() {
 				{"10", " 11 ", " 12"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
 			Expect(records).To(BeEmpty())
 		})
 
+		It("canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			brr := NewBatchRecordReader(rr, "none", WithBatch(1))
+			n, records, err := brr.ReadBatch(ctx)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(n).To(Equal(0))
+			Expect(records).To(BeNil())
+		})
+
+		It("canceled in batch", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			brr := NewBatchRecordReader(rr, "none", WithBatch(4), WithConvertor(&cancelConvertor{cancel: cancel}))
+			n, records, err := brr.ReadBatch(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(6))
+			Expect(records).To(Equal(spec.Records{{"1", "2", "3"}}))
+
+			n, records, err = brr.ReadBatch(ctx)
+			Expect(stderrors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(n).To(Equal(0))
+			Expect(records).To(BeNil())
+		})
+
 		It("1 batch", func() {
 			var (
 				nBytes  int64
@@ -78,35 +107,35 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(33)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(6))
 			Expect(records).To(Equal([]spec.Record{
 				{"1", "2", "3"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(7))
 			Expect(records).To(Equal([]spec.Record{
 				{"4", " 5", "6"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(8))
 			Expect(records).To(Equal([]spec.Record{
 				{" 7", "8", " 9"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(12))
 			Expect(records).To(Equal([]spec.Record{
 				{"10", " 11 ", " 12"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
@@ -125,14 +154,14 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(33)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(13))
 			Expect(records).To(Equal([]spec.Record{
 				{"1", "2", "3"},
 				{"4", " 5", "6"},
 			}))
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(20))
 			Expect(records).To(Equal([]spec.Record{
@@ -140,7 +169,7 @@ var _ = Describe("BatchRecordReader", func() {
 				{"10", " 11 ", " 12"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
@@ -159,7 +188,7 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(33)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(21))
 			Expect(records).To(Equal([]spec.Record{
@@ -167,14 +196,14 @@ var _ = Describe("BatchRecordReader", func() {
 				{"4", " 5", "6"},
 				{" 7", "8", " 9"},
 			}))
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(12))
 			Expect(records).To(Equal([]spec.Record{
 				{"10", " 11 ", " 12"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
@@ -193,7 +222,7 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(33)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(33))
 			Expect(records).To(Equal([]spec.Record{
@@ -203,12 +232,43 @@ var _ = Describe("BatchRecordReader", func() {
 				{"10", " 11 ", " 12"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
 			Expect(records).To(BeEmpty())
 		})
+
+		It("skip convert failed", func() {
+			c, err := NewWasmConvertor("testdata/wasm/trap.wasm", 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			brr := NewBatchRecordReader(rr, "none", WithBatch(4), WithOwnedConvertor(c))
+			n, records, err := brr.ReadBatch(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(n).To(Equal(33))
+			Expect(records).To(BeEmpty())
+			Expect(brr.(ConvertFailedReader).ConvertFailed()).To(Equal(spec.Records{
+				{"1", "2", "3"},
+				{"4", " 5", "6"},
+				{" 7", "8", " 9"},
+				{"10", " 11 ", " 12"},
+			}))
+
+			// The convertor is closed with the reader.
+			Expect(brr.(io.Closer).Close()).NotTo(HaveOccurred())
+			Expect(c.closed).To(BeTrue())
+		})
+
+		It("shared convertor not closed", func() {
+			c, err := NewWasmConvertor("testdata/wasm/trap.wasm", 0)
+			Expect(err).NotTo(HaveOccurred())
+			defer c.Close()
+
+			brr := NewBatchRecordReader(rr, "none", WithConvertor(c))
+			Expect(brr.(io.Closer).Close()).NotTo(HaveOccurred())
+			Expect(c.closed).To(BeFalse())
+		})
 	})
 
 	When("failed", func() {
@@ -247,7 +307,7 @@ var _ = Describe("BatchRecordReader", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(nBytes).To(Equal(int64(16)))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(13))
 			Expect(records).To(Equal([]spec.Record{
@@ -255,14 +315,14 @@ var _ = Describe("BatchRecordReader", func() {
 				{"id3"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(n).To(Equal(3))
 			Expect(records).To(Equal([]spec.Record{
 				{"id4"},
 			}))
 
-			n, records, err = brr.ReadBatch()
+			n, records, err = brr.ReadBatch(context.Background())
 			Expect(err).To(HaveOccurred())
 			Expect(stderrors.Is(err, io.EOF)).To(BeTrue())
 			Expect(n).To(Equal(0))
@@ -280,3 +340,13 @@ var _ = Describe("continueError", func() {
 		Expect(pkgerrors.Cause(err)).To(Equal(baseErr))
 	})
 })
+
+// cancelConvertor cancels the ctx after converting the first record.
+type cancelConvertor struct {
+	cancel context.CancelFunc
+}
+
+func (c *cancelConvertor) Apply(_ source.Source, values []string) (spec.Records, error) {
+	c.cancel()
+	return spec.Records{values}, nil
+}
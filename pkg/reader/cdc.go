@@ -0,0 +1,244 @@
+package reader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/source"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+)
+
+const (
+	CDCFormatDebezium = "debezium"
+	CDCFormatCanal    = "canal"
+
+	// CDCOpColumn is the name of the first column, which holds the mode of the change.
+	CDCOpColumn = "__op"
+)
+
+type (
+	cdcReader struct {
+		*baseReader
+		rr      *remainingReader
+		br      *bufio.Reader
+		fields  []string
+		decode  func([]byte) ([]cdcChange, error)
+		pending []spec.Record
+	}
+
+	cdcChange struct {
+		mode  specbase.Mode
+		image map[string]any
+	}
+
+	debeziumEnvelope struct {
+		Op     string         `json:"op"`
+		Before map[string]any `json:"before"`
+		After  map[string]any `json:"after"`
+	}
+
+	canalEnvelope struct {
+		Type  string           `json:"type"`
+		IsDdl bool             `json:"isDdl"`
+		Data  []map[string]any `json:"data"`
+	}
+)
+
+// NewCDCReader reads the change data capture envelopes in JSON lines.
+// Each record is the mode of the change followed by the configured fields of the row image.
+func NewCDCReader(s source.Source) RecordReader {
+	rr := &remainingReader{Reader: s}
+	r := &cdcReader{
+		baseReader: &baseReader{
+			s: s,
+		},
+		rr: rr,
+		br: bufio.NewReader(rr),
+	}
+	if c := s.Config(); c != nil && c.CDC != nil {
+		r.fields = c.CDC.Fields
+		switch strings.ToLower(c.CDC.Format) {
+		case CDCFormatDebezium:
+			r.decode = decodeDebezium
+		case CDCFormatCanal:
+			r.decode = decodeCanal
+		}
+	}
+	return r
+}
+
+// ValidateCDCConfig checks the format and fields of the cdc config.
+func ValidateCDCConfig(c *source.CDCConfig) error {
+	switch strings.ToLower(c.Format) {
+	case CDCFormatDebezium, CDCFormatCanal:
+	default:
+		return errors.NewImportError(errors.ErrInvalidConfig, "unsupported cdc format %q", c.Format)
+	}
+	if len(c.Fields) == 0 {
+		return errors.NewImportError(errors.ErrInvalidConfig, "cdc fields is empty")
+	}
+	return nil
+}
+
+// CDCHeader returns the column names of the records read by the cdc reader.
+func CDCHeader(c *source.CDCConfig) []string {
+	header := make([]string, 0, len(c.Fields)+1)
+	header = append(header, CDCOpColumn)
+	return append(header, c.Fields...)
+}
+
+func (r *cdcReader) Size() (int64, error) {
+	return r.s.Size()
+}
+
+func (r *cdcReader) Read() (int, spec.Record, error) {
+	if len(r.pending) > 0 {
+		record := r.pending[0]
+		r.pending = r.pending[1:]
+		return 0, record, nil
+	}
+	if r.decode == nil {
+		return 0, nil, errors.ErrInvalidConfig
+	}
+
+	var nBytes int
+	for {
+		line, err := r.br.ReadBytes('\n')
+		nBytes += r.rr.Take(r.br.Buffered())
+		if err != nil && (err != io.EOF || len(line) == 0) {
+			return nBytes, nil, err
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		changes, decodeErr := r.decode(line)
+		if decodeErr != nil {
+			// The malformed envelope is counted as a failed record with the line as the only column.
+			record := spec.Record{string(line)}
+			return nBytes, nil, NewFailedRecordError(errors.AsOrNewImportError(decodeErr).SetRecord(record), record)
+		}
+		if len(changes) == 0 {
+			// tombstones, ddl and the other envelopes without row changes
+			continue
+		}
+
+		records := make([]spec.Record, 0, len(changes))
+		for _, c := range changes {
+			records = append(records, r.record(c))
+		}
+		r.pending = records[1:]
+		return nBytes, records[0], nil
+	}
+}
+
+func (r *cdcReader) record(c cdcChange) spec.Record {
+	record := make(spec.Record, 0, len(r.fields)+1)
+	record = append(record, string(c.mode))
+	for _, field := range r.fields {
+		record = append(record, cdcValue(c.image[field]))
+	}
+	return record
+}
+
+func decodeDebezium(line []byte) ([]cdcChange, error) {
+	var wrapper struct {
+		Schema  json.RawMessage `json:"schema"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(line, &wrapper); err != nil {
+		return nil, errors.NewImportError(err, "decode debezium envelope failed")
+	}
+	// The envelope is wrapped with schema when the json converter enables schemas.
+	if wrapper.Schema != nil || wrapper.Payload != nil {
+		line = wrapper.Payload
+	}
+	if len(line) == 0 || bytes.Equal(line, []byte("null")) {
+		return nil, nil
+	}
+
+	var envelope debeziumEnvelope
+	if err := unmarshalUseNumber(line, &envelope); err != nil {
+		return nil, errors.NewImportError(err, "decode debezium envelope failed")
+	}
+
+	var change cdcChange
+	switch envelope.Op {
+	case "c":
+		change = cdcChange{mode: specbase.InsertMode, image: envelope.After}
+	case "u":
+		change = cdcChange{mode: specbase.UpdateMode, image: envelope.After}
+	case "r":
+		change = cdcChange{mode: specbase.UpsertMode, image: envelope.After}
+	case "d":
+		change = cdcChange{mode: specbase.DeleteMode, image: envelope.Before}
+	case "t", "m":
+		// truncate and message envelopes carry no row.
+		return nil, nil
+	default:
+		return nil, errors.NewImportError(errors.ErrUnknownOperation, "unknown debezium op %q", envelope.Op)
+	}
+	if change.image == nil {
+		return nil, errors.NewImportError(errors.ErrNoRecord, "debezium op %q without row image", envelope.Op)
+	}
+	return []cdcChange{change}, nil
+}
+
+func decodeCanal(line []byte) ([]cdcChange, error) {
+	var envelope canalEnvelope
+	if err := unmarshalUseNumber(line, &envelope); err != nil {
+		return nil, errors.NewImportError(err, "decode canal envelope failed")
+	}
+	if envelope.IsDdl {
+		return nil, nil
+	}
+
+	var mode specbase.Mode
+	switch strings.ToUpper(envelope.Type) {
+	case "INSERT":
+		mode = specbase.InsertMode
+	case "UPDATE":
+		mode = specbase.UpdateMode
+	case "DELETE":
+		// The data of the delete message is the row before deleted.
+		mode = specbase.DeleteMode
+	default:
+		return nil, errors.NewImportError(errors.ErrUnknownOperation, "unknown canal type %q", envelope.Type)
+	}
+
+	changes := make([]cdcChange, 0, len(envelope.Data))
+	for _, image := range envelope.Data {
+		changes = append(changes, cdcChange{mode: mode, image: image})
+	}
+	return changes, nil
+}
+
+func unmarshalUseNumber(data []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func cdcValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case json.Number:
+		return val.String()
+	case bool:
+		return strconv.FormatBool(val)
+	default:
+		data, _ := json.Marshal(val)
+		return string(data)
+	}
+}
@@ -0,0 +1,148 @@
+package reader
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/source"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("cdcReader", func() {
+	var s source.Source
+	open := func(path string, c *source.CDCConfig) {
+		var err error
+		s, err = source.New(&source.Config{
+			Local: &source.LocalConfig{
+				Path: path,
+			},
+			CDC: c,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Open()).NotTo(HaveOccurred())
+	}
+	AfterEach(func() {
+		Expect(s.Close()).NotTo(HaveOccurred())
+	})
+
+	// readAll reads all the records, and returns the records, the failed ones of the continue errors and the total bytes.
+	readAll := func(r RecordReader) (records, failed []spec.Record, nBytes int) {
+		for {
+			n, record, err := r.Read()
+			nBytes += n
+			if err == io.EOF {
+				return records, failed, nBytes
+			}
+			if err != nil {
+				ce := new(continueError)
+				Expect(stderrors.As(err, &ce)).To(BeTrue())
+				failed = append(failed, ce.Record)
+				continue
+			}
+			records = append(records, record)
+		}
+	}
+
+	It("debezium", func() {
+		open("testdata/cdc/debezium.json", &source.CDCConfig{
+			Format: CDCFormatDebezium,
+			Fields: []string{"id", "name", "age", "vip", "tags", "missing"},
+		})
+		r := NewRecordReader(s)
+		Expect(r).To(BeAssignableToTypeOf(&cdcReader{}))
+		Expect(r.Source()).To(Equal(s))
+		size, err := r.Size()
+		Expect(err).NotTo(HaveOccurred())
+
+		records, failed, nBytes := readAll(r)
+		Expect(records).To(Equal([]spec.Record{
+			{"UPSERT", "1", "Tom", "18", "true", `["a","b"]`, ""},
+			{"INSERT", "2", "Jerry", "", "false", "", ""},
+			{"UPDATE", "2", "Jerry", "20", "false", "", ""},
+			{"DELETE", "1", "Tom", "18", "true", `["a","b"]`, ""},
+			{"INSERT", "3", "Spike", "3", "false", "", ""},
+		}))
+		// the unknown op and the broken line are failed with the lines
+		Expect(failed).To(Equal([]spec.Record{
+			{`{"before":null,"after":{"id":3},"op":"x"}`},
+			{`{"before":null,"after":`},
+		}))
+		Expect(int64(nBytes)).To(Equal(size))
+	})
+
+	It("canal", func() {
+		open("testdata/cdc/canal.json", &source.CDCConfig{
+			Format: CDCFormatCanal,
+			Fields: []string{"id", "name", "age"},
+		})
+		r := NewCDCReader(s)
+		size, err := r.Size()
+		Expect(err).NotTo(HaveOccurred())
+
+		records, failed, nBytes := readAll(r)
+		Expect(records).To(Equal([]spec.Record{
+			{"INSERT", "1", "Tom", "18"},
+			{"INSERT", "2", "Jerry", ""},
+			{"UPDATE", "2", "Jerry", "20"},
+			{"DELETE", "1", "Tom", "18"},
+		}))
+		// the unknown type
+		Expect(failed).To(HaveLen(1))
+		Expect(int64(nBytes)).To(Equal(size))
+	})
+
+	It("malformed envelopes in batch", func() {
+		open("testdata/cdc/debezium.json", &source.CDCConfig{
+			Format: CDCFormatDebezium,
+			Fields: []string{"id"},
+		})
+		brr := NewBatchRecordReader(NewRecordReader(s), "none", WithBatch(100))
+		size, err := brr.Size()
+		Expect(err).NotTo(HaveOccurred())
+
+		n, records, err := brr.ReadBatch(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(int64(n)).To(Equal(size))
+		Expect(records).To(HaveLen(5))
+		Expect(brr.(ConvertFailedReader).ConvertFailed()).To(Equal(spec.Records{
+			{`{"before":null,"after":{"id":3},"op":"x"}`},
+			{`{"before":null,"after":`},
+		}))
+	})
+
+	It("unsupported format", func() {
+		open("testdata/cdc/canal.json", &source.CDCConfig{
+			Format: "maxwell",
+			Fields: []string{"id"},
+		})
+		r := NewCDCReader(s)
+		n, record, err := r.Read()
+		Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+		Expect(n).To(Equal(0))
+		Expect(record).To(BeNil())
+	})
+})
+
+var _ = DescribeTable("ValidateCDCConfig",
+	func(c *source.CDCConfig, expectErr error) {
+		err := ValidateCDCConfig(c)
+		if expectErr == nil {
+			Expect(err).NotTo(HaveOccurred())
+		} else {
+			Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+		}
+	},
+	Entry("debezium", &source.CDCConfig{Format: "debezium", Fields: []string{"id"}}, nil),
+	Entry("canal", &source.CDCConfig{Format: "Canal", Fields: []string{"id"}}, nil),
+	Entry("unsupported format", &source.CDCConfig{Format: "maxwell", Fields: []string{"id"}}, errors.ErrInvalidConfig),
+	Entry("no fields", &source.CDCConfig{Format: "debezium"}, errors.ErrInvalidConfig),
+)
+
+var _ = It("CDCHeader", func() {
+	Expect(CDCHeader(&source.CDCConfig{Fields: []string{"id", "name"}})).To(Equal([]string{CDCOpColumn, "id", "name"}))
+})
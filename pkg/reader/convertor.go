@@ -0,0 +1,141 @@
+package reader
+
+import (
+	"strings"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/source"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+)
+
+const (
+	ConvertorNone      = "none"
+	ConvertorExplode   = "explode"
+	ConvertorCartesian = "cartesian"
+
+	DefaultConvertSeparator = "|"
+)
+
+var (
+	_ Convertor = (*NoneConvertor)(nil)
+	_ Convertor = (*ExplodeConvertor)(nil)
+
+	convertorFactories = map[string]ConvertorFactory{
+		ConvertorExplode:   newExplodeConvertor,
+		ConvertorCartesian: newCartesianConvertor,
+		ConvertorWasm:      newWasmConvertor,
+	}
+)
+
+type (
+	// ConvertOptions configures the built-in convertors.
+	ConvertOptions struct {
+		// The indices of the multi-valued columns.
+		Columns []int `yaml:"columns,omitempty" json:"columns,omitempty,optional"`
+		// The separator of the values in the columns, `|` by default.
+		Separator string `yaml:"separator,omitempty" json:"separator,omitempty,optional"`
+		// The path of the WASM module.
+		Module string `yaml:"module,omitempty" json:"module,omitempty,optional"`
+		// The timeout of each call to the WASM module, 1s by default.
+		Timeout time.Duration `yaml:"timeout,omitempty" json:"timeout,omitempty,optional"`
+	}
+
+	// ConvertorFactory builds the convertor with the options.
+	ConvertorFactory func(opts *ConvertOptions) (Convertor, error)
+
+	// ExplodeConvertor splits the multi-valued columns by the separator,
+	// and returns a record for each combination of the values, the other columns are kept.
+	// The record is dropped if any of the columns has no values.
+	ExplodeConvertor struct {
+		Columns   []int
+		Separator string
+	}
+)
+
+// NewConvertor returns the convertor by name, the built-in convertors are configured by the options,
+// and the others are got from the registry.
+func NewConvertor(name string, opts *ConvertOptions) (Convertor, error) {
+	factory, ok := convertorFactories[strings.ToLower(name)]
+	if !ok {
+		return GetConvertor(name), nil
+	}
+	if opts == nil {
+		opts = &ConvertOptions{}
+	}
+	return factory(opts)
+}
+
+// IsBuiltinConvertor reports whether the convertor of the name is built by NewConvertor for each call,
+// otherwise it is got from the registry and shared.
+func IsBuiltinConvertor(name string) bool {
+	_, ok := convertorFactories[strings.ToLower(name)]
+	return ok
+}
+
+func newExplodeConvertor(opts *ConvertOptions) (Convertor, error) {
+	if len(opts.Columns) != 1 {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig,
+			"convertor %s requires one column, got %v", ConvertorExplode, opts.Columns)
+	}
+	return newMultiValuedConvertor(opts)
+}
+
+func newCartesianConvertor(opts *ConvertOptions) (Convertor, error) {
+	if len(opts.Columns) < 2 {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig,
+			"convertor %s requires two or more columns, got %v", ConvertorCartesian, opts.Columns)
+	}
+	return newMultiValuedConvertor(opts)
+}
+
+func newMultiValuedConvertor(opts *ConvertOptions) (Convertor, error) {
+	for _, index := range opts.Columns {
+		if index < 0 {
+			return nil, errors.ErrInvalidIndex
+		}
+	}
+	separator := opts.Separator
+	if separator == "" {
+		separator = DefaultConvertSeparator
+	}
+	return &ExplodeConvertor{
+		Columns:   opts.Columns,
+		Separator: separator,
+	}, nil
+}
+
+func (c *ExplodeConvertor) Apply(_ source.Source, values []string) (spec.Records, error) {
+	records := spec.Records{values}
+	for _, index := range c.Columns {
+		if index >= len(values) {
+			// Keep the record, it fails on picking the column later.
+			return spec.Records{values}, nil
+		}
+		items := c.split(values[index])
+		exploded := make(spec.Records, 0, len(records)*len(items))
+		for _, record := range records {
+			for _, item := range items {
+				cpy := make(spec.Record, len(record))
+				copy(cpy, record)
+				cpy[index] = item
+				exploded = append(exploded, cpy)
+			}
+		}
+		records = exploded
+	}
+	return records, nil
+}
+
+// split returns the non-empty values.
+func (c *ExplodeConvertor) split(s string) []string {
+	items := strings.Split(s, c.Separator)
+	n := 0
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			items[n] = item
+			n++
+		}
+	}
+	return items[:n]
+}
@@ -0,0 +1,79 @@
+package reader
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Convertor", func() {
+	DescribeTable("NewConvertor",
+		func(name string, opts *ConvertOptions, values []string, expectRecords spec.Records) {
+			c, err := NewConvertor(name, opts)
+			Expect(err).NotTo(HaveOccurred())
+			records, err := c.Apply(nil, values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal(expectRecords))
+		},
+		Entry("none", "none", nil, []string{"1", "a|b"}, spec.Records{{"1", "a|b"}}),
+		Entry("unknown", "unknown", nil, []string{"1", "a|b"}, spec.Records{{"1", "a|b"}}),
+		Entry("explode", "explode", &ConvertOptions{Columns: []int{1}},
+			[]string{"1", "tag1|tag2|tag3", "x"},
+			spec.Records{{"1", "tag1", "x"}, {"1", "tag2", "x"}, {"1", "tag3", "x"}}),
+		Entry("explode separator", "EXPLODE", &ConvertOptions{Columns: []int{0}, Separator: ";"},
+			[]string{"a; b;;", "x"},
+			spec.Records{{"a", "x"}, {"b", "x"}}),
+		Entry("explode single", "explode", &ConvertOptions{Columns: []int{0}},
+			[]string{"a", "x"},
+			spec.Records{{"a", "x"}}),
+		Entry("explode empty", "explode", &ConvertOptions{Columns: []int{0}},
+			[]string{"", "x"},
+			spec.Records{}),
+		Entry("explode no column", "explode", &ConvertOptions{Columns: []int{2}},
+			[]string{"a|b", "x"},
+			spec.Records{{"a|b", "x"}}),
+		Entry("cartesian", "cartesian", &ConvertOptions{Columns: []int{0, 2}},
+			[]string{"a|b", "x", "1|2"},
+			spec.Records{{"a", "x", "1"}, {"a", "x", "2"}, {"b", "x", "1"}, {"b", "x", "2"}}),
+		Entry("cartesian empty", "cartesian", &ConvertOptions{Columns: []int{0, 1}},
+			[]string{"a|b", ""},
+			spec.Records{}),
+	)
+
+	DescribeTable("NewConvertor failed",
+		func(name string, opts *ConvertOptions, expectErr error) {
+			c, err := NewConvertor(name, opts)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			Expect(c).To(BeNil())
+		},
+		Entry("explode no options", "explode", nil, errors.ErrInvalidConfig),
+		Entry("explode two columns", "explode", &ConvertOptions{Columns: []int{0, 1}}, errors.ErrInvalidConfig),
+		Entry("explode invalid index", "explode", &ConvertOptions{Columns: []int{-1}}, errors.ErrInvalidIndex),
+		Entry("cartesian one column", "cartesian", &ConvertOptions{Columns: []int{0}}, errors.ErrInvalidConfig),
+	)
+
+	It("does not modify the values", func() {
+		c, err := NewConvertor("explode", &ConvertOptions{Columns: []int{0}})
+		Expect(err).NotTo(HaveOccurred())
+		values := []string{"a|b", "x"}
+		_, err = c.Apply(nil, values)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(values).To(Equal([]string{"a|b", "x"}))
+	})
+})
+
+var _ = DescribeTable("IsBuiltinConvertor",
+	func(name string, expected bool) {
+		Expect(IsBuiltinConvertor(name)).To(Equal(expected))
+	},
+	Entry("explode", "explode", true),
+	Entry("cartesian", "Cartesian", true),
+	Entry("wasm", "wasm", true),
+	Entry("none", ConvertorNone, false),
+	Entry("registered", "my-convertor", false),
+)
@@ -0,0 +1,217 @@
+package reader
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/source"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	ConvertorWasm = "wasm"
+
+	DefaultWasmTimeout = time.Second
+
+	// The memory of the module is limited to 64 MiB.
+	wasmMemoryLimitPages = 1024
+)
+
+var _ Convertor = (*WasmConvertor)(nil)
+
+// WasmConvertor calls the WASM module to convert the records.
+//
+// The module runs in a pure-Go runtime without any access to the files, network or environment,
+// and exports the following functions besides `memory`:
+//
+//	alloc(size i32) -> ptr i32: Allocates the memory to write the input.
+//	convert(ptr i32, len i32) -> i64: Converts the input, which is the JSON array of the values such as `["1","a|b"]`,
+//	    and returns the output `ptr << 32 | len`, which is the JSON array of the records such as `[["1","a"],["1","b"]]`.
+//	free(ptr i32, len i32): Optional. Frees the input and the output after the call.
+//
+// Each call takes an idle instance of the module, or instantiates a new one, so that the calls run concurrently.
+// The instance is dropped after a failed call, such as timed out, trapped or invalid output.
+type WasmConvertor struct {
+	timeout time.Duration
+	runtime wazero.Runtime
+	code    wazero.CompiledModule
+
+	mu     sync.Mutex
+	idle   []api.Module
+	closed bool
+}
+
+func newWasmConvertor(opts *ConvertOptions) (Convertor, error) {
+	if opts.Module == "" {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "convertor %s requires the module", ConvertorWasm)
+	}
+	return NewWasmConvertor(opts.Module, opts.Timeout)
+}
+
+// NewWasmConvertor compiles the WASM module at the path, the timeout applies to each call.
+func NewWasmConvertor(path string, timeout time.Duration) (*WasmConvertor, error) {
+	if timeout <= 0 {
+		timeout = DefaultWasmTimeout
+	}
+	binary, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.NewImportError(err, "read wasm module %s failed", path)
+	}
+
+	ctx := context.Background()
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCloseOnContextDone(true).
+		WithMemoryLimitPages(wasmMemoryLimitPages),
+	)
+	// Some toolchains, such as TinyGo and Rust, import WASI even if it is not used.
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, r); err != nil {
+		_ = r.Close(ctx)
+		return nil, errors.NewImportError(err, "instantiate wasi failed")
+	}
+	code, err := r.CompileModule(ctx, binary)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "compile wasm module %s failed: %s", path, err)
+	}
+	for _, name := range []string{"alloc", "convert"} {
+		if _, ok := code.ExportedFunctions()[name]; !ok {
+			_ = r.Close(ctx)
+			return nil, errors.NewImportError(errors.ErrInvalidConfig, "wasm module %s does not export %s", path, name)
+		}
+	}
+
+	c := &WasmConvertor{
+		timeout: timeout,
+		runtime: r,
+		code:    code,
+	}
+	// Instantiate once to fail fast, such as the unsatisfied imports.
+	mod, err := c.instantiate(ctx)
+	if err != nil {
+		_ = r.Close(ctx)
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "instantiate wasm module %s failed: %s", path, err)
+	}
+	c.idle = append(c.idle, mod)
+	return c, nil
+}
+
+func (c *WasmConvertor) Apply(_ source.Source, values []string) (spec.Records, error) {
+	input, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	mod, err := c.get(ctx)
+	if err != nil {
+		return nil, NewContinueError(errors.NewImportError(err, "instantiate wasm module failed").SetRecord(values))
+	}
+
+	records, err := c.call(ctx, mod, input)
+	if err != nil {
+		// The instance may be closed or in a bad state.
+		_ = mod.Close(context.Background())
+		return nil, NewContinueError(errors.NewImportError(err, "call wasm module failed").SetRecord(values))
+	}
+	c.put(mod)
+	return records, nil
+}
+
+// Close releases the runtime and all the instances of the module, it is closed with the reader.
+func (c *WasmConvertor) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	c.idle = nil
+	c.mu.Unlock()
+	return c.runtime.Close(context.Background())
+}
+
+// get takes an idle instance, or instantiates a new one if none.
+func (c *WasmConvertor) get(ctx context.Context) (api.Module, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "wasm convertor closed")
+	}
+	if n := len(c.idle); n > 0 {
+		mod := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return mod, nil
+	}
+	c.mu.Unlock()
+	return c.instantiate(ctx)
+}
+
+// put returns the instance to be reused by the subsequent calls.
+func (c *WasmConvertor) put(mod api.Module) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		_ = mod.Close(context.Background())
+		return
+	}
+	c.idle = append(c.idle, mod)
+}
+
+func (c *WasmConvertor) instantiate(ctx context.Context) (api.Module, error) {
+	// No name, so that the module can be instantiated many times. `_initialize` is called for the reactors.
+	return c.runtime.InstantiateModule(ctx, c.code, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"),
+	)
+}
+
+func (*WasmConvertor) call(ctx context.Context, mod api.Module, input []byte) (spec.Records, error) {
+	mem := mod.Memory()
+	if mem == nil {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "no memory exported")
+	}
+
+	results, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, err
+	}
+	inputPtr := uint32(results[0])
+	if !mem.Write(inputPtr, input) {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "alloc out of memory")
+	}
+
+	if results, err = mod.ExportedFunction("convert").Call(ctx, uint64(inputPtr), uint64(len(input))); err != nil {
+		return nil, err
+	}
+	outputPtr, outputLen := uint32(results[0]>>32), uint32(results[0])
+	output, ok := mem.Read(outputPtr, outputLen)
+	if !ok {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "output out of memory")
+	}
+
+	var records spec.Records
+	if err = json.Unmarshal(output, &records); err != nil {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "invalid output %q: %s", output, err)
+	}
+
+	if free := mod.ExportedFunction("free"); free != nil {
+		if _, err = free.Call(ctx, uint64(inputPtr), uint64(len(input))); err != nil {
+			return nil, err
+		}
+		if _, err = free.Call(ctx, uint64(outputPtr), uint64(outputLen)); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
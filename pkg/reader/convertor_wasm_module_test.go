@@ -0,0 +1,108 @@
+package reader
+
+import (
+	stderrors "errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/spec"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WasmConvertor", func() {
+	It("successfully", func() {
+		c, err := NewConvertor("wasm", &ConvertOptions{Module: "testdata/wasm/echo.wasm"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c).NotTo(BeNil())
+		defer func() {
+			Expect(c.(*WasmConvertor).Close()).NotTo(HaveOccurred())
+		}()
+
+		for _, values := range [][]string{{"1", "a|b"}, {"2", `"quoted"`}, {}} {
+			records, err := c.Apply(nil, values)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal(spec.Records{values}))
+		}
+	})
+
+	It("concurrency", func() {
+		c, err := NewWasmConvertor("testdata/wasm/echo.wasm", 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				values := []string{strconv.Itoa(i)}
+				records, err := c.Apply(nil, values)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(Equal(spec.Records{values}))
+			}(i)
+		}
+		wg.Wait()
+		Expect(c.idle).NotTo(BeEmpty())
+
+		Expect(c.Close()).NotTo(HaveOccurred())
+		Expect(c.Close()).NotTo(HaveOccurred())
+		records, err := c.Apply(nil, []string{"1"})
+		Expect(err).To(HaveOccurred())
+		Expect(records).To(BeNil())
+	})
+
+	It("timeout", func() {
+		c, err := NewWasmConvertor("testdata/wasm/loop.wasm", 10*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+		defer c.Close()
+
+		for i := 0; i < 2; i++ {
+			records, err := c.Apply(nil, []string{"1"})
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.As(err, new(*continueError))).To(BeTrue())
+			Expect(records).To(BeNil())
+		}
+	})
+
+	It("trap", func() {
+		c, err := NewWasmConvertor("testdata/wasm/trap.wasm", 0)
+		Expect(err).NotTo(HaveOccurred())
+		defer c.Close()
+
+		records, err := c.Apply(nil, []string{"1"})
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.As(err, new(*continueError))).To(BeTrue())
+		Expect(records).To(BeNil())
+	})
+
+	DescribeTable("failed",
+		func(module string, expectErr error) {
+			c, err := NewConvertor("wasm", &ConvertOptions{Module: module})
+			Expect(err).To(HaveOccurred())
+			if expectErr != nil {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+			Expect(c).To(BeNil())
+		},
+		Entry("no module", "", errors.ErrInvalidConfig),
+		Entry("not exists", "testdata/wasm/not-exists.wasm", nil),
+		Entry("invalid module", "testdata/local.csv", errors.ErrInvalidConfig),
+	)
+
+	It("no exports", func() {
+		// The empty module with only the magic and version.
+		module := filepath.Join(GinkgoT().TempDir(), "empty.wasm")
+		Expect(os.WriteFile(module, []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}, 0o600)).NotTo(HaveOccurred())
+
+		c, err := NewWasmConvertor(module, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+		Expect(c).To(BeNil())
+	})
+})
@@ -6,6 +6,7 @@ import (
 	stderrors "errors"
 	"io"
 
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
 	"github.com/lucky-xin/nebula-importer/pkg/spec"
 )
@@ -59,6 +60,20 @@ func NewCSVReader(s source.Source) RecordReader {
 	}
 }
 
+// ReadCSVHeader reads the header of the opened csv source.
+func ReadCSVHeader(s source.Source) ([]string, error) {
+	r := NewCSVReader(s).(*csvReader)
+	if !r.h.withHeader {
+		return nil, errors.ErrNoHeader
+	}
+	r.h.hasRead = true
+	header, err := r.cr.Read()
+	if err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
 func (r *csvReader) Size() (int64, error) {
 	return r.s.Size()
 }
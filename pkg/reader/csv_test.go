@@ -4,6 +4,7 @@ import (
 	stderrors "errors"
 	"io"
 
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
 	"github.com/lucky-xin/nebula-importer/pkg/spec"
 
@@ -179,6 +180,20 @@ var _ = Describe("csvReader", func() {
 			Expect(n).To(Equal(0))
 			Expect(record).To(BeEmpty())
 		})
+
+		It("read header", func() {
+			header, err := ReadCSVHeader(s)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(header).To(Equal([]string{"h1", "h2", "h3"}))
+		})
+
+		It("read header without header", func() {
+			s.Config().CSV.WithHeader = false
+			header, err := ReadCSVHeader(s)
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrNoHeader)).To(BeTrue())
+			Expect(header).To(BeNil())
+		})
 	})
 
 	Describe("withHeader read failed", func() {
@@ -1,6 +1,8 @@
 package reader
 
 import (
+	"io"
+
 	"github.com/lucky-xin/nebula-importer/pkg/logger"
 )
 
@@ -12,8 +14,11 @@ type (
 	Option func(*options)
 
 	options struct {
-		batch  int
-		logger logger.Logger
+		batch     int
+		logger    logger.Logger
+		convertor Convertor
+		// ownConvertor reports whether the convertor is owned by the reader, and closed with the reader.
+		ownConvertor bool
 	}
 )
 
@@ -29,6 +34,24 @@ func WithLogger(l logger.Logger) Option {
 	}
 }
 
+// WithConvertor sets the convertor, which takes precedence over the convertor by name.
+// It may be shared, such as the registered ones, so it is never closed by the reader.
+func WithConvertor(c Convertor) Option {
+	return func(m *options) {
+		m.convertor = c
+		m.ownConvertor = false
+	}
+}
+
+// WithOwnedConvertor is the same as WithConvertor, but the convertor is owned by the reader,
+// and closed with the reader if it is an io.Closer, such as the WASM convertor built by NewConvertor.
+func WithOwnedConvertor(c Convertor) Option {
+	return func(m *options) {
+		m.convertor = c
+		m.ownConvertor = true
+	}
+}
+
 func newOptions(opts ...Option) *options {
 	var defaultOptions = &options{
 		batch: DefaultBatchSize,
@@ -52,3 +75,21 @@ func (o *options) withOptions(opts ...Option) {
 		o.logger = logger.NopLogger
 	}
 }
+
+// closeConvertor closes the convertor set by WithOwnedConvertor, the others may be shared and are never closed.
+func (o *options) closeConvertor() error {
+	if !o.ownConvertor {
+		return nil
+	}
+	if c, ok := o.convertor.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (o *options) getConvertor(name string) Convertor {
+	if o.convertor != nil {
+		return o.convertor
+	}
+	return GetConvertor(name)
+}
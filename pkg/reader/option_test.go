@@ -24,4 +24,18 @@ var _ = Describe("Option", func() {
 		Expect(o.batch).To(Equal(100))
 		Expect(o.logger).NotTo(BeNil())
 	})
+
+	It("getConvertor", func() {
+		o := newOptions()
+		Expect(o.getConvertor("none")).To(Equal(&NoneConvertor{}))
+
+		c := &ExplodeConvertor{Columns: []int{0}, Separator: "|"}
+		o = newOptions(WithConvertor(c))
+		Expect(o.getConvertor("none")).To(BeIdenticalTo(c))
+		Expect(o.ownConvertor).To(BeFalse())
+
+		o = newOptions(WithOwnedConvertor(c))
+		Expect(o.getConvertor("none")).To(BeIdenticalTo(c))
+		Expect(o.ownConvertor).To(BeTrue())
+	})
 })
@@ -15,5 +15,8 @@ type (
 )
 
 func NewRecordReader(s source.Source) RecordReader {
+	if c := s.Config(); c != nil && c.CDC != nil {
+		return NewCDCReader(s)
+	}
 	return NewCSVReader(s)
 }
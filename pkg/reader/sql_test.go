@@ -1,6 +1,7 @@
 package reader
 
 import (
+	"context"
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/lucky-xin/nebula-importer/pkg/source"
 	"testing"
@@ -54,8 +55,8 @@ func TestSQLSource(t *testing.T) {
 		t.Fatal(err)
 	}
 	reader := NewSQLBatchRecordReader(sou.(*source.SQLSource), "none", WithBatch(10))
-	n, record, err := reader.ReadBatch()
-	n, record, err = reader.ReadBatch()
+	n, record, err := reader.ReadBatch(context.Background())
+	n, record, err = reader.ReadBatch(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
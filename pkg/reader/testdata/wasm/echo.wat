@@ -0,0 +1,42 @@
+;; echo.wasm returns the input record as the only output record.
+;; alloc reserves one more byte before and after the input,
+;; so convert wraps the input `["a","b"]` in place to `[["a","b"]]`.
+(module
+  (memory (export "memory") 1)
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $n i32) (result i32)
+    (local $p i32)
+    global.get $next
+    local.set $p
+    global.get $next
+    local.get $n
+    i32.add
+    i32.const 2
+    i32.add
+    global.set $next
+    local.get $p
+    i32.const 1
+    i32.add)
+  (func (export "convert") (param $p i32) (param $n i32) (result i64)
+    local.get $p
+    i32.const 1
+    i32.sub
+    i32.const 91 ;; [
+    i32.store8
+    local.get $p
+    local.get $n
+    i32.add
+    i32.const 93 ;; ]
+    i32.store8
+    ;; (p - 1) << 32 | (n + 2)
+    local.get $p
+    i32.const 1
+    i32.sub
+    i64.extend_i32_u
+    i64.const 32
+    i64.shl
+    local.get $n
+    i32.const 2
+    i32.add
+    i64.extend_i32_u
+    i64.or))
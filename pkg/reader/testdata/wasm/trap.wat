@@ -0,0 +1,19 @@
+;; trap.wasm traps in convert, the alloc is the same as echo.wat.
+(module
+  (memory (export "memory") 1)
+  (global $next (mut i32) (i32.const 1024))
+  (func (export "alloc") (param $n i32) (result i32)
+    (local $p i32)
+    global.get $next
+    local.set $p
+    global.get $next
+    local.get $n
+    i32.add
+    i32.const 2
+    i32.add
+    global.set $next
+    local.get $p
+    i32.const 1
+    i32.add)
+  (func (export "convert") (param $p i32) (param $n i32) (result i64)
+    unreachable))
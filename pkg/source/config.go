@@ -12,6 +12,7 @@ type (
 		SQL   *SQLConfig   `yaml:"sql,omitempty" json:"sql,omitempty,optional"`
 		// The following is format information
 		CSV *CSVConfig `yaml:"csv,omitempty" json:"csv,omitempty,optional"`
+		CDC *CDCConfig `yaml:"cdc,omitempty" json:"cdc,omitempty,optional"`
 	}
 
 	CSVConfig struct {
@@ -20,6 +21,14 @@ type (
 		WithHeader bool   `yaml:"withHeader,omitempty" json:"withHeader,omitempty,optional"`
 		LazyQuotes bool   `yaml:"lazyQuotes,omitempty" json:"lazyQuotes,omitempty,optional"`
 	}
+
+	// CDCConfig reads the change data capture envelopes in JSON lines, such as Debezium or Canal.
+	CDCConfig struct {
+		// The format of the envelopes, `debezium` or `canal`.
+		Format string `yaml:"format" json:"format"`
+		// The fields of the row images read as the columns after the operation.
+		Fields []string `yaml:"fields" json:"fields"`
+	}
 )
 
 func (c *Config) Clone() *Config {
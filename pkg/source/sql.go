@@ -134,6 +134,18 @@ func (s *SQLSource) Size() (total int64, err error) {
 	return
 }
 
+// Columns returns the column names of the query.
+func (s *SQLSource) Columns() ([]string, error) {
+	rows, err := s.Db.Query(s.BuildQuerySQL("", 0))
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		_ = rows.Close()
+	}(rows)
+	return rows.Columns()
+}
+
 func (s *SQLSource) Read(p []byte) (int, error) {
 	return 2, nil
 }
@@ -8,6 +8,46 @@ type (
 	}
 
 	StatementBuilderFunc func(records ...Record) (statement string, nRecord int, err error)
+
+	// DedupStatementBuilder is the StatementBuilder which skips the records seen in the previous builds.
+	DedupStatementBuilder interface {
+		StatementBuilder
+		// BuildDedup is the same as Build, and also returns the number of the records skipped as duplicates.
+		BuildDedup(records ...Record) (statement string, nRecord, nDuplicate int, err error)
+		// Forget forgets the records, so that they can be built again, such as the statement failed to execute.
+		Forget(records ...Record) error
+	}
+
+	// VerifyStatementBuilder is the StatementBuilder which verifies the records by a query before building,
+	// such as the endpoints of the edges exist.
+	VerifyStatementBuilder interface {
+		StatementBuilder
+		// BuildVerify builds the query which yields the values of the columns to verify the records, empty if no need.
+		BuildVerify(records ...Record) (query string, columns []string, err error)
+		// BuildVerified is the same as Build with the values of the columns yielded by the query, in the order of the columns,
+		// and also returns the number of the records skipped as unverified.
+		BuildVerified(values [][]string, records ...Record) (statement string, nRecord, nUnverified int, err error)
+	}
+
+	// VersionStatementBuilder is the StatementBuilder which finds the stale records by a query before building,
+	// such as the records not newer than the existing ones.
+	VersionStatementBuilder interface {
+		StatementBuilder
+		// BuildStaleQuery builds the query which yields the keys and versions of the existing ones in the columns, empty if no need.
+		BuildStaleQuery(records ...Record) (query string, columns []string, err error)
+		// BuildFresh is the same as Build with the values of the columns yielded by the query, in the order of the columns,
+		// but skips the records not newer than the existing ones, and also returns the number of them as stale.
+		BuildFresh(existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error)
+	}
+
+	// ParamStatementBuilder is the StatementBuilder which binds the values to the params instead of the literals,
+	// so that the statement is executed with the params.
+	ParamStatementBuilder interface {
+		StatementBuilder
+		// BuildParam is the same as Build, and returns the params referred as `$name` in the statement,
+		// and also the number of the records skipped as duplicates.
+		BuildParam(records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error)
+	}
 )
 
 func (f StatementBuilderFunc) Build(records ...Record) (statement string, nRecord int, err error) {
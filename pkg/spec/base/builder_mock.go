@@ -52,3 +52,320 @@ func (mr *MockStatementBuilderMockRecorder) Build(records ...interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockStatementBuilder)(nil).Build), records...)
 }
+
+// MockDedupStatementBuilder is a mock of DedupStatementBuilder interface.
+type MockDedupStatementBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockDedupStatementBuilderMockRecorder
+}
+
+// MockDedupStatementBuilderMockRecorder is the mock recorder for MockDedupStatementBuilder.
+type MockDedupStatementBuilderMockRecorder struct {
+	mock *MockDedupStatementBuilder
+}
+
+// NewMockDedupStatementBuilder creates a new mock instance.
+func NewMockDedupStatementBuilder(ctrl *gomock.Controller) *MockDedupStatementBuilder {
+	mock := &MockDedupStatementBuilder{ctrl: ctrl}
+	mock.recorder = &MockDedupStatementBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDedupStatementBuilder) EXPECT() *MockDedupStatementBuilderMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockDedupStatementBuilder) Build(records ...Record) (string, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Build", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockDedupStatementBuilderMockRecorder) Build(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockDedupStatementBuilder)(nil).Build), records...)
+}
+
+// BuildDedup mocks base method.
+func (m *MockDedupStatementBuilder) BuildDedup(records ...Record) (string, int, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildDedup", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// BuildDedup indicates an expected call of BuildDedup.
+func (mr *MockDedupStatementBuilderMockRecorder) BuildDedup(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildDedup", reflect.TypeOf((*MockDedupStatementBuilder)(nil).BuildDedup), records...)
+}
+
+// Forget mocks base method.
+func (m *MockDedupStatementBuilder) Forget(records ...Record) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Forget", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockDedupStatementBuilderMockRecorder) Forget(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockDedupStatementBuilder)(nil).Forget), records...)
+}
+
+// MockVerifyStatementBuilder is a mock of VerifyStatementBuilder interface.
+type MockVerifyStatementBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockVerifyStatementBuilderMockRecorder
+}
+
+// MockVerifyStatementBuilderMockRecorder is the mock recorder for MockVerifyStatementBuilder.
+type MockVerifyStatementBuilderMockRecorder struct {
+	mock *MockVerifyStatementBuilder
+}
+
+// NewMockVerifyStatementBuilder creates a new mock instance.
+func NewMockVerifyStatementBuilder(ctrl *gomock.Controller) *MockVerifyStatementBuilder {
+	mock := &MockVerifyStatementBuilder{ctrl: ctrl}
+	mock.recorder = &MockVerifyStatementBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVerifyStatementBuilder) EXPECT() *MockVerifyStatementBuilderMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockVerifyStatementBuilder) Build(records ...Record) (string, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Build", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockVerifyStatementBuilderMockRecorder) Build(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockVerifyStatementBuilder)(nil).Build), records...)
+}
+
+// BuildVerified mocks base method.
+func (m *MockVerifyStatementBuilder) BuildVerified(values [][]string, records ...Record) (string, int, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{values}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildVerified", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// BuildVerified indicates an expected call of BuildVerified.
+func (mr *MockVerifyStatementBuilderMockRecorder) BuildVerified(values interface{}, records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{values}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildVerified", reflect.TypeOf((*MockVerifyStatementBuilder)(nil).BuildVerified), varargs...)
+}
+
+// BuildVerify mocks base method.
+func (m *MockVerifyStatementBuilder) BuildVerify(records ...Record) (string, []string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildVerify", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BuildVerify indicates an expected call of BuildVerify.
+func (mr *MockVerifyStatementBuilderMockRecorder) BuildVerify(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildVerify", reflect.TypeOf((*MockVerifyStatementBuilder)(nil).BuildVerify), records...)
+}
+
+// MockVersionStatementBuilder is a mock of VersionStatementBuilder interface.
+type MockVersionStatementBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionStatementBuilderMockRecorder
+}
+
+// MockVersionStatementBuilderMockRecorder is the mock recorder for MockVersionStatementBuilder.
+type MockVersionStatementBuilderMockRecorder struct {
+	mock *MockVersionStatementBuilder
+}
+
+// NewMockVersionStatementBuilder creates a new mock instance.
+func NewMockVersionStatementBuilder(ctrl *gomock.Controller) *MockVersionStatementBuilder {
+	mock := &MockVersionStatementBuilder{ctrl: ctrl}
+	mock.recorder = &MockVersionStatementBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionStatementBuilder) EXPECT() *MockVersionStatementBuilderMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockVersionStatementBuilder) Build(records ...Record) (string, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Build", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockVersionStatementBuilderMockRecorder) Build(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockVersionStatementBuilder)(nil).Build), records...)
+}
+
+// BuildFresh mocks base method.
+func (m *MockVersionStatementBuilder) BuildFresh(existing [][]string, records ...Record) (string, int, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{existing}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildFresh", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+// BuildFresh indicates an expected call of BuildFresh.
+func (mr *MockVersionStatementBuilderMockRecorder) BuildFresh(existing interface{}, records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{existing}, records...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildFresh", reflect.TypeOf((*MockVersionStatementBuilder)(nil).BuildFresh), varargs...)
+}
+
+// BuildStaleQuery mocks base method.
+func (m *MockVersionStatementBuilder) BuildStaleQuery(records ...Record) (string, []string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildStaleQuery", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].([]string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BuildStaleQuery indicates an expected call of BuildStaleQuery.
+func (mr *MockVersionStatementBuilderMockRecorder) BuildStaleQuery(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildStaleQuery", reflect.TypeOf((*MockVersionStatementBuilder)(nil).BuildStaleQuery), records...)
+}
+
+// MockParamStatementBuilder is a mock of ParamStatementBuilder interface.
+type MockParamStatementBuilder struct {
+	ctrl     *gomock.Controller
+	recorder *MockParamStatementBuilderMockRecorder
+}
+
+// MockParamStatementBuilderMockRecorder is the mock recorder for MockParamStatementBuilder.
+type MockParamStatementBuilderMockRecorder struct {
+	mock *MockParamStatementBuilder
+}
+
+// NewMockParamStatementBuilder creates a new mock instance.
+func NewMockParamStatementBuilder(ctrl *gomock.Controller) *MockParamStatementBuilder {
+	mock := &MockParamStatementBuilder{ctrl: ctrl}
+	mock.recorder = &MockParamStatementBuilderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockParamStatementBuilder) EXPECT() *MockParamStatementBuilderMockRecorder {
+	return m.recorder
+}
+
+// Build mocks base method.
+func (m *MockParamStatementBuilder) Build(records ...Record) (string, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Build", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Build indicates an expected call of Build.
+func (mr *MockParamStatementBuilderMockRecorder) Build(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockParamStatementBuilder)(nil).Build), records...)
+}
+
+// BuildParam mocks base method.
+func (m *MockParamStatementBuilder) BuildParam(records ...Record) (string, map[string]any, int, int, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range records {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BuildParam", varargs...)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(map[string]any)
+	ret2, _ := ret[2].(int)
+	ret3, _ := ret[3].(int)
+	ret4, _ := ret[4].(error)
+	return ret0, ret1, ret2, ret3, ret4
+}
+
+// BuildParam indicates an expected call of BuildParam.
+func (mr *MockParamStatementBuilderMockRecorder) BuildParam(records ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildParam", reflect.TypeOf((*MockParamStatementBuilder)(nil).BuildParam), records...)
+}
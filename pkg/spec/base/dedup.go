@@ -0,0 +1,59 @@
+package specbase
+
+import (
+	"github.com/lucky-xin/nebula-importer/pkg/dedup"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+
+	"github.com/dustin/go-humanize"
+)
+
+type (
+	// Dedup skips the records whose keys have been seen in the import, such as the same VID of a tag.
+	Dedup struct {
+		// The memory budget of the keys, such as `64MiB`, 64MiB by default.
+		MaxMemory string `yaml:"maxMemory,omitempty" json:"maxMemory,omitempty,optional"`
+		// Keep the keys on disk when the memory budget is exhausted, otherwise the keys beyond the budget are not deduplicated.
+		Spill bool `yaml:"spill,omitempty" json:"spill,omitempty,optional"`
+
+		set dedup.Set
+	}
+)
+
+// Build builds the set of the keys, it does nothing if already built, so the copies of the Dedup share the same set.
+func (d *Dedup) Build() error {
+	if d.set != nil {
+		return nil
+	}
+	maxMemory := int64(dedup.DefaultMaxMemory)
+	if d.MaxMemory != "" {
+		v, err := humanize.ParseBytes(d.MaxMemory)
+		if err != nil || v == 0 {
+			return errors.NewImportError(errors.ErrInvalidConfig, "invalid dedup maxMemory %q", d.MaxMemory)
+		}
+		maxMemory = int64(v)
+	}
+	d.set = dedup.New(dedup.WithMaxMemory(maxMemory), dedup.WithSpill(d.Spill))
+	return nil
+}
+
+// IsDuplicate remembers the key, and reports whether it has been seen before.
+func (d *Dedup) IsDuplicate(key string) (bool, error) {
+	added, err := d.set.Add(key)
+	if err != nil {
+		return false, err
+	}
+	return !added, nil
+}
+
+// Forget forgets the keys, so that they are not duplicates any more.
+func (d *Dedup) Forget(keys ...string) error {
+	return d.set.Remove(keys...)
+}
+
+// Close closes the set of the keys, such as removes the spilled file.
+func (d *Dedup) Close() error {
+	if d.set == nil {
+		return nil
+	}
+	return d.set.Close()
+}
@@ -1,20 +1,41 @@
 package specbase
 
 import (
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
 )
 
-type Filter struct {
-	Expr    string `yaml:"expr,omitempty" json:"expr,omitempty"`
-	program *vm.Program
+type (
+	Filter struct {
+		Expr    string `yaml:"expr,omitempty" json:"expr,omitempty"`
+		program *vm.Program
+	}
+
+	FilterOption func(*filterOptions)
+
+	filterOptions struct {
+		lookups picker.Lookups
+	}
+)
+
+// WithFilterLookups sets the lookups which are available as `lookup(name, key)` in the expression.
+func WithFilterLookups(lookups picker.Lookups) FilterOption {
+	return func(o *filterOptions) {
+		o.lookups = lookups
+	}
 }
 
-func (f *Filter) Build() error {
+func (f *Filter) Build(opts ...FilterOption) error {
+	var o filterOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
 	var env = map[string]any{
 		"Record": Record{},
 	}
-	program, err := expr.Compile(f.Expr, expr.Env(env), expr.AsBool())
+	program, err := picker.CompileExpr(f.Expr, o.lookups, expr.Env(env), expr.AsBool())
 	if err != nil {
 		return err
 	}
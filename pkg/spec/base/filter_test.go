@@ -1,6 +1,8 @@
 package specbase
 
 import (
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
@@ -35,4 +37,32 @@ var _ = Describe("Filter", func() {
 		Expect(err).NotTo(HaveOccurred())
 		Expect(ok).To(BeTrue())
 	})
+
+	It("with lookups", func() {
+		f := Filter{
+			Expr: `lookup("users", Record[0]) != nil`,
+		}
+		err := f.Build()
+		Expect(err).To(HaveOccurred())
+
+		err = f.Build(WithFilterLookups(picker.Lookups{"users": testLookup{"1": "Tom"}}))
+		Expect(err).NotTo(HaveOccurred())
+
+		ok, err := f.Filter(Record{"1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+
+		ok, err = f.Filter(Record{"2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
 })
+
+type testLookup map[string]string
+
+func (l testLookup) Lookup(key string) (any, error) {
+	if v, ok := l[key]; ok {
+		return v, nil
+	}
+	return nil, nil
+}
@@ -3,8 +3,12 @@ package spec
 import specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 
 type (
-	Record               = specbase.Record
-	Records              = specbase.Records
-	StatementBuilder     = specbase.StatementBuilder
-	StatementBuilderFunc = specbase.StatementBuilderFunc
+	Record                  = specbase.Record
+	Records                 = specbase.Records
+	StatementBuilder        = specbase.StatementBuilder
+	StatementBuilderFunc    = specbase.StatementBuilderFunc
+	DedupStatementBuilder   = specbase.DedupStatementBuilder
+	VerifyStatementBuilder  = specbase.VerifyStatementBuilder
+	VersionStatementBuilder = specbase.VersionStatementBuilder
+	ParamStatementBuilder   = specbase.ParamStatementBuilder
 )
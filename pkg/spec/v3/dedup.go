@@ -0,0 +1,63 @@
+package specv3
+
+import (
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+)
+
+// validateDedup checks that all the records are written in one INSERT or UPSERT mode. The keys do not tell the modes,
+// so the UPDATE or DELETE of a key seen before, such as in a change feed routed by the opColumn, would be skipped.
+func validateDedup(modes []specbase.Mode) error {
+	if len(modes) != 1 || (modes[0] != specbase.InsertMode && modes[0] != specbase.UpsertMode) {
+		return errors.NewImportError(errors.ErrInvalidConfig, "the dedup works only in one INSERT or UPSERT mode")
+	}
+	return nil
+}
+
+// dedupRecords returns the records which pass the filter and are not seen before by the keys,
+// and the number of the records skipped as duplicates.
+func dedupRecords(
+	d *specbase.Dedup,
+	filter *specbase.Filter,
+	fnKey func(record Record) (string, error),
+	records []Record,
+) (deduped []Record, nDuplicate int, err error) {
+	deduped = make([]Record, 0, len(records))
+	for _, record := range records {
+		// The records filtered out must not be remembered, they are skipped here directly.
+		if filter != nil {
+			ok, err := filter.Filter(record)
+			if err != nil {
+				return nil, 0, err
+			}
+			if !ok {
+				continue
+			}
+		}
+		key, err := fnKey(record)
+		if err != nil {
+			return nil, 0, err
+		}
+		isDuplicate, err := d.IsDuplicate(key)
+		if err != nil {
+			return nil, 0, err
+		}
+		if isDuplicate {
+			nDuplicate++
+			continue
+		}
+		deduped = append(deduped, record)
+	}
+	return deduped, nDuplicate, nil
+}
+
+// forgetRecords forgets the keys of the records, the records whose keys can not be got are ignored.
+func forgetRecords(d *specbase.Dedup, fnKey func(record Record) (string, error), records []Record) error {
+	keys := make([]string, 0, len(records))
+	for _, record := range records {
+		if key, err := fnKey(record); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return d.Forget(keys...)
+}
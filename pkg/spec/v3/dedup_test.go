@@ -0,0 +1,172 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Dedup", func() {
+	It("node", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			WithNodeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 1}),
+			WithNodeFilter(&specbase.Filter{Expr: `Record[1] != "A"`}),
+			WithNodeDedup(&specbase.Dedup{}),
+			WithNodeMode(specbase.InsertMode),
+		)
+		node.Complete()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		// The record filtered out is not remembered.
+		statement, nRecord, nDuplicate, err := node.StatementDedup(
+			[]string{"1", "A"}, []string{"1", "B"}, []string{"2", "C"}, []string{"1", "D"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(nDuplicate).To(Equal(1))
+		Expect(statement).To(HaveSuffix("`name`(`prop1`) VALUES 1:(\"B\"), 2:(\"C\")"))
+
+		statement, nRecord, err = node.Statement([]string{"2", "E"}, []string{"3", "F"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(HaveSuffix("VALUES 3:(\"F\")"))
+
+		statement, nRecord, nDuplicate, err = node.StatementDedup([]string{"1", "B"}, []string{"3", "F"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nDuplicate).To(Equal(2))
+		Expect(statement).To(Equal(""))
+
+		// The clones share the remembered VIDs.
+		cpy := node.Clone()
+		cpy.Complete()
+		Expect(cpy.Validate()).NotTo(HaveOccurred())
+		_, nRecord, nDuplicate, err = cpy.StatementDedup([]string{"2", "C"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nDuplicate).To(Equal(1))
+
+		Expect(node.Forget([]string{"1", "B"}, []string{})).NotTo(HaveOccurred())
+		statement, nRecord, nDuplicate, err = node.StatementDedup([]string{"1", "B"}, []string{"2", "C"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nDuplicate).To(Equal(1))
+		Expect(statement).To(HaveSuffix("VALUES 1:(\"B\")"))
+
+		_, _, _, err = node.StatementDedup([]string{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("edge", func() {
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+			WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+			WithRank(&Rank{Index: 2}),
+			WithEdgeDedup(&specbase.Dedup{MaxMemory: "1MiB", Spill: true}),
+			WithEdgeMode(specbase.InsertMode),
+		)
+		edge.Complete()
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, nDuplicate, err := edge.StatementDedup(
+			[]string{"1", "2", "0"}, []string{"1", "2", "1"}, []string{"1", "2", "0"}, []string{"2", "1", "0"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(3))
+		Expect(nDuplicate).To(Equal(1))
+		Expect(statement).To(HaveSuffix("`name`() VALUES 1->2@0:(), 1->2@1:(), 2->1@0:()"))
+
+		Expect(edge.Forget([]string{"1", "2", "1"})).NotTo(HaveOccurred())
+		_, nRecord, nDuplicate, err = edge.StatementDedup([]string{"1", "2", "0"}, []string{"1", "2", "1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nDuplicate).To(Equal(1))
+	})
+
+	It("invalid maxMemory", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			WithNodeDedup(&specbase.Dedup{MaxMemory: "unknown"}),
+		)
+		node.Complete()
+		err := node.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidConfig)).To(BeTrue())
+	})
+
+	DescribeTable("modes",
+		func(opts []NodeOption, expectErr error) {
+			node := NewNode(
+				"name",
+				append([]NodeOption{
+					WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+					WithNodeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 1}),
+					WithNodeDedup(&specbase.Dedup{}),
+				}, opts...)...,
+			)
+			node.Complete()
+			err := node.Validate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("INSERT", []NodeOption{WithNodeMode(specbase.InsertMode)}, nil),
+		Entry("UPSERT", []NodeOption{WithNodeMode(specbase.UpsertMode)}, nil),
+		Entry("UPDATE", []NodeOption{WithNodeMode(specbase.UpdateMode)}, errors.ErrInvalidConfig),
+		Entry("DELETE", []NodeOption{WithNodeMode(specbase.DeleteMode)}, errors.ErrInvalidConfig),
+		Entry("opColumn of INSERT", []NodeOption{
+			WithNodeOpColumn(&OpColumn{Mapping: map[string]specbase.Mode{"c": "insert", "r": "insert"}}),
+		}, nil),
+		Entry("opColumn of INSERT and DELETE", []NodeOption{
+			WithNodeOpColumn(&OpColumn{Mapping: map[string]specbase.Mode{"c": "insert", "d": "delete"}}),
+		}, errors.ErrInvalidConfig),
+		Entry("default opColumn", []NodeOption{WithNodeOpColumn(&OpColumn{})}, errors.ErrInvalidConfig),
+	)
+
+	It("statement builder", func() {
+		node := NewNode("node",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			WithNodeMode(specbase.InsertMode),
+		)
+		dedupNode := NewNode("dedupNode",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			WithNodeDedup(&specbase.Dedup{}),
+			WithNodeMode(specbase.InsertMode),
+		)
+		graph := NewGraph("graph", WithGraphNodes(node, dedupNode))
+		graph.Complete()
+		Expect(graph.Validate()).NotTo(HaveOccurred())
+
+		_, ok := graph.NodeStatementBuilder(node).(specbase.DedupStatementBuilder)
+		Expect(ok).To(BeFalse())
+
+		b, ok := graph.NodeStatementBuilder(dedupNode).(specbase.DedupStatementBuilder)
+		Expect(ok).To(BeTrue())
+		_, nRecord, err := b.Build([]string{"1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		_, nRecord, nDuplicate, err := b.BuildDedup([]string{"1"}, []string{"2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nDuplicate).To(Equal(1))
+		Expect(b.Forget([]string{"1"})).NotTo(HaveOccurred())
+
+		_, _, _, err = b.BuildDedup([]string{})
+		Expect(err).To(HaveOccurred())
+		importError, ok := errors.AsImportError(err)
+		Expect(ok).To(BeTrue())
+		Expect(importError.GraphName()).To(Equal("graph"))
+		Expect(importError.NodeName()).To(Equal("dedupNode"))
+	})
+})
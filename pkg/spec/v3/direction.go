@@ -0,0 +1,102 @@
+package specv3
+
+import (
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+)
+
+const (
+	// DirectionForward writes the edge from src to dst, it is the default direction.
+	DirectionForward = "forward"
+	// DirectionReverse writes the edge from dst to src.
+	DirectionReverse = "reverse"
+	// DirectionBoth writes the edges of both directions, such as for the symmetric relationships.
+	DirectionBoth = "both"
+)
+
+var (
+	// The directions of the edges written for each record, true for the reverse one.
+	forwardOnly    = []bool{false}
+	reverseOnly    = []bool{true}
+	bothDirections = []bool{false, true}
+)
+
+func (e *Edge) validateDirection() error {
+	switch e.Direction {
+	case "", DirectionForward:
+		if e.ReverseName != "" {
+			return errors.NewImportError(errors.ErrInvalidConfig,
+				"the reverseName works only with the direction %s or %s", DirectionReverse, DirectionBoth)
+		}
+	case DirectionReverse, DirectionBoth:
+	default:
+		return errors.NewImportError(errors.ErrUnsupportedEdgeDirection, "unsupported direction %q", e.Direction)
+	}
+	return nil
+}
+
+// reverseName returns the name of the reverse edges.
+func (e *Edge) reverseName() string {
+	if e.ReverseName != "" {
+		return e.ReverseName
+	}
+	return e.Name
+}
+
+// modeStatement returns the function to build the statement in the mode, nil if the mode is not supported.
+func (e *Edge) modeStatement(mode specbase.Mode) statementFunc {
+	switch e.Direction {
+	case DirectionReverse:
+		return e.directionStatement(mode, e.reverseName(), reverseOnly)
+	case DirectionBoth:
+		if e.reverseName() == e.Name {
+			// Both the directions are written in one statement.
+			return e.directionStatement(mode, e.Name, bothDirections)
+		}
+		return joinStatements(
+			e.directionStatement(mode, e.Name, forwardOnly),
+			e.directionStatement(mode, e.reverseName(), reverseOnly),
+		)
+	default:
+		return e.directionStatement(mode, e.Name, forwardOnly)
+	}
+}
+
+// joinStatements returns the function to build the statements of the functions one by one, nil if any one is nil.
+func joinStatements(fns ...statementFunc) statementFunc {
+	for _, fn := range fns {
+		if fn == nil {
+			return nil
+		}
+	}
+	return func(b *paramBinder, records ...Record) (string, int, error) {
+		var (
+			statements []string
+			nRecord    int
+		)
+		for _, fn := range fns {
+			s, n, err := fn(b, records...)
+			if err != nil {
+				return "", 0, err
+			}
+			if n > 0 {
+				statements = append(statements, strings.TrimSuffix(s, ";"))
+				nRecord += n
+			}
+		}
+		if nRecord == 0 {
+			return "", 0, nil
+		}
+		return strings.Join(statements, ";") + ";", nRecord, nil
+	}
+}
+
+// endpointIDValues returns the IDs of the src and dst of the record, which are swapped for the reverse edge.
+func endpointIDValues(srcIDValue, dstIDValue string, reverse bool) (string, string) {
+	if reverse {
+		return dstIDValue, srcIDValue
+	}
+	return srcIDValue, dstIDValue
+}
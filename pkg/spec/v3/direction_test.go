@@ -0,0 +1,106 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Direction", func() {
+	newEdge := func(direction, reverseName string, opts ...EdgeOption) *Edge {
+		edge := NewEdge(
+			"knows",
+			append([]EdgeOption{
+				WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+				WithRank(&Rank{Index: 2}),
+				WithEdgeProps(&Prop{Name: "since", Type: ValueTypeInt, Index: 3}),
+				WithEdgeMode(specbase.InsertMode),
+				WithEdgeDirection(direction, reverseName),
+			}, opts...)...,
+		)
+		edge.Complete()
+		return edge
+	}
+	records := []Record{
+		{"1", "2", "0", "2020"},
+		{"2", "3", "1", "2021"},
+	}
+
+	DescribeTable(".Validate",
+		func(edge *Edge, expectErr error) {
+			err := edge.Validate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("default", newEdge("", ""), nil),
+		Entry("forward", newEdge(DirectionForward, ""), nil),
+		Entry("reverse", newEdge(DirectionReverse, "knownBy"), nil),
+		Entry("both", newEdge(DirectionBoth, ""), nil),
+		Entry("unsupported", newEdge("left", ""), errors.ErrUnsupportedEdgeDirection),
+		Entry("reverseName with forward", newEdge(DirectionForward, "knownBy"), errors.ErrInvalidConfig),
+	)
+
+	DescribeTable(".Statement",
+		func(edge *Edge, expectStatement string, expectRecord int) {
+			Expect(edge.Validate()).NotTo(HaveOccurred())
+			statement, nRecord, err := edge.Statement(records...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nRecord).To(Equal(expectRecord))
+			Expect(statement).To(Equal(expectStatement))
+		},
+		Entry("INSERT forward",
+			newEdge(DirectionForward, ""),
+			"INSERT EDGE `knows`(`since`) VALUES 1->2@0:(2020), 2->3@1:(2021)", 2),
+		Entry("INSERT reverse",
+			newEdge(DirectionReverse, ""),
+			"INSERT EDGE `knows`(`since`) VALUES 2->1@0:(2020), 3->2@1:(2021)", 2),
+		Entry("INSERT reverse with reverseName",
+			newEdge(DirectionReverse, "knownBy"),
+			"INSERT EDGE `knownBy`(`since`) VALUES 2->1@0:(2020), 3->2@1:(2021)", 2),
+		Entry("INSERT both",
+			newEdge(DirectionBoth, ""),
+			"INSERT EDGE `knows`(`since`) VALUES 1->2@0:(2020), 2->1@0:(2020), 2->3@1:(2021), 3->2@1:(2021)", 4),
+		Entry("INSERT both with reverseName",
+			newEdge(DirectionBoth, "knownBy"),
+			"INSERT EDGE `knows`(`since`) VALUES 1->2@0:(2020), 2->3@1:(2021);"+
+				"INSERT EDGE `knownBy`(`since`) VALUES 2->1@0:(2020), 3->2@1:(2021);", 4),
+		Entry("UPDATE both",
+			newEdge(DirectionBoth, "", WithEdgeMode(specbase.UpdateMode)),
+			"UPDATE EDGE ON `knows` 1->2@0 SET `since` = 2020;UPDATE EDGE ON `knows` 2->1@0 SET `since` = 2020;"+
+				"UPDATE EDGE ON `knows` 2->3@1 SET `since` = 2021;UPDATE EDGE ON `knows` 3->2@1 SET `since` = 2021;", 4),
+		Entry("UPSERT both with reverseName",
+			newEdge(DirectionBoth, "knownBy", WithEdgeMode(specbase.UpsertMode)),
+			"UPSERT EDGE ON `knows` 1->2@0 SET `since` = 2020;UPSERT EDGE ON `knows` 2->3@1 SET `since` = 2021;"+
+				"UPSERT EDGE ON `knownBy` 2->1@0 SET `since` = 2020;UPSERT EDGE ON `knownBy` 3->2@1 SET `since` = 2021;", 4),
+		Entry("DELETE both",
+			newEdge(DirectionBoth, "", WithEdgeMode(specbase.DeleteMode)),
+			"DELETE EDGE `knows` 1->2@0, 2->1@0, 2->3@1, 3->2@1", 4),
+		Entry("DELETE reverse",
+			newEdge(DirectionReverse, "knownBy", WithEdgeMode(specbase.DeleteMode)),
+			"DELETE EDGE `knownBy` 2->1@0, 3->2@1", 2),
+	)
+
+	It("both with filter", func() {
+		edge := newEdge(DirectionBoth, "knownBy", WithEdgeFilter(&specbase.Filter{Expr: `Record[0] == "1"`}))
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement(records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT EDGE `knows`(`since`) VALUES 1->2@0:(2020);" +
+			"INSERT EDGE `knownBy`(`since`) VALUES 2->1@0:(2020);"))
+
+		statement, nRecord, err = edge.Statement(Record{"3", "4", "0", "2022"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(BeEmpty())
+	})
+})
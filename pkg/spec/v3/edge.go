@@ -6,6 +6,7 @@ import (
 
 	"github.com/lucky-xin/nebula-importer/pkg/bytebufferpool"
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
 	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 	"github.com/lucky-xin/nebula-importer/pkg/utils"
 )
@@ -24,18 +25,43 @@ type (
 
 		Filter *specbase.Filter `yaml:"filter,omitempty" json:"filter,omitempty,optional"`
 
+		Dedup *specbase.Dedup `yaml:"dedup,omitempty" json:"dedup,omitempty,optional"`
+
 		Mode specbase.Mode `yaml:"mode,omitempty" json:"mode,omitempty,optional,default=insert"`
 
-		fnStatement func(records ...Record) (string, int, error)
-		// "INSERT EDGE name(prop_name, ..., prop_name) VALUES "
-		// "UPDATE EDGE ON name "
-		// "DELETE EDGE name "
-		statementPrefix string
+		// UpsertStrategy is how the UPSERT mode builds the statement, see UpsertStrategyStatement and UpsertStrategyInsert.
+		UpsertStrategy string `yaml:"upsertStrategy,omitempty" json:"upsertStrategy,omitempty,optional"`
+
+		// OpColumn routes each record to the mode by its value, and the Mode is ignored.
+		OpColumn *OpColumn `yaml:"opColumn,omitempty" json:"opColumn,omitempty,optional"`
+
+		// Endpoints is the policy of the src and dst vertices, see EndpointsIgnore, EndpointsCreateStub and EndpointsVerify.
+		Endpoints string `yaml:"endpoints,omitempty" json:"endpoints,omitempty,optional"`
+
+		// Direction is which edges are written for each record, see DirectionForward, DirectionReverse and DirectionBoth.
+		Direction string `yaml:"direction,omitempty" json:"direction,omitempty,optional"`
+
+		// ReverseName is the name of the reverse edges, which defaults to the Name.
+		ReverseName string `yaml:"reverseName,omitempty" json:"reverseName,omitempty,optional"`
+
+		// VersionProp is the prop of the version or timestamp, the UPSERT and UPDATE modes apply only if the record is newer.
+		VersionProp string `yaml:"versionProp,omitempty" json:"versionProp,omitempty,optional"`
+
+		// Parameterized binds the prop values to the params of the statement instead of the literals.
+		Parameterized bool `yaml:"parameterized,omitempty" json:"parameterized,omitempty,optional"`
+
+		versionProp      *Prop
+		header           Header
+		lookups          picker.Lookups
+		fnStatement      statementFunc
+		fnModeStatements map[specbase.Mode]statementFunc
 	}
 
 	EdgeNodeRef struct {
 		Name string  `yaml:"-" json:"-"`
 		ID   *NodeID `yaml:"id" json:"id"`
+		// Tag is the tag of the vertex, which is required by the endpoints policies except ignore.
+		Tag string `yaml:"tag,omitempty" json:"tag,omitempty,optional"`
 	}
 
 	Edges []*Edge
@@ -88,6 +114,13 @@ func WithEdgeFilter(f *specbase.Filter) EdgeOption {
 	}
 }
 
+// WithEdgeDedup skips the records whose src, dst and rank have been seen.
+func WithEdgeDedup(d *specbase.Dedup) EdgeOption {
+	return func(e *Edge) {
+		e.Dedup = d
+	}
+}
+
 func WithEdgeMode(m specbase.Mode) EdgeOption {
 	return func(e *Edge) {
 		e.Mode = m
@@ -101,6 +134,96 @@ func (e *Edge) Options(opts ...EdgeOption) *Edge {
 	return e
 }
 
+// WithEdgeUpsertStrategy sets how the UPSERT mode builds the statement.
+func WithEdgeUpsertStrategy(strategy string) EdgeOption {
+	return func(e *Edge) {
+		e.UpsertStrategy = strategy
+	}
+}
+
+// WithEdgeEndpoints sets the policy of the src and dst vertices.
+func WithEdgeEndpoints(policy string) EdgeOption {
+	return func(e *Edge) {
+		e.Endpoints = policy
+	}
+}
+
+// WithEdgeDirection sets which edges are written for each record, and the name of the reverse edges if not empty.
+func WithEdgeDirection(direction, reverseName string) EdgeOption {
+	return func(e *Edge) {
+		e.Direction = direction
+		e.ReverseName = reverseName
+	}
+}
+
+// WithEdgeVersionProp sets the prop of the version, which guards the UPSERT and UPDATE modes.
+func WithEdgeVersionProp(name string) EdgeOption {
+	return func(e *Edge) {
+		e.VersionProp = name
+	}
+}
+
+// WithEdgeParameterized binds the prop values to the params of the statement.
+func WithEdgeParameterized(parameterized bool) EdgeOption {
+	return func(e *Edge) {
+		e.Parameterized = parameterized
+	}
+}
+
+// WithEdgeOpColumn routes each record to the mode by the value of the column.
+func WithEdgeOpColumn(c *OpColumn) EdgeOption {
+	return func(e *Edge) {
+		e.OpColumn = c
+	}
+}
+
+// WithEdgeLookups sets the lookups which are available as `lookup(name, key)` in the expressions.
+func WithEdgeLookups(lookups picker.Lookups) EdgeOption {
+	return func(e *Edge) {
+		e.lookups = lookups
+	}
+}
+
+// WithEdgeHeader sets the header to resolve the column names.
+func WithEdgeHeader(header Header) EdgeOption {
+	return func(e *Edge) {
+		e.header = header
+	}
+}
+
+// Clone returns a copy of the edge, which can be completed and validated separately, such as with another header.
+func (e *Edge) Clone() *Edge {
+	cpy := *e
+	if e.Src != nil {
+		cpy.Src = e.Src.Clone()
+	}
+	if e.Dst != nil {
+		cpy.Dst = e.Dst.Clone()
+	}
+	if e.Rank != nil {
+		cpy.Rank = e.Rank.Clone()
+	}
+	cpy.Props = e.Props.Clone()
+	if e.Filter != nil {
+		filter := *e.Filter
+		cpy.Filter = &filter
+	}
+	if e.OpColumn != nil {
+		cpy.OpColumn = e.OpColumn.Clone()
+	}
+	// The Dedup is shared, so that the duplicates are skipped across the copies.
+	return &cpy
+}
+
+// HasColumnNames reports whether the edge addresses the columns by name.
+func (e *Edge) HasColumnNames() bool {
+	return (e.Src != nil && e.Src.HasColumnNames()) ||
+		(e.Dst != nil && e.Dst.HasColumnNames()) ||
+		(e.Rank != nil && e.Rank.HasColumnNames()) ||
+		(e.OpColumn != nil && e.OpColumn.HasColumnNames()) ||
+		e.Props.HasColumnNames()
+}
+
 //nolint:dupl
 func (e *Edge) Complete() {
 	if e.Src != nil {
@@ -122,35 +245,82 @@ func (e *Edge) Complete() {
 	}
 	e.Props.Complete()
 	e.Mode = e.Mode.Convert()
+	e.fnStatement = e.modeStatement(e.Mode)
 
-	switch e.Mode {
+	if e.OpColumn != nil {
+		e.OpColumn.Complete()
+		e.fnModeStatements = make(map[specbase.Mode]statementFunc)
+		for _, mode := range e.OpColumn.Modes() {
+			e.fnModeStatements[mode] = e.modeStatement(mode)
+		}
+		e.fnStatement = func(b *paramBinder, records ...Record) (string, int, error) {
+			statement, nRecord, err := opStatement(e.OpColumn, e.fnModeStatements, b, records...)
+			if err != nil {
+				return "", 0, e.importError(err)
+			}
+			return statement, nRecord, nil
+		}
+	}
+}
+
+// directionStatement returns the function to build the statement of the edge name in the mode and the directions,
+// nil if the mode is not supported.
+func (e *Edge) directionStatement(
+	mode specbase.Mode,
+	name string,
+	reversed []bool,
+) statementFunc {
+	// "INSERT EDGE name(prop_name, ..., prop_name) VALUES "
+	// "UPDATE EDGE ON name "
+	// "DELETE EDGE name "
+	var (
+		fn     func(prefix string, reversed []bool, b *paramBinder, records ...Record) (string, int, error)
+		prefix string
+	)
+	switch mode {
 	case specbase.InsertMode:
-		e.fnStatement = e.insertStatement
-		// default enable IGNORE_EXISTED_INDEX
-		prefix := "INSERT EDGE"
-		if e.IgnoreExistedIndex != nil && *e.IgnoreExistedIndex {
-			prefix = "INSERT EDGE IGNORE_EXISTED_INDEX"
-		}
-		if e.IgnoreExistedRecord != nil && *e.IgnoreExistedRecord {
-			prefix = prefix + " IF NOT EXISTS"
-		}
-		insertPrefixFmt := "%s %s(%s) VALUES "
-		e.statementPrefix = fmt.Sprintf(
-			insertPrefixFmt,
-			prefix,
-			utils.ConvertIdentifier(e.Name),
-			strings.Join(e.Props.NameList(), ", "),
-		)
+		fn = e.insertStatement
+		prefix = e.insertPrefix(name, e.IgnoreExistedRecord != nil && *e.IgnoreExistedRecord)
 	case specbase.UpsertMode:
-		e.fnStatement = e.updateStatement
-		e.statementPrefix = fmt.Sprintf("UPSERT EDGE ON %s ", utils.ConvertIdentifier(e.Name))
+		if e.UpsertStrategy == UpsertStrategyInsert {
+			// The INSERT without IF NOT EXISTS overwrites the existing ones.
+			fn = e.insertStatement
+			prefix = e.insertPrefix(name, false)
+			break
+		}
+		fn = e.updateStatement
+		prefix = fmt.Sprintf("UPSERT EDGE ON %s ", utils.ConvertIdentifier(name))
 	case specbase.UpdateMode:
-		e.fnStatement = e.updateStatement
-		e.statementPrefix = fmt.Sprintf("UPDATE EDGE ON %s ", utils.ConvertIdentifier(e.Name))
+		fn = e.updateStatement
+		prefix = fmt.Sprintf("UPDATE EDGE ON %s ", utils.ConvertIdentifier(name))
 	case specbase.DeleteMode:
-		e.fnStatement = e.deleteStatement
-		e.statementPrefix = fmt.Sprintf("DELETE EDGE %s ", utils.ConvertIdentifier(e.Name))
+		fn = e.deleteStatement
+		prefix = fmt.Sprintf("DELETE EDGE %s ", utils.ConvertIdentifier(name))
+	default:
+		return nil
 	}
+	return func(b *paramBinder, records ...Record) (string, int, error) {
+		return fn(prefix, reversed, b, records...)
+	}
+}
+
+// insertPrefix returns "INSERT EDGE name(prop_name, ..., prop_name) VALUES ".
+func (e *Edge) insertPrefix(name string, ifNotExists bool) string {
+	// default enable IGNORE_EXISTED_INDEX
+	prefix := "INSERT EDGE"
+	if e.IgnoreExistedIndex != nil && *e.IgnoreExistedIndex {
+		prefix = "INSERT EDGE IGNORE_EXISTED_INDEX"
+	}
+	if ifNotExists {
+		prefix = prefix + " IF NOT EXISTS"
+	}
+	insertPrefixFmt := "%s %s(%s) VALUES "
+	return fmt.Sprintf(
+		insertPrefixFmt,
+		prefix,
+		utils.ConvertIdentifier(name),
+		strings.Join(e.Props.NameList(), ", "),
+	)
 }
 
 func (e *Edge) Validate() error {
@@ -158,10 +328,31 @@ func (e *Edge) Validate() error {
 		return e.importError(errors.ErrNoEdgeName)
 	}
 
+	if err := e.Props.resolveColumns(e.header); err != nil {
+		return e.importError(err)
+	}
+
+	if e.OpColumn != nil {
+		if err := e.OpColumn.resolveColumns(e.header); err != nil {
+			return e.importError(err)
+		}
+		if err := e.OpColumn.Validate(); err != nil {
+			return e.importError(err)
+		}
+	}
+
+	// The header columns and the props picked by name are available in the value expressions.
+	columns := e.Props.columns(e.header)
+	e.Props.setExprEnv(columns, e.lookups)
+
 	if e.Src == nil {
 		return e.importError(errors.ErrNoEdgeSrc)
 	}
 
+	if err := e.Src.resolveColumns(e.header); err != nil {
+		return e.importError(err)
+	}
+	e.Src.setExprEnv(columns, e.lookups)
 	if err := e.Src.Validate(); err != nil {
 		return e.importError(err)
 	}
@@ -170,11 +361,20 @@ func (e *Edge) Validate() error {
 		return e.importError(errors.ErrNoEdgeDst)
 	}
 
+	if err := e.Dst.resolveColumns(e.header); err != nil {
+		return e.importError(err)
+	}
+	e.Dst.setExprEnv(columns, e.lookups)
 	if err := e.Dst.Validate(); err != nil {
 		return e.importError(err)
 	}
 
 	if e.Rank != nil {
+		if err := e.Rank.resolveColumns(e.header); err != nil {
+			return e.importError(err)
+		}
+		e.Rank.columns = columns
+		e.Rank.lookups = e.lookups
 		if err := e.Rank.Validate(); err != nil {
 			return err
 		}
@@ -185,31 +385,142 @@ func (e *Edge) Validate() error {
 	}
 
 	if e.Filter != nil {
-		if err := e.Filter.Build(); err != nil {
+		if err := e.Filter.Build(specbase.WithFilterLookups(e.lookups)); err != nil {
 			return e.importError(errors.ErrFilterSyntax, "%s", err)
 		}
 	}
 
-	if !e.Mode.IsSupport() {
-		return e.importError(errors.ErrUnsupportedMode)
+	if e.Dedup != nil {
+		if err := e.Dedup.Build(); err != nil {
+			return e.importError(err)
+		}
+		if err := validateDedup(e.modes()); err != nil {
+			return e.importError(err)
+		}
 	}
 
-	if (e.Mode == specbase.UpdateMode || e.Mode == specbase.UpsertMode) && len(e.Props) == 0 {
-		return e.importError(errors.ErrNoProps)
+	if err := validateUpsertStrategy(e.UpsertStrategy); err != nil {
+		return e.importError(err)
+	}
+
+	if err := e.validateEndpoints(); err != nil {
+		return e.importError(err)
+	}
+
+	if err := e.validateDirection(); err != nil {
+		return e.importError(err)
+	}
+
+	e.versionProp = nil
+	if e.VersionProp != "" {
+		versionProp, err := validateVersionProp(e.VersionProp, e.Props, e.modes(), e.UpsertStrategy, e.Dedup, e.Src.ID, e.Dst.ID)
+		if err != nil {
+			return e.importError(err)
+		}
+		if e.Endpoints == EndpointsVerify {
+			return e.importError(errors.ErrInvalidConfig, "the version prop does not work with the endpoints policy %s", e.Endpoints)
+		}
+		e.versionProp = versionProp
+	}
+
+	if e.Parameterized && (e.versionProp != nil || e.Endpoints == EndpointsVerify) {
+		return e.importError(errors.ErrInvalidConfig,
+			"the parameterized does not work with the version prop or the endpoints policy %s", EndpointsVerify)
+	}
+
+	for _, mode := range e.modes() {
+		if !mode.IsSupport() {
+			return e.importError(errors.ErrUnsupportedMode)
+		}
+
+		if (mode == specbase.UpdateMode || mode == specbase.UpsertMode) && len(e.Props) == 0 {
+			return e.importError(errors.ErrNoProps)
+		}
 	}
 
 	return nil
 }
 
 func (e *Edge) Statement(records ...Record) (statement string, nRecord int, err error) {
-	return e.fnStatement(records...)
+	statement, nRecord, _, err = e.StatementDedup(records...)
+	return statement, nRecord, err
+}
+
+// StatementDedup is the same as Statement, and also returns the number of the records skipped as duplicates.
+func (e *Edge) StatementDedup(records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	return e.statementDedup(nil, records...)
+}
+
+// StatementParam is the same as StatementDedup, but binds the prop values to the params, see Parameterized.
+func (e *Edge) StatementParam(records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error) {
+	b := newParamBinder()
+	statement, nRecord, nDuplicate, err = e.statementDedup(b, records...)
+	if err != nil {
+		return "", nil, 0, 0, err
+	}
+	return statement, b.params, nRecord, nDuplicate, nil
+}
+
+func (e *Edge) statementDedup(b *paramBinder, records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	if e.Dedup != nil {
+		records, nDuplicate, err = dedupRecords(e.Dedup, e.Filter, e.dedupKey, records)
+		if err != nil {
+			return "", 0, 0, e.importError(err)
+		}
+	}
+	statement, nRecord, err = e.fnStatement(b, records...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if e.Endpoints == EndpointsCreateStub && nRecord > 0 {
+		stubStatement, err := e.stubStatement(records)
+		if err != nil {
+			return "", 0, 0, err
+		}
+		if stubStatement != "" {
+			statement = stubStatement + statement
+		}
+	}
+	return statement, nRecord, nDuplicate, nil
+}
+
+// Forget forgets the src, dst and rank of the records, so that they are not skipped as duplicates any more.
+func (e *Edge) Forget(records ...Record) error {
+	if e.Dedup == nil {
+		return nil
+	}
+	if err := forgetRecords(e.Dedup, e.dedupKey, records); err != nil {
+		return e.importError(err)
+	}
+	return nil
+}
+
+// dedupKey returns `src->dst@rank` of the record.
+func (e *Edge) dedupKey(record Record) (string, error) {
+	srcIDValue, err := e.Src.IDValue(record)
+	if err != nil {
+		return "", err
+	}
+	dstIDValue, err := e.Dst.IDValue(record)
+	if err != nil {
+		return "", err
+	}
+	key := srcIDValue + "->" + dstIDValue
+	if e.Rank != nil {
+		rankValue, err := e.Rank.Value(record)
+		if err != nil {
+			return "", err
+		}
+		key += "@" + rankValue
+	}
+	return key, nil
 }
 
-func (e *Edge) insertStatement(records ...Record) (statement string, nRecord int, err error) {
+func (e *Edge) insertStatement(prefix string, reversed []bool, b *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
-	buff.SetString(e.statementPrefix)
+	buff.SetString(prefix)
 
 	for _, record := range records {
 		if e.Filter != nil {
@@ -238,25 +549,28 @@ func (e *Edge) insertStatement(records ...Record) (statement string, nRecord int
 			}
 			rankValueStatement = "@" + rankValue
 		}
-		propsValueList, err := e.Props.ValueList(record)
+		propsValueList, err := b.valueList(e.Props, record)
 		if err != nil {
 			return "", 0, e.importError(err)
 		}
 
-		if nRecord > 0 {
-			_, _ = buff.WriteString(", ")
-		}
-
-		// src -> dst@rank:(prop_value1, prop_value2, ...)
-		_, _ = buff.WriteString(srcIDValue)
-		_, _ = buff.WriteString("->")
-		_, _ = buff.WriteString(dstIDValue)
-		_, _ = buff.WriteString(rankValueStatement)
-		_, _ = buff.WriteString(":(")
-		_, _ = buff.WriteStringSlice(propsValueList, ", ")
-		_, _ = buff.WriteString(")")
+		for _, reverse := range reversed {
+			if nRecord > 0 {
+				_, _ = buff.WriteString(", ")
+			}
 
-		nRecord++
+			// src -> dst@rank:(prop_value1, prop_value2, ...)
+			fromIDValue, toIDValue := endpointIDValues(srcIDValue, dstIDValue, reverse)
+			_, _ = buff.WriteString(fromIDValue)
+			_, _ = buff.WriteString("->")
+			_, _ = buff.WriteString(toIDValue)
+			_, _ = buff.WriteString(rankValueStatement)
+			_, _ = buff.WriteString(":(")
+			_, _ = buff.WriteStringSlice(propsValueList, ", ")
+			_, _ = buff.WriteString(")")
+
+			nRecord++
+		}
 	}
 
 	if nRecord == 0 {
@@ -266,7 +580,7 @@ func (e *Edge) insertStatement(records ...Record) (statement string, nRecord int
 	return buff.String(), nRecord, nil
 }
 
-func (e *Edge) updateStatement(records ...Record) (statement string, nRecord int, err error) {
+func (e *Edge) updateStatement(prefix string, reversed []bool, b *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
@@ -297,32 +611,42 @@ func (e *Edge) updateStatement(records ...Record) (statement string, nRecord int
 			}
 			rankValueStatement = "@" + rankValue
 		}
-		propsSetValueList, err := e.Props.SetValueList(record)
+		propsSetValueList, err := b.setValueList(e.Props, record)
 		if err != nil {
 			return "", 0, e.importError(err)
 		}
+		var guard string
+		if e.versionProp != nil {
+			if guard, err = versionGuard(e.versionProp, record); err != nil {
+				return "", 0, e.importError(err)
+			}
+		}
 
-		// "UPDATE EDGE ON name "src"->"dst"@rank SET prop_name1 = prop_value1, prop_name1 = prop_value1, ...;"
-		_, _ = buff.WriteString(e.statementPrefix)
-		_, _ = buff.WriteString(srcIDValue)
-		_, _ = buff.WriteString("->")
-		_, _ = buff.WriteString(dstIDValue)
-		_, _ = buff.WriteString(rankValueStatement)
-		_, _ = buff.WriteString(" SET ")
-		_, _ = buff.WriteStringSlice(propsSetValueList, ", ")
-		_, _ = buff.WriteString(";")
-
-		nRecord++
+		for _, reverse := range reversed {
+			// "UPDATE EDGE ON name "src"->"dst"@rank SET prop_name1 = prop_value1, prop_name1 = prop_value1, ...[ WHEN guard];"
+			fromIDValue, toIDValue := endpointIDValues(srcIDValue, dstIDValue, reverse)
+			_, _ = buff.WriteString(prefix)
+			_, _ = buff.WriteString(fromIDValue)
+			_, _ = buff.WriteString("->")
+			_, _ = buff.WriteString(toIDValue)
+			_, _ = buff.WriteString(rankValueStatement)
+			_, _ = buff.WriteString(" SET ")
+			_, _ = buff.WriteStringSlice(propsSetValueList, ", ")
+			_, _ = buff.WriteString(guard)
+			_, _ = buff.WriteString(";")
+
+			nRecord++
+		}
 	}
 
 	return buff.String(), nRecord, nil
 }
 
-func (e *Edge) deleteStatement(records ...Record) (statement string, nRecord int, err error) {
+func (e *Edge) deleteStatement(prefix string, reversed []bool, _ *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
-	buff.SetString(e.statementPrefix)
+	buff.SetString(prefix)
 
 	for _, record := range records {
 		if e.Filter != nil {
@@ -351,17 +675,20 @@ func (e *Edge) deleteStatement(records ...Record) (statement string, nRecord int
 			rankValueStatement = "@" + rankValue
 		}
 
-		if nRecord > 0 {
-			_, _ = buff.WriteString(", ")
-		}
+		for _, reverse := range reversed {
+			if nRecord > 0 {
+				_, _ = buff.WriteString(", ")
+			}
 
-		// src -> dst@rank
-		_, _ = buff.WriteString(srcIDValue)
-		_, _ = buff.WriteString("->")
-		_, _ = buff.WriteString(dstIDValue)
-		_, _ = buff.WriteString(rankValueStatement)
+			// src -> dst@rank
+			fromIDValue, toIDValue := endpointIDValues(srcIDValue, dstIDValue, reverse)
+			_, _ = buff.WriteString(fromIDValue)
+			_, _ = buff.WriteString("->")
+			_, _ = buff.WriteString(toIDValue)
+			_, _ = buff.WriteString(rankValueStatement)
 
-		nRecord++
+			nRecord++
+		}
 	}
 
 	if nRecord == 0 {
@@ -371,6 +698,14 @@ func (e *Edge) deleteStatement(records ...Record) (statement string, nRecord int
 	return buff.String(), nRecord, nil
 }
 
+// modes returns the modes of the records, which are routed by the OpColumn if set.
+func (e *Edge) modes() []specbase.Mode {
+	if e.OpColumn != nil {
+		return e.OpColumn.Modes()
+	}
+	return []specbase.Mode{e.Mode}
+}
+
 func (e *Edge) importError(err error, formatWithArgs ...any) *errors.ImportError {
 	return errors.AsOrNewImportError(err, formatWithArgs...).SetEdgeName(e.Name)
 }
@@ -395,6 +730,35 @@ func (n *EdgeNodeRef) Validate() error {
 	return nil
 }
 
+func (n *EdgeNodeRef) Clone() *EdgeNodeRef {
+	cpy := *n
+	if n.ID != nil {
+		cpy.ID = n.ID.Clone()
+	}
+	return &cpy
+}
+
+func (n *EdgeNodeRef) HasColumnNames() bool {
+	return n.ID != nil && n.ID.HasColumnNames()
+}
+
+func (n *EdgeNodeRef) resolveColumns(header Header) error {
+	if n.ID == nil {
+		return nil
+	}
+	if err := n.ID.resolveColumns(header); err != nil {
+		return n.importError(err)
+	}
+	return nil
+}
+
+func (n *EdgeNodeRef) setExprEnv(columns map[string]int, lookups picker.Lookups) {
+	if n.ID != nil {
+		n.ID.columns = columns
+		n.ID.lookups = lookups
+	}
+}
+
 func (n *EdgeNodeRef) IDValue(record Record) (string, error) {
 	return n.ID.Value(record)
 }
@@ -403,6 +767,26 @@ func (n *EdgeNodeRef) importError(err error, formatWithArgs ...any) *errors.Impo
 	return errors.AsOrNewImportError(err, formatWithArgs...).SetNodeName(n.Name)
 }
 
+func (es Edges) Clone() Edges {
+	if es == nil {
+		return nil
+	}
+	cpy := make(Edges, len(es))
+	for i := range es {
+		cpy[i] = es[i].Clone()
+	}
+	return cpy
+}
+
+func (es Edges) HasColumnNames() bool {
+	for i := range es {
+		if es[i].HasColumnNames() {
+			return true
+		}
+	}
+	return false
+}
+
 func (es Edges) Complete() {
 	for i := range es {
 		es[i].Complete()
@@ -0,0 +1,209 @@
+package specv3
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/bytebufferpool"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+)
+
+const (
+	// EndpointsIgnore inserts the edges without caring about the src and dst vertices, it is the default policy.
+	EndpointsIgnore = "ignore"
+	// EndpointsCreateStub inserts the bare src and dst vertices of the tags with IF NOT EXISTS before the edges.
+	EndpointsCreateStub = "createStub"
+	// EndpointsVerify fetches the src and dst vertices of the tags, and skips the edges whose endpoints are missing.
+	EndpointsVerify = "verify"
+
+	// endpointsTagColumn and endpointsVIDColumn are the columns yielded by the query of EndpointsVerify.
+	endpointsTagColumn = "tag"
+	endpointsVIDColumn = "vid"
+)
+
+func (e *Edge) validateEndpoints() error {
+	switch e.Endpoints {
+	case "", EndpointsIgnore:
+		return nil
+	case EndpointsCreateStub, EndpointsVerify:
+	default:
+		return errors.NewImportError(errors.ErrUnsupportedEndpointPolicy, "unsupported endpoints policy %q", e.Endpoints)
+	}
+	for _, n := range []*EdgeNodeRef{e.Src, e.Dst} {
+		if n.Tag == "" {
+			return n.importError(errors.ErrInvalidConfig, "the tag is required by the endpoints policy %s", e.Endpoints)
+		}
+		// The VIDs generated by graphd can not be compared with the fetched ones.
+		if e.Endpoints == EndpointsVerify && n.ID.Function != nil && *n.ID.Function != "" &&
+			!picker.IsClientFunction(*n.ID.Function) {
+			return n.importError(errors.ErrUnsupportedFunction,
+				"function %s is unsupported by the endpoints policy %s", *n.ID.Function, e.Endpoints)
+		}
+	}
+	if e.Endpoints == EndpointsVerify && e.Dedup != nil {
+		return errors.NewImportError(errors.ErrInvalidConfig, "the endpoints policy %s does not work with dedup", e.Endpoints)
+	}
+	return nil
+}
+
+// needEndpoints reports whether the record passes the filter and needs the endpoints, that is not in DELETE mode.
+func (e *Edge) needEndpoints(record Record) (bool, error) {
+	if e.Filter != nil {
+		ok, err := e.Filter.Filter(record)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	mode := e.Mode
+	if e.OpColumn != nil {
+		var err error
+		if mode, err = e.OpColumn.Mode(record); err != nil {
+			return false, err
+		}
+	}
+	return mode != specbase.DeleteMode, nil
+}
+
+// endpointsRecords returns the records which need the endpoints.
+func (e *Edge) endpointsRecords(records []Record) ([]Record, error) {
+	selected := make([]Record, 0, len(records))
+	for _, record := range records {
+		need, err := e.needEndpoints(record)
+		if err != nil {
+			return nil, e.importError(err)
+		}
+		if need {
+			selected = append(selected, record)
+		}
+	}
+	return selected, nil
+}
+
+// endpointsVIDs returns the distinct src and dst VIDs of the records by the tags, in order.
+func (e *Edge) endpointsVIDs(records []Record) (tags []string, vids map[string][]string, err error) {
+	vids = make(map[string][]string, 2)
+	seen := make(map[string]struct{}, 2*len(records))
+	for _, record := range records {
+		for _, n := range []*EdgeNodeRef{e.Src, e.Dst} {
+			vid, err := n.IDValue(record)
+			if err != nil {
+				return nil, nil, e.importError(err)
+			}
+			key := n.Tag + "\x00" + vid
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			if _, ok := vids[n.Tag]; !ok {
+				tags = append(tags, n.Tag)
+			}
+			vids[n.Tag] = append(vids[n.Tag], vid)
+		}
+	}
+	return tags, vids, nil
+}
+
+// stubStatement returns the statement to insert the bare endpoints of the records if not exist.
+func (e *Edge) stubStatement(records []Record) (string, error) {
+	records, err := e.endpointsRecords(records)
+	if err != nil {
+		return "", err
+	}
+	tags, vids, err := e.endpointsVIDs(records)
+	if err != nil {
+		return "", err
+	}
+
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	// "INSERT VERTEX IF NOT EXISTS tag() VALUES id:(), ..., id:();"
+	for _, tag := range tags {
+		_, _ = buff.WriteString("INSERT VERTEX IF NOT EXISTS ")
+		_, _ = buff.WriteString(utils.ConvertIdentifier(tag))
+		_, _ = buff.WriteString("() VALUES ")
+		for i, vid := range vids[tag] {
+			if i > 0 {
+				_, _ = buff.WriteString(", ")
+			}
+			_, _ = buff.WriteString(vid)
+			_, _ = buff.WriteString(":()")
+		}
+		_, _ = buff.WriteString(";")
+	}
+	return buff.String(), nil
+}
+
+// VerifyQuery returns the query to fetch the existing endpoints of the records with their tags, empty if no need.
+func (e *Edge) VerifyQuery(records ...Record) (query string, columns []string, err error) {
+	records, err = e.endpointsRecords(records)
+	if err != nil {
+		return "", nil, err
+	}
+	tags, vids, err := e.endpointsVIDs(records)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(tags) == 0 {
+		return "", nil, nil
+	}
+
+	// "FETCH PROP ON tag id, ..., id YIELD "tag" AS tag, id(vertex) AS vid UNION FETCH PROP ON ..."
+	queries := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		queries = append(queries, "FETCH PROP ON "+utils.ConvertIdentifier(tag)+" "+strings.Join(vids[tag], ", ")+
+			" YIELD "+strconv.Quote(tag)+" AS "+endpointsTagColumn+", id(vertex) AS "+endpointsVIDColumn)
+	}
+	return strings.Join(queries, " UNION "), []string{endpointsTagColumn, endpointsVIDColumn}, nil
+}
+
+// StatementVerified is the same as Statement, but skips the records whose endpoints are not in the existing vertices,
+// which are the tags and VIDs fetched by the VerifyQuery.
+func (e *Edge) StatementVerified(existing [][]string, records ...Record) (statement string, nRecord, nUnverified int, err error) {
+	var tags, vids []string
+	if len(existing) > 0 {
+		if len(existing) != 2 || len(existing[0]) != len(existing[1]) {
+			return "", 0, 0, e.importError(errors.ErrInvalidValue, "the existing endpoints are not the tags and VIDs")
+		}
+		tags, vids = existing[0], existing[1]
+	}
+	// The tags are yielded as the quoted strings.
+	exists := make(map[string]struct{}, len(vids))
+	for i := range vids {
+		exists[tags[i]+"\x00"+vids[i]] = struct{}{}
+	}
+
+	verified := make([]Record, 0, len(records))
+	for _, record := range records {
+		need, err := e.needEndpoints(record)
+		if err != nil {
+			return "", 0, 0, e.importError(err)
+		}
+		if need {
+			srcIDValue, err := e.Src.IDValue(record)
+			if err != nil {
+				return "", 0, 0, e.importError(err)
+			}
+			dstIDValue, err := e.Dst.IDValue(record)
+			if err != nil {
+				return "", 0, 0, e.importError(err)
+			}
+			_, srcExists := exists[strconv.Quote(e.Src.Tag)+"\x00"+srcIDValue]
+			_, dstExists := exists[strconv.Quote(e.Dst.Tag)+"\x00"+dstIDValue]
+			if !srcExists || !dstExists {
+				nUnverified++
+				continue
+			}
+		}
+		verified = append(verified, record)
+	}
+
+	statement, nRecord, err = e.Statement(verified...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return statement, nRecord, nUnverified, nil
+}
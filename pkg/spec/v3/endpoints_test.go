@@ -0,0 +1,179 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Endpoints", func() {
+	newEdge := func(policy string, srcTag, dstTag string, opts ...EdgeOption) *Edge {
+		edge := NewEdge(
+			"name",
+			append([]EdgeOption{
+				WithEdgeSrc(&EdgeNodeRef{Tag: srcTag, ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{Tag: dstTag, ID: &NodeID{Name: "id", Type: ValueTypeString, Index: 1}}),
+				WithEdgeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 2}),
+				WithEdgeMode(specbase.InsertMode),
+				WithEdgeEndpoints(policy),
+			}, opts...)...,
+		)
+		edge.Complete()
+		return edge
+	}
+
+	DescribeTable(".Validate",
+		func(edge *Edge, expectErr error) {
+			err := edge.Validate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("ignore", newEdge("", "", ""), nil),
+		Entry("createStub", newEdge(EndpointsCreateStub, "player", "team"), nil),
+		Entry("verify", newEdge(EndpointsVerify, "player", "team"), nil),
+		Entry("unsupported", newEdge("unknown", "player", "team"), errors.ErrUnsupportedEndpointPolicy),
+		Entry("no src tag", newEdge(EndpointsCreateStub, "", "team"), errors.ErrInvalidConfig),
+		Entry("no dst tag", newEdge(EndpointsVerify, "player", ""), errors.ErrInvalidConfig),
+		Entry("verify with dedup", newEdge(EndpointsVerify, "player", "team", WithEdgeDedup(&specbase.Dedup{})), errors.ErrInvalidConfig),
+	)
+
+	It("createStub", func() {
+		edge := newEdge(EndpointsCreateStub, "player", "team")
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement(
+			[]string{"1", "a", "x"},
+			[]string{"1", "b", "y"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT VERTEX IF NOT EXISTS `player`() VALUES 1:();" +
+			"INSERT VERTEX IF NOT EXISTS `team`() VALUES \"a\":(), \"b\":();" +
+			"INSERT EDGE `name`(`prop1`) VALUES 1->\"a\":(\"x\"), 1->\"b\":(\"y\")"))
+
+		statement, nRecord, err = edge.Statement()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(BeEmpty())
+	})
+
+	It("createStub in DELETE mode", func() {
+		edge := newEdge(EndpointsCreateStub, "player", "team", WithEdgeMode(specbase.DeleteMode))
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement([]string{"1", "a", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("DELETE EDGE `name` 1->\"a\""))
+	})
+
+	It("VerifyQuery", func() {
+		edge := newEdge(EndpointsVerify, "player", "team")
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		query, columns, err := edge.VerifyQuery([]string{"1", "a", "x"}, []string{"1", "b", "y"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal([]string{"tag", "vid"}))
+		Expect(query).To(Equal("FETCH PROP ON `player` 1 YIELD \"player\" AS tag, id(vertex) AS vid UNION " +
+			"FETCH PROP ON `team` \"a\", \"b\" YIELD \"team\" AS tag, id(vertex) AS vid"))
+
+		edge = newEdge(EndpointsVerify, "player", "player")
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+		query, _, err = edge.VerifyQuery([]string{"1", "a", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(Equal("FETCH PROP ON `player` 1, \"a\" YIELD \"player\" AS tag, id(vertex) AS vid"))
+
+		query, columns, err = edge.VerifyQuery()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(BeEmpty())
+		Expect(columns).To(BeEmpty())
+
+		_, _, err = edge.VerifyQuery([]string{"1"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("StatementVerified", func() {
+		edge := newEdge(EndpointsVerify, "player", "team")
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		records := []Record{
+			{"1", "a", "x"},
+			{"1", "b", "y"},
+			{"2", "a", "z"},
+		}
+		existing := [][]string{{"\"player\"", "\"team\""}, {"1", "\"a\""}}
+		statement, nRecord, nUnverified, err := edge.StatementVerified(existing, records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nUnverified).To(Equal(2))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`prop1`) VALUES 1->\"a\":(\"x\")"))
+
+		statement, nRecord, nUnverified, err = edge.StatementVerified(nil, records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nUnverified).To(Equal(3))
+		Expect(statement).To(BeEmpty())
+
+		_, _, _, err = edge.StatementVerified([][]string{{"1", "\"a\""}}, records...)
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrInvalidValue)).To(BeTrue())
+	})
+
+	It("StatementVerified with different tags", func() {
+		edge := newEdge(EndpointsVerify, "player", "team")
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		// The src 2 exists only as a team, and the dst "a" only as a player.
+		existing := [][]string{{"\"player\"", "\"team\"", "\"player\""}, {"1", "2", "\"a\""}}
+		records := []Record{
+			{"1", "a", "x"},
+			{"2", "b", "y"},
+			{"1", "b", "z"},
+		}
+		statement, nRecord, nUnverified, err := edge.StatementVerified(existing, records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nUnverified).To(Equal(3))
+		Expect(statement).To(BeEmpty())
+
+		existing = [][]string{{"\"player\"", "\"team\"", "\"team\""}, {"1", "\"a\"", "\"b\""}}
+		statement, nRecord, nUnverified, err = edge.StatementVerified(existing, records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(nUnverified).To(Equal(1))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`prop1`) VALUES 1->\"a\":(\"x\"), 1->\"b\":(\"z\")"))
+	})
+
+	It("EdgeStatementBuilder", func() {
+		edge := newEdge(EndpointsVerify, "player", "team")
+		graph := NewGraph("graph", WithGraphEdges(edge))
+		graph.Complete()
+		Expect(graph.Validate()).NotTo(HaveOccurred())
+
+		b, ok := graph.EdgeStatementBuilder(edge).(specbase.VerifyStatementBuilder)
+		Expect(ok).To(BeTrue())
+
+		query, columns, err := b.BuildVerify([]string{"1", "a", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal([]string{"tag", "vid"}))
+		Expect(query).To(HavePrefix("FETCH PROP ON `player` 1"))
+
+		statement, nRecord, nUnverified, err := b.BuildVerified([][]string{{"\"player\"", "\"team\""}, {"1", "\"a\""}}, []string{"1", "a", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nUnverified).To(Equal(0))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`prop1`) VALUES 1->\"a\":(\"x\")"))
+
+		statement, nRecord, err = b.Build([]string{"1", "a", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).NotTo(BeEmpty())
+	})
+})
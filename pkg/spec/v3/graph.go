@@ -2,6 +2,7 @@ package specv3
 
 import (
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
 	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 )
 
@@ -10,9 +11,46 @@ type (
 		Name  string `yaml:"name" json:"name"`
 		Nodes Nodes  `yaml:"tags,omitempty" json:"tags,omitempty,optional"`
 		Edges Edges  `yaml:"edges,omitempty" json:"edges,omitempty,optional"`
+
+		header  Header
+		lookups picker.Lookups
 	}
 
 	GraphOption func(*Graph)
+
+	// dedupStatementBuilder builds the statements of the node or edge with Dedup.
+	dedupStatementBuilder struct {
+		fnBuild  func(records ...Record) (string, int, int, error)
+		fnForget func(records ...Record) error
+	}
+
+	// verifyStatementBuilder builds the statements of the edge with the endpoints verified.
+	verifyStatementBuilder struct {
+		fnBuild    func(records ...Record) (string, int, error)
+		fnVerify   func(records ...Record) (string, []string, error)
+		fnVerified func(values [][]string, records ...Record) (string, int, int, error)
+	}
+
+	// versionStatementBuilder builds the statements of the node or edge without the stale records.
+	versionStatementBuilder struct {
+		fnBuild      func(records ...Record) (string, int, error)
+		fnStaleQuery func(records ...Record) (string, []string, error)
+		fnFresh      func(existing [][]string, records ...Record) (string, int, int, error)
+	}
+
+	// paramStatementBuilder builds the statements of the parameterized node or edge, which may also have Dedup.
+	paramStatementBuilder struct {
+		fnBuild      func(records ...Record) (string, int, int, error)
+		fnBuildParam func(records ...Record) (string, map[string]any, int, int, error)
+		fnForget     func(records ...Record) error
+	}
+)
+
+var (
+	_ specbase.DedupStatementBuilder  = dedupStatementBuilder{}
+	_ specbase.VerifyStatementBuilder = verifyStatementBuilder{}
+	_ specbase.ParamStatementBuilder  = paramStatementBuilder{}
+	_ specbase.DedupStatementBuilder  = paramStatementBuilder{}
 )
 
 func NewGraph(name string, opts ...GraphOption) *Graph {
@@ -39,6 +77,20 @@ func WithGraphEdges(edges ...*Edge) GraphOption {
 	}
 }
 
+// WithGraphHeader sets the header to resolve the column names of the nodes and edges.
+func WithGraphHeader(header Header) GraphOption {
+	return func(g *Graph) {
+		g.header = header
+	}
+}
+
+// WithGraphLookups sets the lookups which are available as `lookup(name, key)` in the expressions of the nodes and edges.
+func WithGraphLookups(lookups picker.Lookups) GraphOption {
+	return func(g *Graph) {
+		g.lookups = lookups
+	}
+}
+
 func (g *Graph) AddNodes(nodes ...*Node) {
 	g.Nodes = append(g.Nodes, nodes...)
 }
@@ -60,6 +112,22 @@ func (g *Graph) Validate() error {
 	if g.Name == "" {
 		return errors.ErrNoSpaceName
 	}
+	if g.header != nil {
+		for _, n := range g.Nodes {
+			n.Options(WithNodeHeader(g.header))
+		}
+		for _, e := range g.Edges {
+			e.Options(WithEdgeHeader(g.header))
+		}
+	}
+	if g.lookups != nil {
+		for _, n := range g.Nodes {
+			n.Options(WithNodeLookups(g.lookups))
+		}
+		for _, e := range g.Edges {
+			e.Options(WithEdgeLookups(g.lookups))
+		}
+	}
 	if err := g.Nodes.Validate(); err != nil {
 		return err
 	}
@@ -79,12 +147,93 @@ func (g *Graph) NodeStatement(n *Node, records ...Record) (statement string, nRe
 	return statement, nRecord, nil
 }
 
+// NodeStatementDedup is the same as NodeStatement, and also returns the number of the records skipped as duplicates.
+func (g *Graph) NodeStatementDedup(n *Node, records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	statement, nRecord, nDuplicate, err = n.StatementDedup(records...)
+	if err != nil {
+		return "", 0, 0, g.importError(err).SetGraphName(g.Name).SetNodeName(n.Name)
+	}
+	return statement, nRecord, nDuplicate, nil
+}
+
+// NodeStatementFresh is the same as NodeStatement, but skips the records not newer than the existing ones fetched by the StaleQuery.
+func (g *Graph) NodeStatementFresh(n *Node, existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error) {
+	statement, nRecord, nStale, err = n.StatementFresh(existing, records...)
+	if err != nil {
+		return "", 0, 0, g.importError(err).SetGraphName(g.Name).SetNodeName(n.Name)
+	}
+	return statement, nRecord, nStale, nil
+}
+
+// NodeStatementParam is the same as NodeStatementDedup, but binds the prop values to the params.
+func (g *Graph) NodeStatementParam(n *Node, records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error) {
+	statement, params, nRecord, nDuplicate, err = n.StatementParam(records...)
+	if err != nil {
+		return "", nil, 0, 0, g.importError(err).SetGraphName(g.Name).SetNodeName(n.Name)
+	}
+	return statement, params, nRecord, nDuplicate, nil
+}
+
+// NodeStatementBuilder returns the builder of the node, which is a DedupStatementBuilder if the node has Dedup,
+// a VersionStatementBuilder if the node has the version prop, or a ParamStatementBuilder if the node is parameterized.
 func (g *Graph) NodeStatementBuilder(n *Node) specbase.StatementBuilder {
+	if n.Parameterized {
+		return paramStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, int, error) {
+				return g.NodeStatementDedup(n, records...)
+			},
+			fnBuildParam: func(records ...Record) (string, map[string]any, int, int, error) {
+				return g.NodeStatementParam(n, records...)
+			},
+			fnForget: n.Forget,
+		}
+	}
+	if n.VersionProp != "" {
+		return versionStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, error) {
+				return g.NodeStatement(n, records...)
+			},
+			fnStaleQuery: func(records ...Record) (string, []string, error) {
+				query, columns, err := n.StaleQuery(records...)
+				if err != nil {
+					return "", nil, g.importError(err).SetGraphName(g.Name).SetNodeName(n.Name)
+				}
+				return query, columns, nil
+			},
+			fnFresh: func(existing [][]string, records ...Record) (string, int, int, error) {
+				return g.NodeStatementFresh(n, existing, records...)
+			},
+		}
+	}
+	if n.Dedup != nil {
+		return dedupStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, int, error) {
+				return g.NodeStatementDedup(n, records...)
+			},
+			fnForget: n.Forget,
+		}
+	}
 	return specbase.StatementBuilderFunc(func(records ...specbase.Record) (string, int, error) {
 		return g.NodeStatement(n, records...)
 	})
 }
 
+// NodesStatement inserts the tags of the nodes in one statement, see Nodes.InsertStatement.
+func (g *Graph) NodesStatement(ns Nodes, records ...Record) (statement string, nRecord int, err error) {
+	statement, nRecord, err = ns.InsertStatement(records...)
+	if err != nil {
+		return "", 0, g.importError(err).SetGraphName(g.Name)
+	}
+	return statement, nRecord, nil
+}
+
+// NodesStatementBuilder returns the builder to insert the tags of the nodes together.
+func (g *Graph) NodesStatementBuilder(ns Nodes) specbase.StatementBuilder {
+	return specbase.StatementBuilderFunc(func(records ...specbase.Record) (string, int, error) {
+		return g.NodesStatement(ns, records...)
+	})
+}
+
 func (g *Graph) EdgeStatement(e *Edge, records ...Record) (statement string, nRecord int, err error) {
 	statement, nRecord, err = e.Statement(records...)
 	if err != nil {
@@ -93,7 +242,99 @@ func (g *Graph) EdgeStatement(e *Edge, records ...Record) (statement string, nRe
 	return statement, nRecord, nil
 }
 
+// EdgeStatementDedup is the same as EdgeStatement, and also returns the number of the records skipped as duplicates.
+func (g *Graph) EdgeStatementDedup(e *Edge, records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	statement, nRecord, nDuplicate, err = e.StatementDedup(records...)
+	if err != nil {
+		return "", 0, 0, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+	}
+	return statement, nRecord, nDuplicate, nil
+}
+
+// EdgeStatementVerified is the same as EdgeStatement, but skips the edges whose endpoints are not in the existing vertices.
+func (g *Graph) EdgeStatementVerified(e *Edge, existing [][]string, records ...Record) (statement string, nRecord, nUnverified int, err error) {
+	statement, nRecord, nUnverified, err = e.StatementVerified(existing, records...)
+	if err != nil {
+		return "", 0, 0, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+	}
+	return statement, nRecord, nUnverified, nil
+}
+
+// EdgeStatementFresh is the same as EdgeStatement, but skips the records not newer than the existing ones fetched by the StaleQuery.
+func (g *Graph) EdgeStatementFresh(e *Edge, existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error) {
+	statement, nRecord, nStale, err = e.StatementFresh(existing, records...)
+	if err != nil {
+		return "", 0, 0, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+	}
+	return statement, nRecord, nStale, nil
+}
+
+// EdgeStatementParam is the same as EdgeStatementDedup, but binds the prop values to the params.
+func (g *Graph) EdgeStatementParam(e *Edge, records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error) {
+	statement, params, nRecord, nDuplicate, err = e.StatementParam(records...)
+	if err != nil {
+		return "", nil, 0, 0, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+	}
+	return statement, params, nRecord, nDuplicate, nil
+}
+
+// EdgeStatementBuilder returns the builder of the edge, which is a DedupStatementBuilder if the edge has Dedup,
+// a VerifyStatementBuilder if the edge verifies the endpoints, a VersionStatementBuilder if the edge has the version prop,
+// or a ParamStatementBuilder if the edge is parameterized.
 func (g *Graph) EdgeStatementBuilder(e *Edge) specbase.StatementBuilder {
+	if e.Parameterized {
+		return paramStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, int, error) {
+				return g.EdgeStatementDedup(e, records...)
+			},
+			fnBuildParam: func(records ...Record) (string, map[string]any, int, int, error) {
+				return g.EdgeStatementParam(e, records...)
+			},
+			fnForget: e.Forget,
+		}
+	}
+	if e.VersionProp != "" {
+		return versionStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, error) {
+				return g.EdgeStatement(e, records...)
+			},
+			fnStaleQuery: func(records ...Record) (string, []string, error) {
+				query, columns, err := e.StaleQuery(records...)
+				if err != nil {
+					return "", nil, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+				}
+				return query, columns, nil
+			},
+			fnFresh: func(existing [][]string, records ...Record) (string, int, int, error) {
+				return g.EdgeStatementFresh(e, existing, records...)
+			},
+		}
+	}
+	if e.Endpoints == EndpointsVerify {
+		return verifyStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, error) {
+				return g.EdgeStatement(e, records...)
+			},
+			fnVerify: func(records ...Record) (string, []string, error) {
+				query, columns, err := e.VerifyQuery(records...)
+				if err != nil {
+					return "", nil, g.importError(err).SetGraphName(g.Name).SetEdgeName(e.Name)
+				}
+				return query, columns, nil
+			},
+			fnVerified: func(values [][]string, records ...Record) (string, int, int, error) {
+				return g.EdgeStatementVerified(e, values, records...)
+			},
+		}
+	}
+	if e.Dedup != nil {
+		return dedupStatementBuilder{
+			fnBuild: func(records ...Record) (string, int, int, error) {
+				return g.EdgeStatementDedup(e, records...)
+			},
+			fnForget: e.Forget,
+		}
+	}
 	return specbase.StatementBuilderFunc(func(records ...specbase.Record) (string, int, error) {
 		return g.EdgeStatement(e, records...)
 	})
@@ -120,3 +361,57 @@ func (g *Graph) GetEdgeByName(name string) (*Edge, bool) {
 func (g *Graph) importError(err error, formatWithArgs ...any) *errors.ImportError {
 	return errors.AsOrNewImportError(err, formatWithArgs...).SetGraphName(g.Name)
 }
+
+func (b dedupStatementBuilder) Build(records ...Record) (statement string, nRecord int, err error) {
+	statement, nRecord, _, err = b.fnBuild(records...)
+	return statement, nRecord, err
+}
+
+func (b dedupStatementBuilder) BuildDedup(records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	return b.fnBuild(records...)
+}
+
+func (b dedupStatementBuilder) Forget(records ...Record) error {
+	return b.fnForget(records...)
+}
+
+func (b verifyStatementBuilder) Build(records ...Record) (statement string, nRecord int, err error) {
+	return b.fnBuild(records...)
+}
+
+func (b verifyStatementBuilder) BuildVerify(records ...Record) (query string, columns []string, err error) {
+	return b.fnVerify(records...)
+}
+
+func (b verifyStatementBuilder) BuildVerified(values [][]string, records ...Record) (statement string, nRecord, nUnverified int, err error) {
+	return b.fnVerified(values, records...)
+}
+
+func (b versionStatementBuilder) Build(records ...Record) (statement string, nRecord int, err error) {
+	return b.fnBuild(records...)
+}
+
+func (b versionStatementBuilder) BuildStaleQuery(records ...Record) (query string, columns []string, err error) {
+	return b.fnStaleQuery(records...)
+}
+
+func (b versionStatementBuilder) BuildFresh(existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error) {
+	return b.fnFresh(existing, records...)
+}
+
+func (b paramStatementBuilder) Build(records ...Record) (statement string, nRecord int, err error) {
+	statement, nRecord, _, err = b.fnBuild(records...)
+	return statement, nRecord, err
+}
+
+func (b paramStatementBuilder) BuildDedup(records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	return b.fnBuild(records...)
+}
+
+func (b paramStatementBuilder) BuildParam(records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error) {
+	return b.fnBuildParam(records...)
+}
+
+func (b paramStatementBuilder) Forget(records ...Record) error {
+	return b.fnForget(records...)
+}
@@ -0,0 +1,59 @@
+package specv3
+
+import (
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+)
+
+// concatItemColumnKey is the key of the concat item to address the column by name, such as {column: name}.
+const concatItemColumnKey = "column"
+
+// Header maps the column names to the indices, such as the csv header or the sql columns.
+type Header map[string]int
+
+func NewHeader(names []string) Header {
+	h := make(Header, len(names))
+	for i, name := range names {
+		if _, ok := h[name]; !ok {
+			h[name] = i
+		}
+	}
+	return h
+}
+
+func (h Header) resolve(name string) (int, error) {
+	if h == nil {
+		return 0, errors.NewImportError(errors.ErrNoHeader, "column %q requires the header", name)
+	}
+	index, ok := h[name]
+	if !ok {
+		return 0, errors.NewImportError(errors.ErrUnknownColumn, "unknown column %q", name)
+	}
+	return index, nil
+}
+
+func (h Header) resolveAll(names []string) ([]int, error) {
+	indices := make([]int, 0, len(names))
+	for _, name := range names {
+		index, err := h.resolve(name)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, index)
+	}
+	return indices, nil
+}
+
+// concatItemColumn returns the column name if the concat item addresses the column by name.
+func concatItemColumn(item any) (string, bool) {
+	var v any
+	switch m := item.(type) {
+	case map[string]any:
+		v = m[concatItemColumnKey]
+	case map[any]any:
+		v = m[concatItemColumnKey]
+	default:
+		return "", false
+	}
+	name, ok := v.(string)
+	return name, ok
+}
@@ -0,0 +1,175 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Header", func() {
+	It("NewHeader", func() {
+		h := NewHeader([]string{"a", "b", "a"})
+		Expect(h).To(Equal(Header{"a": 0, "b": 1}))
+	})
+
+	DescribeTable(".resolve",
+		func(h Header, name string, expectIndex int, expectErr error) {
+			index, err := h.resolve(name)
+			if expectErr != nil {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(index).To(Equal(expectIndex))
+			}
+		},
+		Entry("no header", Header(nil), "a", 0, errors.ErrNoHeader),
+		Entry("unknown column", Header{"a": 0}, "b", 0, errors.ErrUnknownColumn),
+		Entry("successfully", Header{"a": 0, "b": 1}, "b", 1, nil),
+	)
+
+	It("node", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{
+				Name: "id",
+				Type: ValueTypeString,
+				ConcatItems: []any{
+					"user-",
+					map[string]any{"column": "id"},
+				},
+			}),
+			WithNodeProps(
+				&Prop{Name: "name", Type: ValueTypeString, Column: "name"},
+				&Prop{
+					Name:               "age",
+					Type:               ValueTypeInt,
+					Column:             "age",
+					Nullable:           true,
+					AlternativeColumns: []string{"age2"},
+				},
+				&Prop{
+					Name:      "desc",
+					Type:      ValueTypeString,
+					ValueExpr: func() *string { s := `name + ":" + age2`; return &s }(),
+				},
+			),
+			WithNodeMode(specbase.InsertMode),
+			WithNodeHeader(NewHeader([]string{"age", "id", "name", "age2"})),
+		)
+		Expect(node.HasColumnNames()).To(BeTrue())
+		node.Complete()
+		err := node.Validate()
+		Expect(err).NotTo(HaveOccurred())
+
+		statement, nRecord, err := node.Statement([]string{"", "1", "Tom", "18"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`name`, `age`, `desc`) VALUES \"user-1\":(\"Tom\", 18, \"Tom:18\")"))
+	})
+
+	It("node unknown column", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{
+				Name:        "id",
+				Type:        ValueTypeString,
+				ConcatItems: []any{map[string]any{"column": "unknown"}},
+			}),
+			WithNodeHeader(NewHeader([]string{"id"})),
+		)
+		node.Complete()
+		err := node.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnknownColumn)).To(BeTrue())
+	})
+
+	It("node without header", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeString, Column: "id"}),
+		)
+		node.Complete()
+		err := node.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrNoHeader)).To(BeTrue())
+	})
+
+	It("edge", func() {
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{
+				Name: "src",
+				ID:   &NodeID{Name: "id", Type: ValueTypeString, Column: "src"},
+			}),
+			WithEdgeDst(&EdgeNodeRef{
+				Name: "dst",
+				ID:   &NodeID{Name: "id", Type: ValueTypeString, Column: "dst"},
+			}),
+			WithRank(&Rank{Column: "rank"}),
+			WithEdgeProps(&Prop{Name: "weight", Type: ValueTypeDouble, Column: "weight"}),
+			WithEdgeMode(specbase.InsertMode),
+		)
+		Expect(edge.HasColumnNames()).To(BeTrue())
+		graph := NewGraph("graph", WithGraphEdges(edge), WithGraphHeader(NewHeader([]string{"weight", "rank", "dst", "src"})))
+		graph.Complete()
+		err := graph.Validate()
+		Expect(err).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement([]string{"1.5", "2", "b", "a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`weight`) VALUES \"a\"->\"b\"@2:(1.5)"))
+	})
+
+	It("edge unknown column", func() {
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{
+				Name: "src",
+				ID:   &NodeID{Name: "id", Type: ValueTypeString, Index: 0},
+			}),
+			WithEdgeDst(&EdgeNodeRef{
+				Name: "dst",
+				ID:   &NodeID{Name: "id", Type: ValueTypeString, Index: 1},
+			}),
+			WithRank(&Rank{Column: "unknown"}),
+			WithEdgeHeader(NewHeader([]string{"src", "dst"})),
+		)
+		edge.Complete()
+		err := edge.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnknownColumn)).To(BeTrue())
+	})
+
+	It("clone", func() {
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Column: "src"}}),
+			WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Column: "dst"}}),
+			WithRank(&Rank{Column: "rank"}),
+			WithEdgeProps(&Prop{Column: "p"}),
+			WithEdgeFilter(&specbase.Filter{Expr: "true"}),
+		)
+		cpy := Edges{edge}.Clone()[0]
+		Expect(cpy).To(Equal(edge))
+		Expect(cpy.Src).NotTo(BeIdenticalTo(edge.Src))
+		Expect(cpy.Src.ID).NotTo(BeIdenticalTo(edge.Src.ID))
+		Expect(cpy.Dst.ID).NotTo(BeIdenticalTo(edge.Dst.ID))
+		Expect(cpy.Rank).NotTo(BeIdenticalTo(edge.Rank))
+		Expect(cpy.Props[0]).NotTo(BeIdenticalTo(edge.Props[0]))
+		Expect(cpy.Filter).NotTo(BeIdenticalTo(edge.Filter))
+
+		node := NewNode("name", WithNodeID(&NodeID{Column: "id"}), WithNodeProps(&Prop{Column: "p"}))
+		nodeCpy := Nodes{node}.Clone()[0]
+		Expect(nodeCpy).To(Equal(node))
+		Expect(nodeCpy.ID).NotTo(BeIdenticalTo(node.ID))
+		Expect(nodeCpy.Props[0]).NotTo(BeIdenticalTo(node.Props[0]))
+		Expect(Nodes(nil).Clone()).To(BeNil())
+		Expect(Edges(nil).Clone()).To(BeNil())
+	})
+})
@@ -6,10 +6,20 @@ import (
 
 	"github.com/lucky-xin/nebula-importer/pkg/bytebufferpool"
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
 	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 	"github.com/lucky-xin/nebula-importer/pkg/utils"
 )
 
+const (
+	// DeleteScopeTag deletes the tag from the vertices in DELETE mode, it is the default scope.
+	DeleteScopeTag = "tag"
+	// DeleteScopeVertex deletes the whole vertices in DELETE mode, but leaves their edges.
+	DeleteScopeVertex = "vertex"
+	// DeleteScopeVertexWithEdge deletes the whole vertices with their edges in DELETE mode.
+	DeleteScopeVertexWithEdge = "vertexWithEdge"
+)
+
 type (
 	// Node is VERTEX in 3.x
 	Node struct {
@@ -23,13 +33,30 @@ type (
 
 		Filter *specbase.Filter `yaml:"filter,omitempty" json:"filter,omitempty,optional"`
 
+		Dedup *specbase.Dedup `yaml:"dedup,omitempty" json:"dedup,omitempty,optional"`
+
 		Mode specbase.Mode `yaml:"mode,omitempty" json:"mode,omitempty,default=insert"`
 
-		fnStatement func(records ...Record) (string, int, error)
-		// "INSERT VERTEX name(prop_name, ..., prop_name) VALUES "
-		// "UPDATE VERTEX ON name "
-		// "DELETE TAG name FROM "
-		statementPrefix string
+		// DeleteScope is what the DELETE mode removes, the tag by default, or the whole vertices.
+		DeleteScope string `yaml:"deleteScope,omitempty" json:"deleteScope,omitempty,optional"`
+
+		// UpsertStrategy is how the UPSERT mode builds the statement, see UpsertStrategyStatement and UpsertStrategyInsert.
+		UpsertStrategy string `yaml:"upsertStrategy,omitempty" json:"upsertStrategy,omitempty,optional"`
+
+		// OpColumn routes each record to the mode by its value, and the Mode is ignored.
+		OpColumn *OpColumn `yaml:"opColumn,omitempty" json:"opColumn,omitempty,optional"`
+
+		// VersionProp is the prop of the version or timestamp, the UPSERT and UPDATE modes apply only if the record is newer.
+		VersionProp string `yaml:"versionProp,omitempty" json:"versionProp,omitempty,optional"`
+
+		// Parameterized binds the prop values to the params of the statement instead of the literals.
+		Parameterized bool `yaml:"parameterized,omitempty" json:"parameterized,omitempty,optional"`
+
+		versionProp      *Prop
+		header           Header
+		lookups          picker.Lookups
+		fnStatement      statementFunc
+		fnModeStatements map[specbase.Mode]statementFunc
 	}
 
 	Nodes []*Node
@@ -70,12 +97,68 @@ func WithNodeFilter(f *specbase.Filter) NodeOption {
 	}
 }
 
+// WithNodeDedup skips the records whose VIDs have been seen.
+func WithNodeDedup(d *specbase.Dedup) NodeOption {
+	return func(n *Node) {
+		n.Dedup = d
+	}
+}
+
 func WithNodeMode(m specbase.Mode) NodeOption {
 	return func(n *Node) {
 		n.Mode = m
 	}
 }
 
+// WithNodeDeleteScope sets what the DELETE mode removes, see DeleteScopeTag, DeleteScopeVertex and DeleteScopeVertexWithEdge.
+func WithNodeDeleteScope(scope string) NodeOption {
+	return func(n *Node) {
+		n.DeleteScope = scope
+	}
+}
+
+// WithNodeUpsertStrategy sets how the UPSERT mode builds the statement.
+func WithNodeUpsertStrategy(strategy string) NodeOption {
+	return func(n *Node) {
+		n.UpsertStrategy = strategy
+	}
+}
+
+// WithNodeOpColumn routes each record to the mode by the value of the column.
+func WithNodeOpColumn(c *OpColumn) NodeOption {
+	return func(n *Node) {
+		n.OpColumn = c
+	}
+}
+
+// WithNodeVersionProp sets the prop of the version, which guards the UPSERT and UPDATE modes.
+func WithNodeVersionProp(name string) NodeOption {
+	return func(n *Node) {
+		n.VersionProp = name
+	}
+}
+
+// WithNodeParameterized binds the prop values to the params of the statement.
+func WithNodeParameterized(parameterized bool) NodeOption {
+	return func(n *Node) {
+		n.Parameterized = parameterized
+	}
+}
+
+// WithNodeLookups sets the lookups which are available as `lookup(name, key)` in the expressions.
+func WithNodeLookups(lookups picker.Lookups) NodeOption {
+	return func(n *Node) {
+		n.lookups = lookups
+	}
+}
+
+// WithNodeHeader sets the header to resolve the column names.
+func WithNodeHeader(header Header) NodeOption {
+	return func(n *Node) {
+		n.header = header
+	}
+}
+
 func (n *Node) Options(opts ...NodeOption) *Node {
 	for _, opt := range opts {
 		opt(n)
@@ -83,6 +166,31 @@ func (n *Node) Options(opts ...NodeOption) *Node {
 	return n
 }
 
+// Clone returns a copy of the node, which can be completed and validated separately, such as with another header.
+func (n *Node) Clone() *Node {
+	cpy := *n
+	if n.ID != nil {
+		cpy.ID = n.ID.Clone()
+	}
+	cpy.Props = n.Props.Clone()
+	if n.Filter != nil {
+		filter := *n.Filter
+		cpy.Filter = &filter
+	}
+	if n.OpColumn != nil {
+		cpy.OpColumn = n.OpColumn.Clone()
+	}
+	// The Dedup is shared, so that the duplicates are skipped across the copies.
+	return &cpy
+}
+
+// HasColumnNames reports whether the node addresses the columns by name.
+func (n *Node) HasColumnNames() bool {
+	return (n.ID != nil && n.ID.HasColumnNames()) ||
+		(n.OpColumn != nil && n.OpColumn.HasColumnNames()) ||
+		n.Props.HasColumnNames()
+}
+
 //nolint:dupl
 func (n *Node) Complete() {
 	if n.ID != nil {
@@ -91,35 +199,84 @@ func (n *Node) Complete() {
 	}
 	n.Props.Complete()
 	n.Mode = n.Mode.Convert()
+	n.fnStatement = n.modeStatement(n.Mode)
 
-	switch n.Mode {
-	case specbase.InsertMode:
-		n.fnStatement = n.insertStatement
-		// default enable IGNORE_EXISTED_INDEX
-		prefix := "INSERT VERTEX"
-		if n.IgnoreExistedIndex != nil && *n.IgnoreExistedIndex {
-			prefix = "INSERT VERTEX IGNORE_EXISTED_INDEX"
+	if n.OpColumn != nil {
+		n.OpColumn.Complete()
+		n.fnModeStatements = make(map[specbase.Mode]statementFunc)
+		for _, mode := range n.OpColumn.Modes() {
+			n.fnModeStatements[mode] = n.modeStatement(mode)
 		}
-		if n.IgnoreExistedRecord != nil && *n.IgnoreExistedRecord {
-			prefix = prefix + " IF NOT EXISTS"
+		n.fnStatement = func(b *paramBinder, records ...Record) (string, int, error) {
+			statement, nRecord, err := opStatement(n.OpColumn, n.fnModeStatements, b, records...)
+			if err != nil {
+				return "", 0, n.importError(err)
+			}
+			return statement, nRecord, nil
 		}
-		insertPrefixFmt := "%s %s(%s) VALUES "
-		n.statementPrefix = fmt.Sprintf(
-			insertPrefixFmt,
-			prefix,
-			utils.ConvertIdentifier(n.Name),
-			strings.Join(n.Props.NameList(), ", "),
-		)
+	}
+}
+
+// modeStatement returns the function to build the statement in the mode, nil if the mode is not supported.
+func (n *Node) modeStatement(mode specbase.Mode) statementFunc {
+	// "INSERT VERTEX name(prop_name, ..., prop_name) VALUES "
+	// "UPDATE VERTEX ON name "
+	// "DELETE TAG name FROM "
+	// "DELETE VERTEX "
+	var (
+		fn     func(prefix string, b *paramBinder, records ...Record) (string, int, error)
+		prefix string
+	)
+	switch mode {
+	case specbase.InsertMode:
+		fn = n.insertStatement
+		prefix = n.insertPrefix(n.IgnoreExistedRecord != nil && *n.IgnoreExistedRecord)
 	case specbase.UpsertMode:
-		n.fnStatement = n.updateStatement
-		n.statementPrefix = fmt.Sprintf("UPSERT VERTEX ON %s ", utils.ConvertIdentifier(n.Name))
+		if n.UpsertStrategy == UpsertStrategyInsert {
+			// The INSERT without IF NOT EXISTS overwrites the existing ones.
+			fn = n.insertStatement
+			prefix = n.insertPrefix(false)
+			break
+		}
+		fn = n.updateStatement
+		prefix = fmt.Sprintf("UPSERT VERTEX ON %s ", utils.ConvertIdentifier(n.Name))
 	case specbase.UpdateMode:
-		n.fnStatement = n.updateStatement
-		n.statementPrefix = fmt.Sprintf("UPDATE VERTEX ON %s ", utils.ConvertIdentifier(n.Name))
+		fn = n.updateStatement
+		prefix = fmt.Sprintf("UPDATE VERTEX ON %s ", utils.ConvertIdentifier(n.Name))
 	case specbase.DeleteMode:
-		n.fnStatement = n.deleteStatement
-		n.statementPrefix = fmt.Sprintf("DELETE TAG %s FROM ", utils.ConvertIdentifier(n.Name))
+		switch n.DeleteScope {
+		case DeleteScopeVertex, DeleteScopeVertexWithEdge:
+			fn = n.deleteVertexStatement
+			prefix = "DELETE VERTEX "
+		default:
+			fn = n.deleteStatement
+			prefix = fmt.Sprintf("DELETE TAG %s FROM ", utils.ConvertIdentifier(n.Name))
+		}
+	default:
+		return nil
+	}
+	return func(b *paramBinder, records ...Record) (string, int, error) {
+		return fn(prefix, b, records...)
+	}
+}
+
+// insertPrefix returns "INSERT VERTEX name(prop_name, ..., prop_name) VALUES ".
+func (n *Node) insertPrefix(ifNotExists bool) string {
+	// default enable IGNORE_EXISTED_INDEX
+	prefix := "INSERT VERTEX"
+	if n.IgnoreExistedIndex != nil && *n.IgnoreExistedIndex {
+		prefix = "INSERT VERTEX IGNORE_EXISTED_INDEX"
+	}
+	if ifNotExists {
+		prefix = prefix + " IF NOT EXISTS"
 	}
+	insertPrefixFmt := "%s %s(%s) VALUES "
+	return fmt.Sprintf(
+		insertPrefixFmt,
+		prefix,
+		utils.ConvertIdentifier(n.Name),
+		strings.Join(n.Props.NameList(), ", "),
+	)
 }
 
 func (n *Node) Validate() error {
@@ -131,6 +288,29 @@ func (n *Node) Validate() error {
 		return n.importError(errors.ErrNoNodeID)
 	}
 
+	if err := n.ID.resolveColumns(n.header); err != nil {
+		return n.importError(err)
+	}
+
+	if err := n.Props.resolveColumns(n.header); err != nil {
+		return n.importError(err)
+	}
+
+	if n.OpColumn != nil {
+		if err := n.OpColumn.resolveColumns(n.header); err != nil {
+			return n.importError(err)
+		}
+		if err := n.OpColumn.Validate(); err != nil {
+			return n.importError(err)
+		}
+	}
+
+	// The header columns and the props picked by name are available in the value expressions.
+	columns := n.Props.columns(n.header)
+	n.Props.setExprEnv(columns, n.lookups)
+	n.ID.columns = columns
+	n.ID.lookups = n.lookups
+
 	if err := n.ID.Validate(); err != nil {
 		return n.importError(err)
 	}
@@ -140,31 +320,106 @@ func (n *Node) Validate() error {
 	}
 
 	if n.Filter != nil {
-		if err := n.Filter.Build(); err != nil {
+		if err := n.Filter.Build(specbase.WithFilterLookups(n.lookups)); err != nil {
 			return n.importError(errors.ErrFilterSyntax, "%s", err)
 		}
 	}
 
-	if !n.Mode.IsSupport() {
-		return n.importError(errors.ErrUnsupportedMode)
+	if n.Dedup != nil {
+		if err := n.Dedup.Build(); err != nil {
+			return n.importError(err)
+		}
+		if err := validateDedup(n.modes()); err != nil {
+			return n.importError(err)
+		}
+	}
+
+	switch n.DeleteScope {
+	case "", DeleteScopeTag, DeleteScopeVertex, DeleteScopeVertexWithEdge:
+	default:
+		return n.importError(errors.ErrUnsupportedDeleteScope, "unsupported delete scope %q", n.DeleteScope)
 	}
 
-	if (n.Mode == specbase.UpdateMode || n.Mode == specbase.UpsertMode) && len(n.Props) == 0 {
-		return n.importError(errors.ErrNoProps)
+	if err := validateUpsertStrategy(n.UpsertStrategy); err != nil {
+		return n.importError(err)
+	}
+
+	n.versionProp = nil
+	if n.VersionProp != "" {
+		versionProp, err := validateVersionProp(n.VersionProp, n.Props, n.modes(), n.UpsertStrategy, n.Dedup, n.ID)
+		if err != nil {
+			return n.importError(err)
+		}
+		n.versionProp = versionProp
+	}
+
+	if n.Parameterized && n.versionProp != nil {
+		return n.importError(errors.ErrInvalidConfig, "the parameterized does not work with the version prop")
+	}
+
+	for _, mode := range n.modes() {
+		if !mode.IsSupport() {
+			return n.importError(errors.ErrUnsupportedMode)
+		}
+
+		if (mode == specbase.UpdateMode || mode == specbase.UpsertMode) && len(n.Props) == 0 {
+			return n.importError(errors.ErrNoProps)
+		}
 	}
 
 	return nil
 }
 
 func (n *Node) Statement(records ...Record) (statement string, nRecord int, err error) {
-	return n.fnStatement(records...)
+	statement, nRecord, _, err = n.StatementDedup(records...)
+	return statement, nRecord, err
+}
+
+// StatementDedup is the same as Statement, and also returns the number of the records skipped as duplicates.
+func (n *Node) StatementDedup(records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	return n.statementDedup(nil, records...)
+}
+
+// StatementParam is the same as StatementDedup, but binds the prop values to the params, see Parameterized.
+func (n *Node) StatementParam(records ...Record) (statement string, params map[string]any, nRecord, nDuplicate int, err error) {
+	b := newParamBinder()
+	statement, nRecord, nDuplicate, err = n.statementDedup(b, records...)
+	if err != nil {
+		return "", nil, 0, 0, err
+	}
+	return statement, b.params, nRecord, nDuplicate, nil
+}
+
+func (n *Node) statementDedup(b *paramBinder, records ...Record) (statement string, nRecord, nDuplicate int, err error) {
+	if n.Dedup != nil {
+		records, nDuplicate, err = dedupRecords(n.Dedup, n.Filter, n.ID.Value, records)
+		if err != nil {
+			return "", 0, 0, n.importError(err)
+		}
+	}
+	statement, nRecord, err = n.fnStatement(b, records...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return statement, nRecord, nDuplicate, nil
+}
+
+// Forget forgets the VIDs of the records, so that they are not skipped as duplicates any more.
+func (n *Node) Forget(records ...Record) error {
+	if n.Dedup == nil {
+		return nil
+	}
+	if err := forgetRecords(n.Dedup, n.ID.Value, records); err != nil {
+		return n.importError(err)
+	}
+	return nil
 }
 
-func (n *Node) insertStatement(records ...Record) (statement string, nRecord int, err error) {
+func (n *Node) insertStatement(prefix string, b *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
-	buff.SetString(n.statementPrefix)
+	buff.SetString(prefix)
 
 	for _, record := range records {
 		if n.Filter != nil {
@@ -180,7 +435,7 @@ func (n *Node) insertStatement(records ...Record) (statement string, nRecord int
 		if err != nil {
 			return "", 0, n.importError(err)
 		}
-		propsValueList, err := n.Props.ValueList(record)
+		propsValueList, err := b.valueList(n.Props, record)
 		if err != nil {
 			return "", 0, n.importError(err)
 		}
@@ -205,7 +460,7 @@ func (n *Node) insertStatement(records ...Record) (statement string, nRecord int
 	return buff.String(), nRecord, nil
 }
 
-func (n *Node) updateStatement(records ...Record) (statement string, nRecord int, err error) {
+func (n *Node) updateStatement(prefix string, b *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
@@ -223,16 +478,24 @@ func (n *Node) updateStatement(records ...Record) (statement string, nRecord int
 		if err != nil {
 			return "", 0, n.importError(err)
 		}
-		propsSetValueList, err := n.Props.SetValueList(record)
+		propsSetValueList, err := b.setValueList(n.Props, record)
 		if err != nil {
 			return "", 0, n.importError(err)
 		}
 
-		// "UPDATE VERTEX ON name id SET prop_name1 = prop_value1, prop_name1 = prop_value1, ...;"
-		_, _ = buff.WriteString(n.statementPrefix)
+		var guard string
+		if n.versionProp != nil {
+			if guard, err = versionGuard(n.versionProp, record); err != nil {
+				return "", 0, n.importError(err)
+			}
+		}
+
+		// "UPDATE VERTEX ON name id SET prop_name1 = prop_value1, prop_name1 = prop_value1, ...[ WHEN guard];"
+		_, _ = buff.WriteString(prefix)
 		_, _ = buff.WriteString(idValue)
 		_, _ = buff.WriteString(" SET ")
 		_, _ = buff.WriteStringSlice(propsSetValueList, ", ")
+		_, _ = buff.WriteString(guard)
 		_, _ = buff.WriteString(";")
 
 		nRecord++
@@ -241,7 +504,7 @@ func (n *Node) updateStatement(records ...Record) (statement string, nRecord int
 	return buff.String(), nRecord, nil
 }
 
-func (n *Node) deleteStatement(records ...Record) (statement string, nRecord int, err error) {
+func (n *Node) deleteStatement(prefix string, _ *paramBinder, records ...Record) (statement string, nRecord int, err error) {
 	buff := bytebufferpool.Get()
 	defer bytebufferpool.Put(buff)
 
@@ -261,7 +524,7 @@ func (n *Node) deleteStatement(records ...Record) (statement string, nRecord int
 		}
 
 		// "DELETE TAG name FROM id;"
-		_, _ = buff.WriteString(n.statementPrefix)
+		_, _ = buff.WriteString(prefix)
 		_, _ = buff.WriteString(idValue)
 		_, _ = buff.WriteString(";")
 
@@ -271,10 +534,80 @@ func (n *Node) deleteStatement(records ...Record) (statement string, nRecord int
 	return buff.String(), nRecord, nil
 }
 
+func (n *Node) deleteVertexStatement(prefix string, _ *paramBinder, records ...Record) (statement string, nRecord int, err error) {
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	buff.SetString(prefix)
+
+	for _, record := range records {
+		if n.Filter != nil {
+			ok, err := n.Filter.Filter(record)
+			if err != nil {
+				return "", 0, n.importError(err)
+			}
+			if !ok { // skipping those return false by Filter
+				continue
+			}
+		}
+		idValue, err := n.ID.Value(record)
+		if err != nil {
+			return "", 0, n.importError(err)
+		}
+
+		if nRecord > 0 {
+			_, _ = buff.WriteString(", ")
+		}
+		_, _ = buff.WriteString(idValue)
+
+		nRecord++
+	}
+
+	if nRecord == 0 {
+		return "", 0, nil
+	}
+
+	// "DELETE VERTEX id, ..., id WITH EDGE;"
+	if n.DeleteScope == DeleteScopeVertexWithEdge {
+		_, _ = buff.WriteString(" WITH EDGE")
+	}
+	_, _ = buff.WriteString(";")
+
+	return buff.String(), nRecord, nil
+}
+
+// modes returns the modes of the records, which are routed by the OpColumn if set.
+func (n *Node) modes() []specbase.Mode {
+	if n.OpColumn != nil {
+		return n.OpColumn.Modes()
+	}
+	return []specbase.Mode{n.Mode}
+}
+
 func (n *Node) importError(err error, formatWithArgs ...any) *errors.ImportError {
 	return errors.AsOrNewImportError(err, formatWithArgs...).SetNodeName(n.Name)
 }
 
+func (ns Nodes) Clone() Nodes {
+	if ns == nil {
+		return nil
+	}
+	cpy := make(Nodes, len(ns))
+	for i := range ns {
+		cpy[i] = ns[i].Clone()
+	}
+	return cpy
+}
+
+func (ns Nodes) HasColumnNames() bool {
+	for i := range ns {
+		if ns[i].HasColumnNames() {
+			return true
+		}
+	}
+	return false
+}
+
 func (ns Nodes) Complete() {
 	for i := range ns {
 		ns[i].Complete()
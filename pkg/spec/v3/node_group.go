@@ -0,0 +1,125 @@
+package specv3
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/bytebufferpool"
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+)
+
+// Equal reports whether the ids pick the same VID from the records, it is called after validated.
+func (id *NodeID) Equal(o *NodeID) bool {
+	return id.Type == o.Type &&
+		id.Index == o.Index &&
+		reflect.DeepEqual(id.concatItems, o.concatItems) &&
+		ptrEqual(id.Function, o.Function) &&
+		id.Seed == o.Seed &&
+		id.Namespace == o.Namespace &&
+		id.Prefix == o.Prefix &&
+		ptrEqual(id.ValueExpr, o.ValueExpr)
+}
+
+// ValidateGroup validates that the nodes can be inserted together, which share the id and insert all the records.
+// The nodes must be validated before.
+func (ns Nodes) ValidateGroup() error {
+	for i, n := range ns {
+		if n.Mode != specbase.InsertMode || n.OpColumn != nil {
+			return n.importError(errors.ErrInvalidConfig, "only the tags in INSERT mode can be grouped")
+		}
+		if n.Filter != nil || n.Dedup != nil {
+			return n.importError(errors.ErrInvalidConfig, "the tags with filter or dedup can not be grouped")
+		}
+		if n.Parameterized {
+			return n.importError(errors.ErrInvalidConfig, "the parameterized tags can not be grouped")
+		}
+		if i == 0 {
+			continue
+		}
+		if !n.ID.Equal(ns[0].ID) {
+			return n.importError(errors.ErrMismatchedNodeID, "the id mismatches the id of tag %s", ns[0].Name)
+		}
+		if isTrue(n.IgnoreExistedIndex) != isTrue(ns[0].IgnoreExistedIndex) ||
+			isTrue(n.IgnoreExistedRecord) != isTrue(ns[0].IgnoreExistedRecord) {
+			return n.importError(errors.ErrInvalidConfig, "the ignoreExistedIndex and ignoreExistedRecord mismatch tag %s", ns[0].Name)
+		}
+	}
+	return nil
+}
+
+// InsertStatement inserts the tags of the nodes in one statement, the nodes must be validated by ValidateGroup.
+func (ns Nodes) InsertStatement(records ...Record) (statement string, nRecord int, err error) {
+	if len(ns) == 0 || len(records) == 0 {
+		return "", 0, nil
+	}
+
+	buff := bytebufferpool.Get()
+	defer bytebufferpool.Put(buff)
+
+	// "INSERT VERTEX name(prop_name, ..., prop_name), ..., name(prop_name, ..., prop_name) VALUES "
+	_, _ = buff.WriteString("INSERT VERTEX")
+	if isTrue(ns[0].IgnoreExistedIndex) {
+		_, _ = buff.WriteString(" IGNORE_EXISTED_INDEX")
+	}
+	if isTrue(ns[0].IgnoreExistedRecord) {
+		_, _ = buff.WriteString(" IF NOT EXISTS")
+	}
+	for i, n := range ns {
+		if i > 0 {
+			_, _ = buff.WriteString(",")
+		}
+		_, _ = buff.WriteString(" ")
+		_, _ = buff.WriteString(utils.ConvertIdentifier(n.Name))
+		_, _ = buff.WriteString("(")
+		_, _ = buff.WriteString(strings.Join(n.Props.NameList(), ", "))
+		_, _ = buff.WriteString(")")
+	}
+	_, _ = buff.WriteString(" VALUES ")
+
+	for _, record := range records {
+		idValue, err := ns[0].ID.Value(record)
+		if err != nil {
+			return "", 0, ns[0].importError(err)
+		}
+
+		if nRecord > 0 {
+			_, _ = buff.WriteString(", ")
+		}
+
+		// id:(prop_value1, ..., prop_value1, prop_value2, ...)
+		_, _ = buff.WriteString(idValue)
+		_, _ = buff.WriteString(":(")
+		nValue := 0
+		for _, n := range ns {
+			propsValueList, err := n.Props.ValueList(record)
+			if err != nil {
+				return "", 0, n.importError(err)
+			}
+			for _, value := range propsValueList {
+				if nValue > 0 {
+					_, _ = buff.WriteString(", ")
+				}
+				_, _ = buff.WriteString(value)
+				nValue++
+			}
+		}
+		_, _ = buff.WriteString(")")
+
+		nRecord++
+	}
+
+	return buff.String(), nRecord, nil
+}
+
+func ptrEqual[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
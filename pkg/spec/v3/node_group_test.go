@@ -0,0 +1,119 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nodes group", func() {
+	newNode := func(name string, id *NodeID, opts ...NodeOption) *Node {
+		options := []NodeOption{
+			WithNodeID(id),
+			WithNodeMode(specbase.InsertMode),
+		}
+		node := NewNode(name, append(options, opts...)...)
+		node.Complete()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+		return node
+	}
+
+	It("InsertStatement", func() {
+		nodes := Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeInt, Index: 0},
+				WithNodeProps(
+					&Prop{Name: "p1", Type: ValueTypeString, Index: 1},
+					&Prop{Name: "p2", Type: ValueTypeInt, Index: 2},
+				),
+			),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			newNode("t3", &NodeID{Name: "id", Type: ValueTypeInt, Index: 0},
+				WithNodeProps(&Prop{Name: "p3", Type: ValueTypeString, Index: 3}),
+			),
+		}
+		Expect(nodes.ValidateGroup()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := nodes.InsertStatement([]string{"1", "a", "1", "x"}, []string{"2", "b", "2", "y"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT VERTEX `t1`(`p1`, `p2`), `t2`(), `t3`(`p3`) VALUES " +
+			"1:(\"a\", 1, \"x\"), 2:(\"b\", 2, \"y\")"))
+
+		statement, nRecord, err = nodes.InsertStatement()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(Equal(""))
+
+		statement, nRecord, err = nodes.InsertStatement([]string{"1", "a", "1"})
+		Expect(err).To(HaveOccurred())
+		importError, ok := errors.AsImportError(err)
+		Expect(ok).To(BeTrue())
+		Expect(importError.NodeName()).To(Equal("t3"))
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(Equal(""))
+
+		graph := NewGraph("graph", WithGraphNodes(nodes...))
+		statement, nRecord, err = graph.NodesStatementBuilder(nodes).Build([]string{"1", "a", "1", "x"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("INSERT VERTEX `t1`(`p1`, `p2`), `t2`(), `t3`(`p3`) VALUES 1:(\"a\", 1, \"x\")"))
+
+		_, _, err = graph.NodesStatement(nodes, []string{})
+		Expect(err).To(HaveOccurred())
+		importError, ok = errors.AsImportError(err)
+		Expect(ok).To(BeTrue())
+		Expect(importError.GraphName()).To(Equal("graph"))
+	})
+
+	It("InsertStatement with ignore", func() {
+		nodes := Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}, WithNodeIgnoreExistedIndex(true)),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}, WithNodeIgnoreExistedIndex(true)),
+		}
+		Expect(nodes.ValidateGroup()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := nodes.InsertStatement([]string{"a"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("INSERT VERTEX IGNORE_EXISTED_INDEX `t1`(), `t2`() VALUES \"a\":()"))
+	})
+
+	DescribeTable("ValidateGroup",
+		func(nodes Nodes, expectErr error) {
+			err := nodes.ValidateGroup()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("empty", Nodes{}, nil),
+		Entry("same id", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, ConcatItems: []any{0, "-", 1}}),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeString, ConcatItems: []any{0, "-", 1}}),
+		}, nil),
+		Entry("mismatched index", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeString, Index: 1}),
+		}, errors.ErrMismatchedNodeID),
+		Entry("mismatched function", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeString, Index: 0, Function: &[]string{"hash"}[0]}),
+		}, errors.ErrMismatchedNodeID),
+		Entry("mismatched ignore", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}),
+			newNode("t2", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}, WithNodeIgnoreExistedIndex(true)),
+		}, errors.ErrInvalidConfig),
+		Entry("not insert", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}, WithNodeMode(specbase.DeleteMode)),
+		}, errors.ErrInvalidConfig),
+		Entry("filter", Nodes{
+			newNode("t1", &NodeID{Name: "id", Type: ValueTypeString, Index: 0}, WithNodeFilter(&specbase.Filter{Expr: "true"})),
+		}, errors.ErrInvalidConfig),
+	)
+})
@@ -4,6 +4,7 @@ import (
 	stderrors "errors"
 
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
 	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -117,6 +118,91 @@ var _ = Describe("Node", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("value expr syntax failed", func() {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeString}),
+				WithNodeProps(&Prop{
+					Name:      "prop",
+					Type:      ValueTypeString,
+					ValueExpr: func() *string { s := "unknown + 1"; return &s }(),
+				}),
+			)
+			node.Complete()
+			err := node.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrValueExprSyntax)).To(BeTrue())
+		})
+
+		It("value expr with named columns", func() {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{
+					Name:      "id",
+					Type:      ValueTypeString,
+					ValueExpr: func() *string { s := `"user-" + lower(name)`; return &s }(),
+				}),
+				WithNodeProps(
+					&Prop{Name: "name", Type: ValueTypeString, Index: 1},
+					&Prop{
+						Name:      "age",
+						Type:      ValueTypeInt,
+						ValueExpr: func() *string { s := "int(Record[2]) + 1"; return &s }(),
+					},
+				),
+				WithNodeMode(specbase.InsertMode),
+			)
+			node.Complete()
+			err := node.Validate()
+			Expect(err).NotTo(HaveOccurred())
+
+			statement, nRecord, err := node.Statement([]string{"1", "Tom", "17"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nRecord).To(Equal(1))
+			Expect(statement).To(Equal("INSERT VERTEX `name`(`name`, `age`) VALUES \"user-tom\":(\"Tom\", 18)"))
+		})
+
+		It("value expr and filter with lookups", func() {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+				WithNodeProps(&Prop{
+					Name:      "name",
+					Type:      ValueTypeString,
+					ValueExpr: func() *string { s := `lookup("users", Record[1])`; return &s }(),
+				}),
+				WithNodeFilter(&specbase.Filter{
+					Expr: `lookup("users", Record[1]) != nil`,
+				}),
+				WithNodeLookups(picker.Lookups{"users": testLookup{"u1": "Tom"}}),
+				WithNodeMode(specbase.InsertMode),
+			)
+			node.Complete()
+			err := node.Validate()
+			Expect(err).NotTo(HaveOccurred())
+
+			statement, nRecord, err := node.Statement([]string{"1", "u1"}, []string{"2", "u2"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(nRecord).To(Equal(1))
+			Expect(statement).To(Equal("INSERT VERTEX `name`(`name`) VALUES 1:(\"Tom\")"))
+		})
+
+		It("unknown lookup", func() {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{
+					Name:      "id",
+					Type:      ValueTypeString,
+					ValueExpr: func() *string { s := `lookup("groups", Record[0])`; return &s }(),
+				}),
+				WithNodeLookups(picker.Lookups{"users": testLookup{}}),
+			)
+			node.Complete()
+			err := node.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(stderrors.Is(err, errors.ErrUnknownLookup)).To(BeTrue())
+		})
+
 		It("success with props", func() {
 			node := NewNode(
 				"name",
@@ -645,6 +731,82 @@ var _ = Describe("Node", func() {
 					Expect(statement).To(Equal(""))
 				})
 			})
+
+			When("WithNodeDeleteScope", func() {
+				newNode := func(scope string) *Node {
+					node := NewNode(
+						"name",
+						WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+						WithNodeProps(
+							&Prop{Name: "prop1", Type: ValueTypeString, Index: 1},
+						),
+						WithNodeFilter(&specbase.Filter{
+							Expr: `Record[1] != "A"`,
+						}),
+						WithNodeMode(specbase.DeleteMode),
+						WithNodeDeleteScope(scope),
+					)
+					node.Complete()
+					return node
+				}
+
+				It("tag", func() {
+					node := newNode(DeleteScopeTag)
+					Expect(node.Validate()).NotTo(HaveOccurred())
+
+					statement, nRecord, err := node.Statement([]string{"1", "B"}, []string{"2", "C"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(2))
+					Expect(statement).To(Equal("DELETE TAG `name` FROM 1;DELETE TAG `name` FROM 2;"))
+				})
+
+				It("vertex", func() {
+					node := newNode(DeleteScopeVertex)
+					Expect(node.Validate()).NotTo(HaveOccurred())
+
+					statement, nRecord, err := node.Statement([]string{"1", "B"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(1))
+					Expect(statement).To(Equal("DELETE VERTEX 1;"))
+
+					statement, nRecord, err = node.Statement([]string{"1", "B"}, []string{"2", "A"}, []string{"3", "D"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(2))
+					Expect(statement).To(Equal("DELETE VERTEX 1, 3;"))
+
+					statement, nRecord, err = node.Statement([]string{"2", "A"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(0))
+					Expect(statement).To(Equal(""))
+
+					statement, nRecord, err = node.Statement([]string{"1", "B"}, []string{"x"})
+					Expect(err).To(HaveOccurred())
+					Expect(nRecord).To(Equal(0))
+					Expect(statement).To(Equal(""))
+				})
+
+				It("vertexWithEdge", func() {
+					node := newNode(DeleteScopeVertexWithEdge)
+					Expect(node.Validate()).NotTo(HaveOccurred())
+
+					statement, nRecord, err := node.Statement([]string{"1", "B"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(1))
+					Expect(statement).To(Equal("DELETE VERTEX 1 WITH EDGE;"))
+
+					statement, nRecord, err = node.Statement([]string{"1", "B"}, []string{"2", "C"}, []string{"3", "D"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(nRecord).To(Equal(3))
+					Expect(statement).To(Equal("DELETE VERTEX 1, 2, 3 WITH EDGE;"))
+				})
+
+				It("unsupported", func() {
+					node := newNode("edge")
+					err := node.Validate()
+					Expect(err).To(HaveOccurred())
+					Expect(stderrors.Is(err, errors.ErrUnsupportedDeleteScope)).To(BeTrue())
+				})
+			})
 		})
 	})
 })
@@ -719,3 +881,12 @@ var _ = Describe("Nodes", func() {
 		),
 	)
 })
+
+type testLookup map[string]string
+
+func (l testLookup) Lookup(key string) (any, error) {
+	if v, ok := l[key]; ok {
+		return v, nil
+	}
+	return nil, nil
+}
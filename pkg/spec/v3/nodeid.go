@@ -8,7 +8,11 @@ import (
 )
 
 var supportedNodeIDFunctions = map[string]struct{}{
-	"HASH": {},
+	"HASH":     {},
+	"XXHASH64": {},
+	"MURMUR3":  {},
+	"UUIDV5":   {},
+	"PREFIX":   {},
 }
 
 type (
@@ -17,10 +21,18 @@ type (
 		Name        string        `yaml:"-" json:"-"`
 		Type        ValueType     `yaml:"type" json:"type"`
 		Index       int           `yaml:"index" json:"index"`
-		ConcatItems []interface{} `yaml:"concatItems,omitempty" json:"concatItems,omitempty,optional"` // only support string and int, string for constant, int is for Index
+		Column      string        `yaml:"column,omitempty" json:"column,omitempty,optional"`
+		ConcatItems []interface{} `yaml:"concatItems,omitempty" json:"concatItems,omitempty,optional"` // only support string, int and {column: name}, string for constant, int is for Index
 		Function    *string       `yaml:"function" json:"function,omitempty,optional"`
+		Seed        uint64        `yaml:"seed,omitempty" json:"seed,omitempty,optional"`           // the seed of xxhash64 and murmur3
+		Namespace   string        `yaml:"namespace,omitempty" json:"namespace,omitempty,optional"` // the namespace of uuidv5
+		Prefix      string        `yaml:"prefix,omitempty" json:"prefix,omitempty,optional"`       // the prefix of prefix
+		ValueExpr   *string       `yaml:"valueExpr,omitempty" json:"valueExpr,omitempty,optional"`
 
-		picker picker.Picker
+		columns     map[string]int
+		lookups     picker.Lookups
+		concatItems []any // the concat items with the column names resolved
+		picker      picker.Picker
 	}
 )
 
@@ -29,6 +41,51 @@ func IsSupportedNodeIDFunction(function string) bool {
 	return ok
 }
 
+func (id *NodeID) Clone() *NodeID {
+	cpy := *id
+	return &cpy
+}
+
+// HasColumnNames reports whether the id addresses the columns by name.
+func (id *NodeID) HasColumnNames() bool {
+	if id.Column != "" {
+		return true
+	}
+	for _, item := range id.ConcatItems {
+		if _, ok := concatItemColumn(item); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveColumns resolves the column names to the indices by the header.
+func (id *NodeID) resolveColumns(header Header) error {
+	if id.Column != "" {
+		index, err := header.resolve(id.Column)
+		if err != nil {
+			return id.importError(err)
+		}
+		id.Index = index
+	}
+	id.concatItems = id.ConcatItems
+	if len(id.ConcatItems) > 0 {
+		concatItems := make([]any, len(id.ConcatItems))
+		for i, item := range id.ConcatItems {
+			concatItems[i] = item
+			if name, ok := concatItemColumn(item); ok {
+				index, err := header.resolve(name)
+				if err != nil {
+					return id.importError(err)
+				}
+				concatItems[i] = index
+			}
+		}
+		id.concatItems = concatItems
+	}
+	return nil
+}
+
 func (id *NodeID) Complete() {
 	if id.Type == "" {
 		id.Type = ValueTypeDefault
@@ -55,6 +112,9 @@ func (id *NodeID) Validate() error {
 func (id *NodeID) Value(record Record) (string, error) {
 	val, err := id.picker.Pick(record)
 	if err != nil {
+		if id.ValueExpr != nil {
+			return "", id.importError(err, "record value expr %q pick failed", *id.ValueExpr).SetRecord(record)
+		}
 		if len(id.ConcatItems) > 0 {
 			return "", id.importError(err, "record concat items %v pick failed", id.ConcatItems).SetRecord(record)
 		}
@@ -66,12 +126,24 @@ func (id *NodeID) Value(record Record) (string, error) {
 
 func (id *NodeID) initPicker() error {
 	pickerConfig := picker.Config{
-		Type:     string(id.Type),
-		Function: id.Function,
+		ValueExpr: id.ValueExpr,
+		Columns:   id.columns,
+		Lookups:   id.lookups,
+		Type:      string(id.Type),
+		Function:  id.Function,
+		FunctionOptions: picker.FunctionOptions{
+			Seed:      id.Seed,
+			Namespace: id.Namespace,
+			Prefix:    id.Prefix,
+		},
 	}
 
-	if len(id.ConcatItems) > 0 {
-		pickerConfig.ConcatItems = id.ConcatItems
+	concatItems := id.concatItems
+	if concatItems == nil {
+		concatItems = id.ConcatItems
+	}
+	if len(concatItems) > 0 {
+		pickerConfig.ConcatItems = concatItems
 	} else {
 		pickerConfig.Indices = []int{id.Index}
 	}
@@ -40,7 +40,10 @@ var _ = Describe("NodeID", func() {
 				patches := gomonkey.NewPatches()
 				defer patches.Reset()
 				patches.ApplyGlobalVar(&supportedNodeIDFunctions, map[string]struct{}{
-					"HASH": {},
+					"HASH":     {},
+					"XXHASH64": {},
+					"UUIDV5":   {},
+					"PREFIX":   {},
 				})
 			}
 
@@ -144,6 +147,30 @@ var _ = Describe("NodeID", func() {
 			"hash(\"c1s3c2s1s2c3s0\")",
 			nil,
 		),
+		Entry("Function xxhash64",
+			&NodeID{
+				Name:     "id",
+				Type:     ValueTypeInt,
+				Index:    1,
+				Function: func() *string { s := "xxhash64"; return &s }(),
+				Seed:     42,
+			},
+			Record([]string{"s0", "hello"}),
+			"-4367754540140381902",
+			nil,
+		),
+		Entry("Function prefix",
+			&NodeID{
+				Name:     "id",
+				Type:     ValueTypeString,
+				Index:    0,
+				Function: func() *string { s := "prefix"; return &s }(),
+				Prefix:   "user_",
+			},
+			Record([]string{"1"}),
+			"\"user_1\"",
+			nil,
+		),
 		Entry("unsupported value type",
 			&NodeID{
 				Name:  "id",
@@ -165,5 +192,16 @@ var _ = Describe("NodeID", func() {
 			nil,
 			errors.ErrUnsupportedFunction,
 		),
+		Entry("invalid function option",
+			&NodeID{
+				Name:     "id",
+				Type:     ValueTypeString,
+				Index:    0,
+				Function: func() *string { s := "uuidv5"; return &s }(),
+			},
+			Record([]string{"1"}),
+			nil,
+			errors.ErrInvalidFunctionOption,
+		),
 	)
 })
@@ -0,0 +1,142 @@
+package specv3
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+)
+
+type (
+	// OpColumn routes each record to the mode by the value of the column, such as the operation of a change feed.
+	OpColumn struct {
+		Index  int    `yaml:"index" json:"index"`
+		Column string `yaml:"column,omitempty" json:"column,omitempty,optional"`
+		// The modes by the values, `I`, `U` and `D` to INSERT, UPDATE and DELETE by default,
+		// and the mode names themselves, such as the `__op` column of the cdc reader.
+		Mapping map[string]specbase.Mode `yaml:"mapping,omitempty" json:"mapping,omitempty,optional"`
+	}
+)
+
+func defaultOpMapping() map[string]specbase.Mode {
+	return map[string]specbase.Mode{
+		"I": specbase.InsertMode,
+		"U": specbase.UpdateMode,
+		"D": specbase.DeleteMode,
+
+		string(specbase.InsertMode): specbase.InsertMode,
+		string(specbase.UpdateMode): specbase.UpdateMode,
+		string(specbase.UpsertMode): specbase.UpsertMode,
+		string(specbase.DeleteMode): specbase.DeleteMode,
+	}
+}
+
+func (c *OpColumn) Clone() *OpColumn {
+	cpy := *c
+	return &cpy
+}
+
+// HasColumnNames reports whether the op column is addressed by name.
+func (c *OpColumn) HasColumnNames() bool {
+	return c.Column != ""
+}
+
+// resolveColumns resolves the column name to the index by the header.
+func (c *OpColumn) resolveColumns(header Header) error {
+	if c.Column != "" {
+		index, err := header.resolve(c.Column)
+		if err != nil {
+			return err
+		}
+		c.Index = index
+	}
+	return nil
+}
+
+func (c *OpColumn) Complete() {
+	if len(c.Mapping) == 0 {
+		c.Mapping = defaultOpMapping()
+		return
+	}
+	mapping := make(map[string]specbase.Mode, len(c.Mapping))
+	for value, mode := range c.Mapping {
+		mapping[value] = mode.Convert()
+	}
+	c.Mapping = mapping
+}
+
+func (c *OpColumn) Validate() error {
+	if c.Index < 0 {
+		return errors.NewImportError(errors.ErrInvalidIndex, "invalid op column index %d", c.Index)
+	}
+	for value, mode := range c.Mapping {
+		if !mode.IsSupport() {
+			return errors.NewImportError(errors.ErrUnsupportedMode, "unsupported mode %q of op %q", mode, value)
+		}
+	}
+	return nil
+}
+
+// Modes returns the distinct modes in the mapping, in order.
+func (c *OpColumn) Modes() []specbase.Mode {
+	modes := make([]specbase.Mode, 0, len(c.Mapping))
+	seen := make(map[specbase.Mode]struct{}, len(c.Mapping))
+	for _, mode := range c.Mapping {
+		if _, ok := seen[mode]; !ok {
+			seen[mode] = struct{}{}
+			modes = append(modes, mode)
+		}
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	return modes
+}
+
+// Mode returns the mode of the record.
+func (c *OpColumn) Mode(record Record) (specbase.Mode, error) {
+	if c.Index >= len(record) {
+		return "", errors.NewImportError(errors.ErrNoRecord, "record index %d pick failed", c.Index).SetRecord(record)
+	}
+	mode, ok := c.Mapping[record[c.Index]]
+	if !ok {
+		return "", errors.NewImportError(errors.ErrUnknownOperation, "unknown op %q", record[c.Index]).SetRecord(record)
+	}
+	return mode, nil
+}
+
+// opStatement builds the statements of the records by their modes, the consecutive records of the same mode are
+// built into one statement, so the order of the records is kept, and so is the order of the operations on a key.
+func opStatement(
+	c *OpColumn,
+	fnModeStatements map[specbase.Mode]statementFunc,
+	b *paramBinder,
+	records ...Record,
+) (statement string, nRecord int, err error) {
+	modes := make([]specbase.Mode, len(records))
+	for i, record := range records {
+		if modes[i], err = c.Mode(record); err != nil {
+			return "", 0, err
+		}
+	}
+
+	var statements []string
+	for start := 0; start < len(records); {
+		end := start + 1
+		for end < len(records) && modes[end] == modes[start] {
+			end++
+		}
+		s, n, err := fnModeStatements[modes[start]](b, records[start:end]...)
+		if err != nil {
+			return "", 0, err
+		}
+		if n > 0 {
+			statements = append(statements, strings.TrimSuffix(s, ";"))
+			nRecord += n
+		}
+		start = end
+	}
+	if nRecord == 0 {
+		return "", 0, nil
+	}
+	return strings.Join(statements, ";") + ";", nRecord, nil
+}
@@ -0,0 +1,177 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OpColumn", func() {
+	It(".Complete", func() {
+		c := &OpColumn{}
+		c.Complete()
+		Expect(c.Mapping).To(Equal(map[string]specbase.Mode{
+			"I": specbase.InsertMode,
+			"U": specbase.UpdateMode,
+			"D": specbase.DeleteMode,
+
+			"INSERT": specbase.InsertMode,
+			"UPDATE": specbase.UpdateMode,
+			"UPSERT": specbase.UpsertMode,
+			"DELETE": specbase.DeleteMode,
+		}))
+		Expect(c.Modes()).To(Equal([]specbase.Mode{specbase.DeleteMode, specbase.InsertMode, specbase.UpdateMode, specbase.UpsertMode}))
+
+		c = &OpColumn{Mapping: map[string]specbase.Mode{"c": "insert", "r": "insert", "u": "upsert"}}
+		c.Complete()
+		Expect(c.Mapping).To(Equal(map[string]specbase.Mode{
+			"c": specbase.InsertMode,
+			"r": specbase.InsertMode,
+			"u": specbase.UpsertMode,
+		}))
+		Expect(c.Modes()).To(Equal([]specbase.Mode{specbase.InsertMode, specbase.UpsertMode}))
+	})
+
+	DescribeTable(".Validate",
+		func(c *OpColumn, expectErr error) {
+			c.Complete()
+			err := c.Validate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("default", &OpColumn{}, nil),
+		Entry("invalid index", &OpColumn{Index: -1}, errors.ErrInvalidIndex),
+		Entry("unsupported mode", &OpColumn{Mapping: map[string]specbase.Mode{"I": "MERGE"}}, errors.ErrUnsupportedMode),
+	)
+
+	DescribeTable(".Mode",
+		func(record Record, expectMode specbase.Mode, expectErr error) {
+			c := &OpColumn{Index: 1}
+			c.Complete()
+			mode, err := c.Mode(record)
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+			Expect(mode).To(Equal(expectMode))
+		},
+		Entry("insert", Record{"1", "I"}, specbase.InsertMode, nil),
+		Entry("delete", Record{"1", "D"}, specbase.DeleteMode, nil),
+		Entry("mode name", Record{"1", "UPSERT"}, specbase.UpsertMode, nil),
+		Entry("no record", Record{"1"}, specbase.Mode(""), errors.ErrNoRecord),
+		Entry("unknown", Record{"1", "X"}, specbase.Mode(""), errors.ErrUnknownOperation),
+	)
+})
+
+var _ = Describe("opColumn", func() {
+	It("node", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Column: "id"}),
+			WithNodeProps(&Prop{Name: "prop1", Type: ValueTypeString, Column: "name"}),
+			WithNodeOpColumn(&OpColumn{Column: "op"}),
+			WithNodeHeader(NewHeader([]string{"op", "id", "name"})),
+		)
+		Expect(node.HasColumnNames()).To(BeTrue())
+		node.Complete()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := node.Statement(
+			[]string{"I", "1", "a"},
+			[]string{"I", "2", "b"},
+			[]string{"U", "1", "c"},
+			[]string{"D", "2", ""},
+			[]string{"I", "2", "d"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(5))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`prop1`) VALUES 1:(\"a\"), 2:(\"b\");" +
+			"UPDATE VERTEX ON `name` 1 SET `prop1` = \"c\";" +
+			"DELETE TAG `name` FROM 2;" +
+			"INSERT VERTEX `name`(`prop1`) VALUES 2:(\"d\");"))
+
+		statement, nRecord, err = node.Statement([]string{"D", "1", ""})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("DELETE TAG `name` FROM 1;"))
+
+		statement, nRecord, err = node.Statement([]string{"I", "1", "a"}, []string{"X", "2", "b"})
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnknownOperation)).To(BeTrue())
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(Equal(""))
+	})
+
+	It("node with filter", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 1}),
+			WithNodeOpColumn(&OpColumn{Index: 0, Mapping: map[string]specbase.Mode{"c": "INSERT", "d": "DELETE"}}),
+			WithNodeFilter(&specbase.Filter{Expr: `Record[1] != "2"`}),
+		)
+		node.Complete()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := node.Statement([]string{"d", "2"}, []string{"c", "2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(statement).To(Equal(""))
+
+		statement, nRecord, err = node.Statement([]string{"d", "1"}, []string{"c", "2"}, []string{"c", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("DELETE TAG `name` FROM 1;INSERT VERTEX `name`() VALUES 3:();"))
+	})
+
+	It("node update without props", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 1}),
+			WithNodeOpColumn(&OpColumn{Index: 0}),
+		)
+		node.Complete()
+		err := node.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrNoProps)).To(BeTrue())
+	})
+
+	It("edge", func() {
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+			WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 2}}),
+			WithEdgeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 3}),
+			WithEdgeOpColumn(&OpColumn{Index: 0, Mapping: map[string]specbase.Mode{"c": "INSERT", "u": "UPSERT", "d": "DELETE"}}),
+		)
+		edge.Complete()
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement(
+			[]string{"c", "1", "2", "a"},
+			[]string{"u", "1", "2", "b"},
+			[]string{"d", "1", "2", ""},
+			[]string{"d", "2", "3", ""},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(4))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`prop1`) VALUES 1->2:(\"a\");" +
+			"UPSERT EDGE ON `name` 1->2 SET `prop1` = \"b\";" +
+			"DELETE EDGE `name` 1->2, 2->3;"))
+
+		_, _, err = edge.Statement([]string{"c", "1", "2"})
+		Expect(err).To(HaveOccurred())
+		importError, ok := errors.AsImportError(err)
+		Expect(ok).To(BeTrue())
+		Expect(importError.EdgeName()).To(Equal("name"))
+	})
+})
@@ -0,0 +1,81 @@
+package specv3
+
+import (
+	"strconv"
+	"strings"
+)
+
+type (
+	// statementFunc builds the statement of the records, and binds the prop values to the params if the binder is not nil.
+	statementFunc func(b *paramBinder, records ...Record) (string, int, error)
+
+	// paramBinder binds the prop values to the params named p0, p1, ..., which are referred as $p0, $p1, ...
+	// in the statement. The nil binder keeps the values as the literals.
+	paramBinder struct {
+		params map[string]any
+	}
+)
+
+func newParamBinder() *paramBinder {
+	return &paramBinder{
+		params: make(map[string]any),
+	}
+}
+
+// valueList is the same as Props.ValueList, but the values are bound to the params.
+func (b *paramBinder) valueList(ps Props, record Record) ([]string, error) {
+	valueList, err := ps.ValueList(record)
+	if err != nil || b == nil {
+		return valueList, err
+	}
+	for i, prop := range ps {
+		valueList[i] = b.bind(prop, valueList[i])
+	}
+	return valueList, nil
+}
+
+// setValueList is the same as Props.SetValueList, but the values are bound to the params.
+func (b *paramBinder) setValueList(ps Props, record Record) ([]string, error) {
+	if b == nil {
+		return ps.SetValueList(record)
+	}
+	return ps.setValueList(record, b.bind)
+}
+
+// bind binds the value of the prop to a new param, and returns the reference of the param.
+// The value is kept as it is if it can not be bound, such as the function calls of the temporal and geography types.
+func (b *paramBinder) bind(p *Prop, value string) string {
+	param, ok := paramValue(p.Type, value)
+	if !ok {
+		return value
+	}
+	name := "p" + strconv.Itoa(len(b.params))
+	b.params[name] = param
+	return "$" + name
+}
+
+// paramValue returns the typed value of the nGQL literal, false if the literal is not a plain value of the type.
+func paramValue(t ValueType, literal string) (any, bool) {
+	if literal == dbNULL {
+		return nil, true
+	}
+	switch ValueType(strings.ToUpper(string(t))) {
+	case ValueTypeBool:
+		v, err := strconv.ParseBool(literal)
+		return v, err == nil
+	case ValueTypeInt, ValueTypeInt8, ValueTypeInt16, ValueTypeInt32, ValueTypeInt64:
+		v, err := strconv.ParseInt(literal, 10, 64)
+		return v, err == nil
+	case ValueTypeFloat, ValueTypeDouble:
+		v, err := strconv.ParseFloat(literal, 64)
+		return v, err == nil
+	case ValueTypeString, ValueTypeFixedString:
+		// The strings are quoted by strconv.Quote, but not the ones wrapped by the functions.
+		if !strings.HasPrefix(literal, `"`) {
+			return nil, false
+		}
+		v, err := strconv.Unquote(literal)
+		return v, err == nil
+	}
+	return nil, false
+}
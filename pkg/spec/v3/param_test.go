@@ -0,0 +1,208 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Parameterized", func() {
+	newNode := func(opts ...NodeOption) *Node {
+		node := NewNode(
+			"name",
+			append([]NodeOption{
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeString, Index: 0}),
+				WithNodeProps(
+					&Prop{Name: "str", Type: ValueTypeString, Index: 1},
+					&Prop{Name: "int", Type: ValueTypeInt, Index: 2},
+					&Prop{Name: "date", Type: ValueTypeDate, Index: 3},
+				),
+				WithNodeMode(specbase.InsertMode),
+				WithNodeParameterized(true),
+			}, opts...)...,
+		)
+		node.Complete()
+		return node
+	}
+	newEdge := func(opts ...EdgeOption) *Edge {
+		edge := NewEdge(
+			"name",
+			append([]EdgeOption{
+				WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+				WithEdgeProps(
+					&Prop{Name: "weight", Type: ValueTypeDouble, Index: 2},
+					&Prop{Name: "note", Type: ValueTypeString, Index: 3, Nullable: true},
+				),
+				WithEdgeMode(specbase.InsertMode),
+				WithEdgeParameterized(true),
+			}, opts...)...,
+		)
+		edge.Complete()
+		return edge
+	}
+
+	DescribeTable("paramValue",
+		func(t ValueType, literal string, expectParam any, expectOK bool) {
+			param, ok := paramValue(t, literal)
+			Expect(ok).To(Equal(expectOK))
+			switch {
+			case !expectOK:
+			case expectParam == nil:
+				Expect(param).To(BeNil())
+			default:
+				Expect(param).To(Equal(expectParam))
+			}
+		},
+		Entry("NULL", ValueTypeInt, "NULL", nil, true),
+		Entry("BOOL", ValueTypeBool, "true", true, true),
+		Entry("INT", ValueTypeInt64, "-12", int64(-12), true),
+		Entry("INT hex", ValueTypeInt, "0x1F", nil, false),
+		Entry("DOUBLE", ValueTypeDouble, "1.0", float64(1), true),
+		Entry("STRING", ValueTypeString, `"a\"b\\c"`, `a"b\c`, true),
+		Entry("string lowercase type", ValueType("string"), `"a"`, "a", true),
+		Entry("STRING by function", ValueTypeString, `hash("a")`, nil, false),
+		Entry("DATE", ValueTypeDate, `DATE("2020-01-01")`, nil, false),
+	)
+
+	DescribeTable(".Validate",
+		func(fnValidate func() error, expectErr error) {
+			err := fnValidate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("node", func() error {
+			return newNode().Validate()
+		}, nil),
+		Entry("node with version prop", func() error {
+			return newNode(WithNodeMode(specbase.UpsertMode), WithNodeVersionProp("int")).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("edge", func() error {
+			return newEdge().Validate()
+		}, nil),
+		Entry("edge with endpoints verify", func() error {
+			return newEdge(
+				WithEdgeEndpoints(EndpointsVerify),
+				WithEdgeSrc(&EdgeNodeRef{Tag: "player", ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{Tag: "player", ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+			).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("group", func() error {
+			node := newNode()
+			if err := node.Validate(); err != nil {
+				return err
+			}
+			return Nodes{node}.ValidateGroup()
+		}, errors.ErrInvalidConfig),
+	)
+
+	It("node", func() {
+		node := newNode()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+		records := []Record{
+			{"a", "x\ty", "1", "2020-01-01"},
+			{"b", "z", "2", "2020-01-02"},
+		}
+
+		statement, params, nRecord, nDuplicate, err := node.StatementParam(records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(nDuplicate).To(Equal(0))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`str`, `int`, `date`) VALUES " +
+			"\"a\":($p0, $p1, DATE(\"2020-01-01\")), \"b\":($p2, $p3, DATE(\"2020-01-02\"))"))
+		Expect(params).To(Equal(map[string]any{
+			"p0": "x\ty",
+			"p1": int64(1),
+			"p2": "z",
+			"p3": int64(2),
+		}))
+
+		// The statement without params is not changed.
+		statement, nRecord, err = node.Statement(records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`str`, `int`, `date`) VALUES " +
+			"\"a\":(\"x\\ty\", 1, DATE(\"2020-01-01\")), \"b\":(\"z\", 2, DATE(\"2020-01-02\"))"))
+
+		_, _, _, _, err = node.StatementParam(Record{"a"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("node with op column", func() {
+		node := newNode(WithNodeOpColumn(&OpColumn{Index: 4}))
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		statement, params, nRecord, _, err := node.StatementParam(
+			Record{"a", "x", "1", "2020-01-01", "U"},
+			Record{"b", "y", "2", "2020-01-02", "D"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("UPDATE VERTEX ON `name` \"a\" SET `str` = $p0, `int` = $p1, `date` = DATE(\"2020-01-01\");" +
+			"DELETE TAG `name` FROM \"b\";"))
+		Expect(params).To(Equal(map[string]any{
+			"p0": "x",
+			"p1": int64(1),
+		}))
+	})
+
+	It("edge", func() {
+		edge := newEdge(WithEdgeDirection(DirectionBoth, "reversed"), WithEdgeMode(specbase.UpsertMode))
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, params, nRecord, _, err := edge.StatementParam(Record{"1", "2", "3", ""})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("UPSERT EDGE ON `name` 1->2 SET `weight` = $p0, `note` = $p1;" +
+			"UPSERT EDGE ON `reversed` 2->1 SET `weight` = $p2, `note` = $p3;"))
+		Expect(params).To(Equal(map[string]any{
+			"p0": float64(3),
+			"p1": nil,
+			"p2": float64(3),
+			"p3": nil,
+		}))
+	})
+
+	It("graph builders", func() {
+		node := newNode(WithNodeDedup(&specbase.Dedup{}))
+		edge := newEdge()
+		graph := NewGraph("graph", WithGraphNodes(node), WithGraphEdges(edge))
+		graph.Complete()
+		Expect(graph.Validate()).NotTo(HaveOccurred())
+
+		nb, ok := graph.NodeStatementBuilder(node).(specbase.ParamStatementBuilder)
+		Expect(ok).To(BeTrue())
+		statement, params, nRecord, nDuplicate, err := nb.BuildParam(
+			Record{"a", "x", "1", "2020-01-01"},
+			Record{"a", "x", "1", "2020-01-01"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nDuplicate).To(Equal(1))
+		Expect(statement).To(HavePrefix("INSERT VERTEX `name`"))
+		Expect(params).To(HaveLen(2))
+		Expect(nb.(specbase.DedupStatementBuilder).Forget(Record{"a"})).NotTo(HaveOccurred())
+		_, nRecord, err = nb.Build(Record{"a", "x", "1", "2020-01-01"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+
+		eb, ok := graph.EdgeStatementBuilder(edge).(specbase.ParamStatementBuilder)
+		Expect(ok).To(BeTrue())
+		statement, params, nRecord, _, err = eb.BuildParam(Record{"1", "2", "3.5", "n"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`weight`, `note`) VALUES 1->2:($p0, $p1)"))
+		Expect(params).To(Equal(map[string]any{"p0": 3.5, "p1": "n"}))
+		_, _, _, _, err = eb.BuildParam(Record{"1"})
+		Expect(err).To(HaveOccurred())
+		_, _, err = eb.Build(Record{"1", "2", "3.5", "n"})
+		Expect(err).NotTo(HaveOccurred())
+	})
+})
@@ -1,6 +1,8 @@
 package specv3
 
 import (
+	"strings"
+
 	"github.com/expr-lang/expr"
 	"github.com/expr-lang/expr/vm"
 	"github.com/lucky-xin/nebula-importer/pkg/errors"
@@ -13,13 +15,21 @@ type (
 		Name               string    `yaml:"name" json:"name"`
 		Type               ValueType `yaml:"type" json:"type"`
 		Index              int       `yaml:"index" json:"index"`
+		Column             string    `yaml:"column,omitempty" json:"column,omitempty,optional"`
 		Nullable           bool      `yaml:"nullable" json:"nullable,omitempty,optional,default=false"`
 		NullValue          string    `yaml:"nullValue" json:"nullValue,omitempty,optional"`
 		AlternativeIndices []int     `yaml:"alternativeIndices,omitempty" json:"alternativeIndices,omitempty,optional"`
+		AlternativeColumns []string  `yaml:"alternativeColumns,omitempty" json:"alternativeColumns,omitempty,optional"`
 		DefaultValue       *string   `yaml:"defaultValue" json:"defaultValue,omitempty,optional"`
+		Format             string    `yaml:"format,omitempty" json:"format,omitempty,optional"`
+		Timezone           string    `yaml:"timezone,omitempty" json:"timezone,omitempty,optional"`
+		NullPolicy         string    `yaml:"nullPolicy,omitempty" json:"nullPolicy,omitempty,optional"`
 		Expr               *string   `yaml:"expr" json:"expr,omitempty,optional"`
+		ValueExpr          *string   `yaml:"valueExpr,omitempty" json:"valueExpr,omitempty,optional"`
 
 		convertedName string
+		columns       map[string]int
+		lookups       picker.Lookups
 		picker        picker.Picker
 		exprProgram   *vm.Program
 	}
@@ -34,6 +44,35 @@ func (p *Prop) Complete() {
 	}
 }
 
+func (p *Prop) Clone() *Prop {
+	cpy := *p
+	return &cpy
+}
+
+// HasColumnNames reports whether the prop addresses the columns by name.
+func (p *Prop) HasColumnNames() bool {
+	return p.Column != "" || len(p.AlternativeColumns) > 0
+}
+
+// resolveColumns resolves the column names to the indices by the header.
+func (p *Prop) resolveColumns(header Header) error {
+	if p.Column != "" {
+		index, err := header.resolve(p.Column)
+		if err != nil {
+			return p.importError(err)
+		}
+		p.Index = index
+	}
+	if len(p.AlternativeColumns) > 0 {
+		indices, err := header.resolveAll(p.AlternativeColumns)
+		if err != nil {
+			return p.importError(err)
+		}
+		p.AlternativeIndices = indices
+	}
+	return nil
+}
+
 func (p *Prop) Validate() error {
 	if p.Name == "" {
 		return p.importError(errors.ErrNoPropName)
@@ -60,6 +99,9 @@ func (p *Prop) Validate() error {
 func (p *Prop) Value(record Record) (string, error) {
 	val, err := p.picker.Pick(record)
 	if err != nil {
+		if p.ValueExpr != nil {
+			return "", p.importError(err, "record value expr %q pick failed", *p.ValueExpr).SetRecord(record)
+		}
 		return "", p.importError(err, "record index %d pick failed", p.Index).SetRecord(record)
 	}
 	defer val.Release()
@@ -76,20 +118,27 @@ func (p *Prop) SetValue(record Record) (string, error) {
 
 func (p *Prop) initPicker() error {
 	pickerConfig := picker.Config{
-		Indices: []int{p.Index},
-		Type:    string(p.Type),
+		ValueExpr:  p.ValueExpr,
+		Columns:    p.columns,
+		Lookups:    p.lookups,
+		Indices:    []int{p.Index},
+		Format:     p.Format,
+		Timezone:   p.Timezone,
+		NullPolicy: p.NullPolicy,
+		NullValue:  dbNULL,
+		Type:       string(p.Type),
 	}
 
 	if p.Nullable {
 		pickerConfig.Nullable = func(s string) bool {
 			return s == p.NullValue
 		}
-		pickerConfig.NullValue = dbNULL
 		if len(p.AlternativeIndices) > 0 {
 			pickerConfig.Indices = append(pickerConfig.Indices, p.AlternativeIndices...)
 		}
-		pickerConfig.DefaultValue = p.DefaultValue
 	}
+	// The default value is also used by the null policy of the temporal types.
+	pickerConfig.DefaultValue = p.DefaultValue
 
 	var err error
 	p.picker, err = pickerConfig.Build()
@@ -115,6 +164,57 @@ func (ps Props) Validate() error {
 	return nil
 }
 
+func (ps Props) Clone() Props {
+	if ps == nil {
+		return nil
+	}
+	cpy := make(Props, len(ps))
+	for i := range ps {
+		cpy[i] = ps[i].Clone()
+	}
+	return cpy
+}
+
+func (ps Props) HasColumnNames() bool {
+	for i := range ps {
+		if ps[i].HasColumnNames() {
+			return true
+		}
+	}
+	return false
+}
+
+func (ps Props) resolveColumns(header Header) error {
+	for i := range ps {
+		if err := ps[i].resolveColumns(header); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// columns returns the indices of the header columns and the props picked from the record by name,
+// which are available in the value expressions.
+func (ps Props) columns(header Header) map[string]int {
+	columns := make(map[string]int, len(header)+len(ps))
+	for name, index := range header {
+		columns[name] = index
+	}
+	for _, prop := range ps {
+		if prop.ValueExpr == nil {
+			columns[prop.Name] = prop.Index
+		}
+	}
+	return columns
+}
+
+func (ps Props) setExprEnv(columns map[string]int, lookups picker.Lookups) {
+	for i := range ps {
+		ps[i].columns = columns
+		ps[i].lookups = lookups
+	}
+}
+
 func (ps Props) ValueList(record Record) ([]string, error) {
 	valueList := make([]string, 0, len(ps))
 	for _, prop := range ps {
@@ -128,6 +228,11 @@ func (ps Props) ValueList(record Record) ([]string, error) {
 }
 
 func (ps Props) SetValueList(record Record) ([]string, error) {
+	return ps.setValueList(record, nil)
+}
+
+// setValueList is the same as SetValueList, and the values are replaced by the fnBind if not nil, such as the params.
+func (ps Props) setValueList(record Record, fnBind func(p *Prop, value string) string) ([]string, error) {
 	setValueList := make([]string, 0, len(ps))
 	for _, prop := range ps {
 		value, err := prop.SetValue(record)
@@ -146,6 +251,9 @@ func (ps Props) SetValueList(record Record) ([]string, error) {
 				return nil, err
 			}
 		}
+		if fnBind != nil {
+			value = prop.convertedName + " = " + fnBind(prop, strings.TrimPrefix(value, prop.convertedName+" = "))
+		}
 		setValueList = append(setValueList, value)
 	}
 	return setValueList, nil
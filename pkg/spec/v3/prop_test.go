@@ -262,6 +262,75 @@ var _ = Describe("Prop", func() {
 			"0",
 			nil,
 		),
+		Entry("value expr",
+			&Prop{
+				Name:      "p1",
+				Type:      ValueTypeString,
+				ValueExpr: func() *string { s := `lower(Record[0]) + "-" + Record[1]`; return &s }(),
+			},
+			Record([]string{"ABC", "1"}),
+			"\"abc-1\"",
+			nil,
+		),
+		Entry("value expr arithmetic",
+			&Prop{
+				Name:      "p1",
+				Type:      ValueTypeInt,
+				ValueExpr: func() *string { s := `int(Record[0]) * 100 + int(Record[1])`; return &s }(),
+			},
+			Record([]string{"1", "23"}),
+			"123",
+			nil,
+		),
+		Entry("value expr conditional",
+			&Prop{
+				Name:      "p1",
+				Type:      ValueTypeString,
+				ValueExpr: func() *string { s := `Record[0] == "1" ? "male" : "female"`; return &s }(),
+			},
+			Record([]string{"1"}),
+			"\"male\"",
+			nil,
+		),
+		Entry("format",
+			&Prop{
+				Name:     "p1",
+				Type:     ValueTypeDateTime,
+				Format:   "02/01/2006 15:04",
+				Timezone: "Asia/Shanghai",
+			},
+			Record([]string{"13/05/2024 08:30"}),
+			"DATETIME(\"2024-05-13T08:30:00.000000+08:00\")",
+			nil,
+		),
+		Entry("format invalid value",
+			&Prop{
+				Name:   "p1",
+				Type:   ValueTypeDate,
+				Format: "%Y%m%d",
+			},
+			Record([]string{"2024-05-13"}),
+			"",
+			errors.ErrInvalidValue,
+		),
+		Entry("duration",
+			&Prop{
+				Name: "p1",
+				Type: ValueTypeDuration,
+			},
+			Record([]string{"P1DT2H"}),
+			"duration({days: 1, hours: 2})",
+			nil,
+		),
+		Entry("geojson",
+			&Prop{
+				Name: "p1",
+				Type: ValueTypeGeoPoint,
+			},
+			Record([]string{`{"type": "Point", "coordinates": [1.5, 2]}`}),
+			"ST_GeogFromText(\"POINT(1.5 2)\")",
+			nil,
+		),
 		Entry("unsupported value type",
 			&Prop{
 				Name: "p1",
@@ -7,12 +7,38 @@ import (
 
 type (
 	Rank struct {
-		Index int `yaml:"index" json:"index"`
+		Index     int     `yaml:"index" json:"index"`
+		Column    string  `yaml:"column,omitempty" json:"column,omitempty,optional"`
+		ValueExpr *string `yaml:"valueExpr,omitempty" json:"valueExpr,omitempty,optional"`
 
-		picker picker.Picker
+		columns map[string]int
+		lookups picker.Lookups
+		picker  picker.Picker
 	}
 )
 
+func (r *Rank) Clone() *Rank {
+	cpy := *r
+	return &cpy
+}
+
+// HasColumnNames reports whether the rank addresses the column by name.
+func (r *Rank) HasColumnNames() bool {
+	return r.Column != ""
+}
+
+// resolveColumns resolves the column name to the index by the header.
+func (r *Rank) resolveColumns(header Header) error {
+	if r.Column != "" {
+		index, err := header.resolve(r.Column)
+		if err != nil {
+			return r.importError(err)
+		}
+		r.Index = index
+	}
+	return nil
+}
+
 func (*Rank) Complete() {}
 
 func (r *Rank) Validate() error {
@@ -26,6 +52,9 @@ func (r *Rank) Validate() error {
 func (r *Rank) Value(record Record) (string, error) {
 	val, err := r.picker.Pick(record)
 	if err != nil {
+		if r.ValueExpr != nil {
+			return "", r.importError(err, "record value expr %q pick failed", *r.ValueExpr).SetRecord(record)
+		}
 		return "", r.importError(err, "record index %d pick failed", r.Index).SetRecord(record)
 	}
 	defer val.Release()
@@ -34,8 +63,11 @@ func (r *Rank) Value(record Record) (string, error) {
 
 func (r *Rank) initPicker() error {
 	pickerConfig := picker.Config{
-		Indices: []int{r.Index},
-		Type:    string(ValueTypeInt),
+		ValueExpr: r.ValueExpr,
+		Columns:   r.columns,
+		Lookups:   r.lookups,
+		Indices:   []int{r.Index},
+		Type:      string(ValueTypeInt),
 	}
 
 	var err error
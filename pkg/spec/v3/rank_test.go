@@ -82,5 +82,11 @@ var _ = Describe("Rank", func() {
 			"11",
 			nil,
 		),
+		Entry("value expr",
+			&Rank{ValueExpr: func() *string { s := `int(Record[0]) + int(Record[1])`; return &s }()},
+			Record([]string{"1", "11"}),
+			"12",
+			nil,
+		),
 	)
 })
@@ -0,0 +1,19 @@
+package specv3
+
+import "github.com/lucky-xin/nebula-importer/pkg/errors"
+
+const (
+	// UpsertStrategyStatement upserts each record by an UPSERT statement, it is the default strategy.
+	UpsertStrategyStatement = "statement"
+	// UpsertStrategyInsert upserts the records by one batched INSERT statement, which overwrites the existing ones.
+	// It fits only when the props are all the props of the schema, the props not set are reset to their defaults.
+	UpsertStrategyInsert = "insert"
+)
+
+func validateUpsertStrategy(strategy string) error {
+	switch strategy {
+	case "", UpsertStrategyStatement, UpsertStrategyInsert:
+		return nil
+	}
+	return errors.NewImportError(errors.ErrUnsupportedUpsertStrategy, "unsupported upsert strategy %q", strategy)
+}
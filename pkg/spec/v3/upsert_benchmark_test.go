@@ -0,0 +1,101 @@
+package specv3
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lucky-xin/nebula-importer/pkg/client"
+	"github.com/lucky-xin/nebula-importer/pkg/importer"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	"github.com/golang/mock/gomock"
+)
+
+func BenchmarkUpsertStrategyNode(b *testing.B) {
+	for _, strategy := range []string{UpsertStrategyStatement, UpsertStrategyInsert} {
+		b.Run(strategy, func(b *testing.B) {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+				WithNodeProps(
+					&Prop{Name: "prop1", Type: ValueTypeString, Index: 1},
+					&Prop{Name: "prop2", Type: ValueTypeInt, Index: 2},
+				),
+				WithNodeMode(specbase.UpsertMode),
+				WithNodeUpsertStrategy(strategy),
+			)
+			graph := NewGraph("graph", WithGraphNodes(node))
+			graph.Complete()
+			if err := graph.Validate(); err != nil {
+				b.Fatal(err)
+			}
+			benchmarkImport(b, graph.NodeStatementBuilder(node), func(i int) Record {
+				return Record{strconv.Itoa(i), "str" + strconv.Itoa(i), strconv.Itoa(i)}
+			})
+		})
+	}
+}
+
+func BenchmarkUpsertStrategyEdge(b *testing.B) {
+	for _, strategy := range []string{UpsertStrategyStatement, UpsertStrategyInsert} {
+		b.Run(strategy, func(b *testing.B) {
+			edge := NewEdge(
+				"name",
+				WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+				WithEdgeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 2}),
+				WithEdgeMode(specbase.UpsertMode),
+				WithEdgeUpsertStrategy(strategy),
+			)
+			graph := NewGraph("graph", WithGraphEdges(edge))
+			graph.Complete()
+			if err := graph.Validate(); err != nil {
+				b.Fatal(err)
+			}
+			benchmarkImport(b, graph.EdgeStatementBuilder(edge), func(i int) Record {
+				return Record{strconv.Itoa(i), strconv.Itoa(i + 1), "str" + strconv.Itoa(i)}
+			})
+		})
+	}
+}
+
+// benchmarkImport imports the batches of 200 records by the mock client, which returns at once,
+// so that only the statement building and the importer are measured, not the execution in graphd.
+// The statements of each request are reported, graphd runs them sequentially.
+func benchmarkImport(b *testing.B, builder specbase.StatementBuilder, fnRecord func(i int) Record) {
+	const batch = 200
+
+	ctrl := gomock.NewController(b)
+	defer ctrl.Finish()
+	mockResponse := client.NewMockResponse(ctrl)
+	mockResponse.EXPECT().IsSucceed().AnyTimes().Return(true)
+	mockResponse.EXPECT().GetRespTime().AnyTimes().Return(time.Duration(0))
+	mockResponse.EXPECT().GetLatency().AnyTimes().Return(time.Duration(0))
+	mockPool := client.NewMockPool(ctrl)
+	var nStatement int
+	mockPool.EXPECT().Execute(gomock.Any(), gomock.Any()).AnyTimes().DoAndReturn(
+		func(_ context.Context, statement string) (client.Response, error) {
+			nStatement = max(strings.Count(statement, ";"), 1)
+			return mockResponse, nil
+		},
+	)
+
+	records := make([]Record, batch)
+	for i := range records {
+		records[i] = fnRecord(i)
+	}
+
+	i := importer.New(builder, mockPool)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := i.Import(context.Background(), records...); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(b.N*batch)/b.Elapsed().Seconds(), "records/s")
+	b.ReportMetric(float64(nStatement), "statements/request")
+}
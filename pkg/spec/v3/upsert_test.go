@@ -0,0 +1,119 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UpsertStrategy", func() {
+	It("node", func() {
+		newNode := func(strategy string) *Node {
+			node := NewNode(
+				"name",
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+				WithNodeProps(
+					&Prop{Name: "prop1", Type: ValueTypeString, Index: 1},
+					&Prop{Name: "prop2", Type: ValueTypeInt, Index: 2},
+				),
+				WithNodeMode(specbase.UpsertMode),
+				WithNodeUpsertStrategy(strategy),
+			)
+			ignore := true
+			node.IgnoreExistedRecord = &ignore
+			node.Complete()
+			Expect(node.Validate()).NotTo(HaveOccurred())
+			return node
+		}
+
+		statement, nRecord, err := newNode(UpsertStrategyStatement).Statement([]string{"1", "a", "1"}, []string{"2", "b", "2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("UPSERT VERTEX ON `name` 1 SET `prop1` = \"a\", `prop2` = 1;" +
+			"UPSERT VERTEX ON `name` 2 SET `prop1` = \"b\", `prop2` = 2;"))
+
+		// The IF NOT EXISTS is never set, otherwise the existing ones are not overwritten.
+		statement, nRecord, err = newNode(UpsertStrategyInsert).Statement([]string{"1", "a", "1"}, []string{"2", "b", "2"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`prop1`, `prop2`) VALUES 1:(\"a\", 1), 2:(\"b\", 2)"))
+	})
+
+	It("edge", func() {
+		newEdge := func(strategy string) *Edge {
+			edge := NewEdge(
+				"name",
+				WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+				WithRank(&Rank{Index: 2}),
+				WithEdgeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 3}),
+				WithEdgeMode(specbase.UpsertMode),
+				WithEdgeUpsertStrategy(strategy),
+			)
+			edge.Complete()
+			Expect(edge.Validate()).NotTo(HaveOccurred())
+			return edge
+		}
+
+		statement, nRecord, err := newEdge("").Statement([]string{"1", "2", "0", "a"}, []string{"2", "3", "1", "b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("UPSERT EDGE ON `name` 1->2@0 SET `prop1` = \"a\";" +
+			"UPSERT EDGE ON `name` 2->3@1 SET `prop1` = \"b\";"))
+
+		statement, nRecord, err = newEdge(UpsertStrategyInsert).Statement([]string{"1", "2", "0", "a"}, []string{"2", "3", "1", "b"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal("INSERT EDGE `name`(`prop1`) VALUES 1->2@0:(\"a\"), 2->3@1:(\"b\")"))
+	})
+
+	It("with op column", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 1}),
+			WithNodeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 2}),
+			WithNodeOpColumn(&OpColumn{Index: 0}),
+			WithNodeUpsertStrategy(UpsertStrategyInsert),
+		)
+		node.Complete()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := node.Statement(
+			[]string{"UPSERT", "1", "a"},
+			[]string{"UPSERT", "2", "b"},
+			[]string{"UPDATE", "1", "c"},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(3))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`prop1`) VALUES 1:(\"a\"), 2:(\"b\");" +
+			"UPDATE VERTEX ON `name` 1 SET `prop1` = \"c\";"))
+	})
+
+	It("unsupported", func() {
+		node := NewNode(
+			"name",
+			WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+			WithNodeProps(&Prop{Name: "prop1", Type: ValueTypeString, Index: 1}),
+			WithNodeUpsertStrategy("merge"),
+		)
+		node.Complete()
+		err := node.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnsupportedUpsertStrategy)).To(BeTrue())
+
+		edge := NewEdge(
+			"name",
+			WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+			WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+			WithEdgeUpsertStrategy("merge"),
+		)
+		edge.Complete()
+		err = edge.Validate()
+		Expect(err).To(HaveOccurred())
+		Expect(stderrors.Is(err, errors.ErrUnsupportedUpsertStrategy)).To(BeTrue())
+	})
+})
@@ -21,6 +21,7 @@ const (
 	ValueTypeTime          ValueType = "TIME"
 	ValueTypeDateTime      ValueType = "DATETIME"
 	ValueTypeTimestamp     ValueType = "TIMESTAMP"
+	ValueTypeDuration      ValueType = "DURATION"
 	ValueTypeGeo           ValueType = "GEOGRAPHY"
 	ValueTypeGeoPoint      ValueType = "GEOGRAPHY(POINT)"
 	ValueTypeGeoLineString ValueType = "GEOGRAPHY(LINESTRING)"
@@ -45,6 +46,7 @@ var (
 		ValueTypeTime:          {},
 		ValueTypeDateTime:      {},
 		ValueTypeTimestamp:     {},
+		ValueTypeDuration:      {},
 		ValueTypeGeo:           {},
 		ValueTypeGeoPoint:      {},
 		ValueTypeGeoLineString: {},
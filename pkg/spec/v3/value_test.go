@@ -20,6 +20,7 @@ var _ = Describe("Value", func() {
 		Entry(nil, ValueTypeTime, true),
 		Entry(nil, ValueTypeDateTime, true),
 		Entry(nil, ValueTypeTimestamp, true),
+		Entry(nil, ValueTypeDuration, true),
 		Entry(nil, ValueTypeGeo, true),
 		Entry(nil, ValueTypeGeoPoint, true),
 		Entry(nil, ValueTypeGeoLineString, true),
@@ -44,6 +45,7 @@ var _ = Describe("Value", func() {
 		Entry(nil, ValueTypeDate, false),
 		Entry(nil, ValueTypeDateTime, false),
 		Entry(nil, ValueTypeTimestamp, false),
+		Entry(nil, ValueTypeDuration, false),
 		Entry(nil, ValueTypeGeo, false),
 		Entry(nil, ValueTypeGeoPoint, false),
 		Entry(nil, ValueTypeGeoLineString, false),
@@ -0,0 +1,382 @@
+package specv3
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	"github.com/lucky-xin/nebula-importer/pkg/picker"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+	"github.com/lucky-xin/nebula-importer/pkg/utils"
+)
+
+// The columns yielded by the stale query, which are the keys and versions of the existing vertices or edges.
+const (
+	versionVIDColumn  = "vid"
+	versionSrcColumn  = "src"
+	versionDstColumn  = "dst"
+	versionRankColumn = "rank"
+	versionColumn     = "version"
+)
+
+// versionValueTypes are the types of the version prop, which can be compared with the fetched versions.
+var versionValueTypes = map[ValueType]struct{}{
+	ValueTypeInt:         {},
+	ValueTypeInt8:        {},
+	ValueTypeInt16:       {},
+	ValueTypeInt32:       {},
+	ValueTypeInt64:       {},
+	ValueTypeFloat:       {},
+	ValueTypeDouble:      {},
+	ValueTypeTimestamp:   {},
+	ValueTypeString:      {},
+	ValueTypeFixedString: {},
+}
+
+// validateVersionProp returns the prop of the version, which guards the UPSERT and UPDATE modes.
+func validateVersionProp(
+	name string,
+	props Props,
+	modes []specbase.Mode,
+	upsertStrategy string,
+	dedup *specbase.Dedup,
+	ids ...*NodeID,
+) (*Prop, error) {
+	var versionProp *Prop
+	for _, p := range props {
+		if p.Name == name {
+			versionProp = p
+			break
+		}
+	}
+	if versionProp == nil {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "the version prop %s is not in the props", name)
+	}
+	if _, ok := versionValueTypes[versionProp.Type]; !ok {
+		return nil, errors.NewImportError(errors.ErrUnsupportedValueType,
+			"the version prop of type %s can not be compared", versionProp.Type)
+	}
+	// The VIDs generated by graphd can not be compared with the fetched ones.
+	for _, id := range ids {
+		if id.Function != nil && *id.Function != "" && !picker.IsClientFunction(*id.Function) {
+			return nil, errors.NewImportError(errors.ErrUnsupportedFunction,
+				"function %s is unsupported by the version prop", *id.Function)
+		}
+	}
+
+	var guarded bool
+	for _, mode := range modes {
+		switch mode {
+		case specbase.UpsertMode:
+			if upsertStrategy == UpsertStrategyInsert {
+				return nil, errors.NewImportError(errors.ErrInvalidConfig,
+					"the version prop does not work with the upsert strategy %s", UpsertStrategyInsert)
+			}
+			guarded = true
+		case specbase.UpdateMode:
+			guarded = true
+		}
+	}
+	if !guarded {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "the version prop works only in the UPSERT or UPDATE mode")
+	}
+	// The dedup keeps the first record, but the version keeps the newest one.
+	if dedup != nil {
+		return nil, errors.NewImportError(errors.ErrInvalidConfig, "the version prop does not work with dedup")
+	}
+	return versionProp, nil
+}
+
+// versionGuard returns " WHEN prop IS NULL OR prop < value", so that the update applies only if the record is newer.
+func versionGuard(versionProp *Prop, record Record) (string, error) {
+	value, err := versionProp.Value(record)
+	if err != nil {
+		return "", err
+	}
+	return " WHEN " + versionProp.convertedName + " IS NULL OR " + versionProp.convertedName + " < " + value, nil
+}
+
+// isGuarded reports whether the record passes the filter and is guarded by the version, that is in UPSERT or UPDATE mode.
+func isGuarded(filter *specbase.Filter, opColumn *OpColumn, mode specbase.Mode, record Record) (bool, error) {
+	if filter != nil {
+		ok, err := filter.Filter(record)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	if opColumn != nil {
+		var err error
+		if mode, err = opColumn.Mode(record); err != nil {
+			return false, err
+		}
+	}
+	return mode == specbase.UpsertMode || mode == specbase.UpdateMode, nil
+}
+
+// staleQuery returns the query to fetch the versions of the existing elements of the guarded records, empty if no record.
+// The fnKey returns the key to fetch the element of the record, and the fnFetch returns the query of the keys.
+func staleQuery(
+	records []Record,
+	fnGuarded func(record Record) (bool, error),
+	fnKey func(record Record) (string, error),
+	fnFetch func(keys []string) string,
+) (string, error) {
+	keys := make([]string, 0, len(records))
+	seen := make(map[string]struct{}, len(records))
+	for _, record := range records {
+		guarded, err := fnGuarded(record)
+		if err != nil {
+			return "", err
+		}
+		if !guarded {
+			continue
+		}
+		key, err := fnKey(record)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return "", nil
+	}
+	return fnFetch(keys), nil
+}
+
+// existingVersions returns the versions of the existing elements by the keys, which are yielded by the stale query
+// as the nColumn columns of the key parts followed by the version.
+func existingVersions(existing [][]string, nColumn int, fnKey func(i int) string) (map[string]string, error) {
+	if len(existing) == 0 {
+		return nil, nil
+	}
+	if len(existing) != nColumn {
+		return nil, errors.NewImportError(errors.ErrInvalidValue, "the existing versions are not the keys and versions")
+	}
+	for _, values := range existing[1:] {
+		if len(values) != len(existing[0]) {
+			return nil, errors.NewImportError(errors.ErrInvalidValue, "the existing versions are not the keys and versions")
+		}
+	}
+	versions := make(map[string]string, len(existing[0]))
+	for i, version := range existing[nColumn-1] {
+		versions[fnKey(i)] = version
+	}
+	return versions, nil
+}
+
+// freshRecords returns the guarded records except the ones not newer than the existing versions, and the others.
+func freshRecords(
+	versionProp *Prop,
+	versions map[string]string,
+	records []Record,
+	fnGuarded func(record Record) (bool, error),
+	fnKey func(record Record) (string, error),
+) (fresh []Record, nStale int, err error) {
+	if len(versions) == 0 {
+		return records, 0, nil
+	}
+	fresh = make([]Record, 0, len(records))
+	for _, record := range records {
+		guarded, err := fnGuarded(record)
+		if err != nil {
+			return nil, 0, err
+		}
+		if guarded {
+			key, err := fnKey(record)
+			if err != nil {
+				return nil, 0, err
+			}
+			if existing, ok := versions[key]; ok {
+				value, err := versionProp.Value(record)
+				if err != nil {
+					return nil, 0, err
+				}
+				if isStaleVersion(versionProp.Type, existing, value) {
+					nStale++
+					continue
+				}
+			}
+		}
+		fresh = append(fresh, record)
+	}
+	return fresh, nStale, nil
+}
+
+// isStaleVersion reports whether the version of the record is not newer than the existing one.
+// The values not comparable, such as NULL, are not stale, and left to the version guard of the statement.
+func isStaleVersion(t ValueType, existing, value string) bool {
+	switch t {
+	case ValueTypeString, ValueTypeFixedString:
+		existingStr, err := strconv.Unquote(existing)
+		if err != nil {
+			return false
+		}
+		valueStr, err := strconv.Unquote(value)
+		if err != nil {
+			return false
+		}
+		return valueStr <= existingStr
+	case ValueTypeFloat, ValueTypeDouble:
+		existingFloat, err := strconv.ParseFloat(existing, 64)
+		if err != nil {
+			return false
+		}
+		valueFloat, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		return valueFloat <= existingFloat
+	default:
+		existingInt, err := strconv.ParseInt(existing, 0, 64)
+		if err != nil {
+			return false
+		}
+		valueInt, err := strconv.ParseInt(value, 0, 64)
+		if err != nil {
+			return false
+		}
+		return valueInt <= existingInt
+	}
+}
+
+// StaleQuery returns the query to fetch the versions of the existing vertices of the records,
+// and the columns of the VIDs and versions yielded, empty if no need.
+func (n *Node) StaleQuery(records ...Record) (query string, columns []string, err error) {
+	if n.versionProp == nil {
+		return "", nil, nil
+	}
+	// "FETCH PROP ON name id, ..., id YIELD id(vertex) AS vid, properties(vertex).prop AS version"
+	query, err = staleQuery(
+		records,
+		func(record Record) (bool, error) {
+			return isGuarded(n.Filter, n.OpColumn, n.Mode, record)
+		},
+		n.ID.Value,
+		func(keys []string) string {
+			return "FETCH PROP ON " + utils.ConvertIdentifier(n.Name) + " " + strings.Join(keys, ", ") +
+				" YIELD id(vertex) AS " + versionVIDColumn +
+				", properties(vertex)." + n.versionProp.convertedName + " AS " + versionColumn
+		},
+	)
+	if err != nil {
+		return "", nil, n.importError(err)
+	}
+	if query == "" {
+		return "", nil, nil
+	}
+	return query, []string{versionVIDColumn, versionColumn}, nil
+}
+
+// StatementFresh is the same as Statement, but skips the records not newer than the existing vertices,
+// which are the VIDs and versions fetched by the StaleQuery.
+func (n *Node) StatementFresh(existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error) {
+	versions, err := existingVersions(existing, 2, func(i int) string {
+		return existing[0][i]
+	})
+	if err != nil {
+		return "", 0, 0, n.importError(err)
+	}
+	records, nStale, err = freshRecords(
+		n.versionProp,
+		versions,
+		records,
+		func(record Record) (bool, error) {
+			return isGuarded(n.Filter, n.OpColumn, n.Mode, record)
+		},
+		n.ID.Value,
+	)
+	if err != nil {
+		return "", 0, 0, n.importError(err)
+	}
+	statement, nRecord, err = n.Statement(records...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return statement, nRecord, nStale, nil
+}
+
+// versionKey returns the key of the edge of the record, "src->dst@rank" in the forward direction.
+func (e *Edge) versionKey(record Record) (string, error) {
+	srcIDValue, err := e.Src.IDValue(record)
+	if err != nil {
+		return "", err
+	}
+	dstIDValue, err := e.Dst.IDValue(record)
+	if err != nil {
+		return "", err
+	}
+	fromIDValue, toIDValue := endpointIDValues(srcIDValue, dstIDValue, e.Direction == DirectionReverse)
+	rankValue := "0"
+	if e.Rank != nil {
+		if rankValue, err = e.Rank.Value(record); err != nil {
+			return "", err
+		}
+	}
+	return fromIDValue + "->" + toIDValue + "@" + rankValue, nil
+}
+
+// StaleQuery returns the query to fetch the versions of the existing edges of the records,
+// and the columns of the src, dst, ranks and versions yielded, empty if no need.
+// The edges of both directions are checked by the forward ones.
+func (e *Edge) StaleQuery(records ...Record) (query string, columns []string, err error) {
+	if e.versionProp == nil {
+		return "", nil, nil
+	}
+	name := e.Name
+	if e.Direction == DirectionReverse {
+		name = e.reverseName()
+	}
+	// "FETCH PROP ON name src->dst@rank, ... YIELD src(edge) AS src, dst(edge) AS dst, rank(edge) AS rank, properties(edge).prop AS version"
+	query, err = staleQuery(
+		records,
+		func(record Record) (bool, error) {
+			return isGuarded(e.Filter, e.OpColumn, e.Mode, record)
+		},
+		e.versionKey,
+		func(keys []string) string {
+			return "FETCH PROP ON " + utils.ConvertIdentifier(name) + " " + strings.Join(keys, ", ") +
+				" YIELD src(edge) AS " + versionSrcColumn + ", dst(edge) AS " + versionDstColumn +
+				", rank(edge) AS " + versionRankColumn +
+				", properties(edge)." + e.versionProp.convertedName + " AS " + versionColumn
+		},
+	)
+	if err != nil {
+		return "", nil, e.importError(err)
+	}
+	if query == "" {
+		return "", nil, nil
+	}
+	return query, []string{versionSrcColumn, versionDstColumn, versionRankColumn, versionColumn}, nil
+}
+
+// StatementFresh is the same as Statement, but skips the records not newer than the existing edges,
+// which are the src, dst, ranks and versions fetched by the StaleQuery.
+func (e *Edge) StatementFresh(existing [][]string, records ...Record) (statement string, nRecord, nStale int, err error) {
+	versions, err := existingVersions(existing, 4, func(i int) string {
+		return existing[0][i] + "->" + existing[1][i] + "@" + existing[2][i]
+	})
+	if err != nil {
+		return "", 0, 0, e.importError(err)
+	}
+	records, nStale, err = freshRecords(
+		e.versionProp,
+		versions,
+		records,
+		func(record Record) (bool, error) {
+			return isGuarded(e.Filter, e.OpColumn, e.Mode, record)
+		},
+		e.versionKey,
+	)
+	if err != nil {
+		return "", 0, 0, e.importError(err)
+	}
+	statement, nRecord, err = e.Statement(records...)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	return statement, nRecord, nStale, nil
+}
@@ -0,0 +1,259 @@
+package specv3
+
+import (
+	stderrors "errors"
+
+	"github.com/lucky-xin/nebula-importer/pkg/errors"
+	specbase "github.com/lucky-xin/nebula-importer/pkg/spec/base"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("VersionProp", func() {
+	newNode := func(opts ...NodeOption) *Node {
+		node := NewNode(
+			"name",
+			append([]NodeOption{
+				WithNodeID(&NodeID{Name: "id", Type: ValueTypeInt, Index: 0}),
+				WithNodeProps(
+					&Prop{Name: "prop1", Type: ValueTypeString, Index: 1},
+					&Prop{Name: "version", Type: ValueTypeInt, Index: 2},
+				),
+				WithNodeMode(specbase.UpsertMode),
+				WithNodeVersionProp("version"),
+			}, opts...)...,
+		)
+		node.Complete()
+		return node
+	}
+	newEdge := func(opts ...EdgeOption) *Edge {
+		edge := NewEdge(
+			"name",
+			append([]EdgeOption{
+				WithEdgeSrc(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+				WithRank(&Rank{Index: 2}),
+				WithEdgeProps(&Prop{Name: "version", Type: ValueTypeInt, Index: 3}),
+				WithEdgeMode(specbase.UpdateMode),
+				WithEdgeVersionProp("version"),
+			}, opts...)...,
+		)
+		edge.Complete()
+		return edge
+	}
+
+	DescribeTable(".Validate",
+		func(fnValidate func() error, expectErr error) {
+			err := fnValidate()
+			if expectErr == nil {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(stderrors.Is(err, expectErr)).To(BeTrue())
+			}
+		},
+		Entry("node", func() error {
+			return newNode().Validate()
+		}, nil),
+		Entry("node with op column", func() error {
+			return newNode(WithNodeOpColumn(&OpColumn{Index: 3})).Validate()
+		}, nil),
+		Entry("node not in props", func() error {
+			return newNode(WithNodeVersionProp("unknown")).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("node in INSERT", func() error {
+			return newNode(WithNodeMode(specbase.InsertMode)).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("node with upsert strategy insert", func() error {
+			return newNode(WithNodeUpsertStrategy(UpsertStrategyInsert)).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("node with dedup", func() error {
+			return newNode(WithNodeDedup(&specbase.Dedup{})).Validate()
+		}, errors.ErrInvalidConfig),
+		Entry("node with uncomparable type", func() error {
+			return newNode(
+				WithNodeProps(&Prop{Name: "updated", Type: ValueTypeDateTime, Index: 3}),
+				WithNodeVersionProp("updated"),
+			).Validate()
+		}, errors.ErrUnsupportedValueType),
+		Entry("node with graphd function", func() error {
+			return newNode(WithNodeID(&NodeID{Name: "id", Type: ValueTypeString, Index: 0, Function: &[]string{"hash"}[0]})).Validate()
+		}, errors.ErrUnsupportedFunction),
+		Entry("edge", func() error {
+			return newEdge().Validate()
+		}, nil),
+		Entry("edge with endpoints verify", func() error {
+			return newEdge(
+				WithEdgeEndpoints(EndpointsVerify),
+				WithEdgeSrc(&EdgeNodeRef{Tag: "player", ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 0}}),
+				WithEdgeDst(&EdgeNodeRef{Tag: "player", ID: &NodeID{Name: "id", Type: ValueTypeInt, Index: 1}}),
+			).Validate()
+		}, errors.ErrInvalidConfig),
+	)
+
+	It("node", func() {
+		node := newNode()
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := node.Statement([]string{"1", "a", "3"}, []string{"2", "b", "4"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(2))
+		Expect(statement).To(Equal(
+			"UPSERT VERTEX ON `name` 1 SET `prop1` = \"a\", `version` = 3 WHEN `version` IS NULL OR `version` < 3;" +
+				"UPSERT VERTEX ON `name` 2 SET `prop1` = \"b\", `version` = 4 WHEN `version` IS NULL OR `version` < 4;"))
+
+		query, columns, err := node.StaleQuery([]string{"1", "a", "3"}, []string{"2", "b", "4"}, []string{"1", "c", "5"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal([]string{"vid", "version"}))
+		Expect(query).To(Equal(
+			"FETCH PROP ON `name` 1, 2 YIELD id(vertex) AS vid, properties(vertex).`version` AS version"))
+
+		existing := [][]string{{"1", "2"}, {"4", "__NULL__"}}
+		statement, nRecord, nStale, err := node.StatementFresh(existing,
+			[]string{"1", "a", "3"}, []string{"2", "b", "4"}, []string{"1", "c", "5"}, []string{"3", "d", "1"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(3))
+		Expect(nStale).To(Equal(1))
+		Expect(statement).To(Equal(
+			"UPSERT VERTEX ON `name` 2 SET `prop1` = \"b\", `version` = 4 WHEN `version` IS NULL OR `version` < 4;" +
+				"UPSERT VERTEX ON `name` 1 SET `prop1` = \"c\", `version` = 5 WHEN `version` IS NULL OR `version` < 5;" +
+				"UPSERT VERTEX ON `name` 3 SET `prop1` = \"d\", `version` = 1 WHEN `version` IS NULL OR `version` < 1;"))
+
+		_, _, _, err = node.StatementFresh([][]string{{"1"}}, []string{"1", "a", "3"})
+		Expect(err).To(HaveOccurred())
+		_, _, _, err = node.StatementFresh([][]string{{"1", "2"}, {"4"}}, []string{"1", "a", "3"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("node with string version", func() {
+		node := newNode(WithNodeVersionProp("prop1"))
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		existing := [][]string{{"1", "2"}, {`"2024-01-02"`, `"2024-01-02"`}}
+		statement, nRecord, nStale, err := node.StatementFresh(existing,
+			[]string{"1", "2024-01-01", "3"}, []string{"2", "2024-01-03", "4"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nStale).To(Equal(1))
+		Expect(statement).To(HavePrefix("UPSERT VERTEX ON `name` 2 "))
+	})
+
+	It("node with op column", func() {
+		node := newNode(WithNodeOpColumn(&OpColumn{Index: 3}))
+		Expect(node.Validate()).NotTo(HaveOccurred())
+
+		records := []Record{
+			{"1", "a", "3", "I"},
+			{"2", "b", "4", "U"},
+			{"3", "c", "5", "D"},
+		}
+		query, _, err := node.StaleQuery(records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(Equal(
+			"FETCH PROP ON `name` 2 YIELD id(vertex) AS vid, properties(vertex).`version` AS version"))
+
+		statement, nRecord, err := node.Statement(records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(3))
+		Expect(statement).To(Equal("INSERT VERTEX `name`(`prop1`, `version`) VALUES 1:(\"a\", 3);" +
+			"UPDATE VERTEX ON `name` 2 SET `prop1` = \"b\", `version` = 4 WHEN `version` IS NULL OR `version` < 4;" +
+			"DELETE TAG `name` FROM 3;"))
+
+		// The existing version of the inserted vertex does not skip it.
+		_, nRecord, nStale, err := node.StatementFresh([][]string{{"1", "3"}, {"9", "9"}}, records...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(3))
+		Expect(nStale).To(Equal(0))
+
+		query, columns, err := node.StaleQuery(records[0])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(BeEmpty())
+		Expect(columns).To(BeEmpty())
+	})
+
+	It("edge", func() {
+		edge := newEdge()
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		statement, nRecord, err := edge.Statement([]string{"1", "2", "0", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(statement).To(Equal(
+			"UPDATE EDGE ON `name` 1->2@0 SET `version` = 3 WHEN `version` IS NULL OR `version` < 3;"))
+
+		query, columns, err := edge.StaleQuery([]string{"1", "2", "0", "3"}, []string{"1", "2", "1", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(columns).To(Equal([]string{"src", "dst", "rank", "version"}))
+		Expect(query).To(Equal(
+			"FETCH PROP ON `name` 1->2@0, 1->2@1 YIELD src(edge) AS src, dst(edge) AS dst, rank(edge) AS rank, " +
+				"properties(edge).`version` AS version"))
+
+		edge = newEdge(WithEdgeDirection(DirectionReverse, "knownBy"))
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+		query, _, err = edge.StaleQuery([]string{"1", "2", "0", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(Equal(
+			"FETCH PROP ON `knownBy` 2->1@0 YIELD src(edge) AS src, dst(edge) AS dst, rank(edge) AS rank, " +
+				"properties(edge).`version` AS version"))
+
+		existing := [][]string{{"2"}, {"1"}, {"0"}, {"3"}}
+		statement, nRecord, nStale, err := edge.StatementFresh(existing, []string{"1", "2", "0", "3"}, []string{"1", "2", "1", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nStale).To(Equal(1))
+		Expect(statement).To(Equal(
+			"UPDATE EDGE ON `knownBy` 2->1@1 SET `version` = 3 WHEN `version` IS NULL OR `version` < 3;"))
+
+		_, _, _, err = edge.StatementFresh([][]string{{"2"}, {"1"}}, []string{"1", "2", "0", "3"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("edge without rank", func() {
+		edge := newEdge(WithRank(nil))
+		Expect(edge.Validate()).NotTo(HaveOccurred())
+
+		query, _, err := edge.StaleQuery([]string{"1", "2", "3", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(HavePrefix("FETCH PROP ON `name` 1->2@0 YIELD "))
+
+		existing := [][]string{{"1"}, {"2"}, {"0"}, {"3"}}
+		_, nRecord, nStale, err := edge.StatementFresh(existing, []string{"1", "2", "3", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nStale).To(Equal(1))
+	})
+
+	It("graph builders", func() {
+		node := newNode()
+		edge := newEdge()
+		graph := NewGraph("graph", WithGraphNodes(node), WithGraphEdges(edge))
+		graph.Complete()
+		Expect(graph.Validate()).NotTo(HaveOccurred())
+
+		nb, ok := graph.NodeStatementBuilder(node).(specbase.VersionStatementBuilder)
+		Expect(ok).To(BeTrue())
+		query, _, err := nb.BuildStaleQuery([]string{"1", "a", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(HavePrefix("FETCH PROP ON `name` 1 "))
+		statement, nRecord, nStale, err := nb.BuildFresh(nil, []string{"1", "a", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+		Expect(nStale).To(Equal(0))
+		Expect(statement).To(HavePrefix("UPSERT VERTEX ON `name` 1 "))
+		_, nRecord, err = nb.Build([]string{"1", "a", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(1))
+
+		eb, ok := graph.EdgeStatementBuilder(edge).(specbase.VersionStatementBuilder)
+		Expect(ok).To(BeTrue())
+		query, _, err = eb.BuildStaleQuery([]string{"1", "2", "0", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query).To(HavePrefix("FETCH PROP ON `name` 1->2@0 "))
+		_, nRecord, nStale, err = eb.BuildFresh([][]string{{"1"}, {"2"}, {"0"}, {"3"}}, []string{"1", "2", "0", "3"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nRecord).To(Equal(0))
+		Expect(nStale).To(Equal(1))
+		_, _, err = eb.BuildStaleQuery([]string{"1"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -66,6 +66,27 @@ func (s *ConcurrencyStats) RequestSucceeded(nRecords int64, latency, respTime ti
 	s.s.TotalProcessed += nRecords
 }
 
+// Duplicated adds the number of the nodes and edges skipped as duplicates.
+func (s *ConcurrencyStats) Duplicated(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Duplicates += n
+}
+
+// Unverified adds the number of the nodes and edges skipped as unverified.
+func (s *ConcurrencyStats) Unverified(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Unverified += n
+}
+
+// Stale adds the number of the nodes and edges skipped as stale.
+func (s *ConcurrencyStats) Stale(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Stale += n
+}
+
 func (s *ConcurrencyStats) Stats() *Stats {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -70,6 +70,9 @@ var _ = Describe("ConcurrencyStats", func() {
 					concurrencyStats.RequestSucceeded(7, 9*time.Millisecond, 11*time.Millisecond)
 					concurrencyStats.RequestSucceeded(7, 9*time.Millisecond, 11*time.Millisecond)
 					concurrencyStats.Succeeded(nBytes, 7)
+					concurrencyStats.Duplicated(3)
+					concurrencyStats.Unverified(2)
+					concurrencyStats.Stale(1)
 					wg.Done()
 				}(succeededBytes[i])
 			}
@@ -97,6 +100,9 @@ var _ = Describe("ConcurrencyStats", func() {
 			TotalRespTime:   11 * time.Millisecond * time.Duration(sumBatches-sumFailedBatches) * 2,
 			FailedProcessed: sumFailedRecords * 2,
 			TotalProcessed:  sumRecords * 2,
+			Duplicates:      3 * (sumBatches - sumFailedBatches),
+			Unverified:      2 * (sumBatches - sumFailedBatches),
+			Stale:           1 * (sumBatches - sumFailedBatches),
 		}))
 
 		Expect(s.Percentage()).To(Equal(100.0))
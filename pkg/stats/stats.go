@@ -9,18 +9,21 @@ import (
 
 type (
 	Stats struct {
-		StartTime       time.Time     // The time to start statistics.
-		Processed       int64         // The processed bytes or records.
-		Total           int64         // The total bytes or records.
-		RecordStats     bool          // is bytes or records
-		FailedRecords   int64         // The number of records that have failed to be processed.
-		TotalRecords    int64         // The number of records that have been processed.
-		FailedRequest   int64         // The number of requests that have failed.
-		TotalRequest    int64         // The number of requests that have been processed.
-		TotalLatency    time.Duration // The cumulative latency.
-		TotalRespTime   time.Duration // The cumulative response time.
-		FailedProcessed int64         // The number of nodes and edges that have failed to be processed.
-		TotalProcessed  int64         // The number of nodes and edges that have been processed.
+		StartTime       time.Time     `json:"startTime"`       // The time to start statistics.
+		Processed       int64         `json:"processed"`       // The processed bytes or records.
+		Total           int64         `json:"total"`           // The total bytes or records.
+		RecordStats     bool          `json:"recordStats"`     // is bytes or records
+		FailedRecords   int64         `json:"failedRecords"`   // The number of records that have failed to be processed.
+		TotalRecords    int64         `json:"totalRecords"`    // The number of records that have been processed.
+		FailedRequest   int64         `json:"failedRequest"`   // The number of requests that have failed.
+		TotalRequest    int64         `json:"totalRequest"`    // The number of requests that have been processed.
+		TotalLatency    time.Duration `json:"totalLatency"`    // The cumulative latency.
+		TotalRespTime   time.Duration `json:"totalRespTime"`   // The cumulative response time.
+		FailedProcessed int64         `json:"failedProcessed"` // The number of nodes and edges that have failed to be processed.
+		TotalProcessed  int64         `json:"totalProcessed"`  // The number of nodes and edges that have been processed.
+		Duplicates      int64         `json:"duplicates"`      // The number of nodes and edges that have been skipped as duplicates.
+		Unverified      int64         `json:"unverified"`      // The number of nodes and edges that have been skipped as unverified, such as the dangling edges.
+		Stale           int64         `json:"stale"`           // The number of nodes and edges that have been skipped as not newer than the existing ones.
 	}
 )
 
@@ -46,6 +49,7 @@ func (s *Stats) String() string {
 		avgRespTime        time.Duration
 		requestPreSecond   float64
 		processedPreSecond float64
+		str                string
 	)
 
 	if percentage > 0 {
@@ -66,7 +70,7 @@ func (s *Stats) String() string {
 	}
 
 	if s.RecordStats {
-		return fmt.Sprintf("%s %s "+
+		str = fmt.Sprintf("%s %s "+
 			"%.2f%%(%d/%d) "+
 			"Records{Finished: %d, Failed: %d, Rate: %.2f/s}, "+
 			"Requests{Finished: %d, Failed: %d, Latency: %s/%s, Rate: %.2f/s}, "+
@@ -77,16 +81,27 @@ func (s *Stats) String() string {
 			s.TotalRequest, s.FailedRequest, avgLatency, avgRespTime, requestPreSecond,
 			s.TotalProcessed, s.FailedProcessed, processedPreSecond,
 		)
+	} else {
+		str = fmt.Sprintf("%s %s "+
+			"%.2f%%(%s/%s) "+
+			"Records{Finished: %d, Failed: %d, Rate: %.2f/s}, "+
+			"Requests{Finished: %d, Failed: %d, Latency: %s/%s, Rate: %.2f/s}, "+
+			"Processed{Finished: %d, Failed: %d, Rate: %.2f/s}",
+			duration.Truncate(time.Second), remainingTime,
+			percentage, humanize.IBytes(uint64(s.Processed)), humanize.IBytes(uint64(s.Total)),
+			s.TotalRecords, s.FailedRecords, recordsPreSecond,
+			s.TotalRequest, s.FailedRequest, avgLatency, avgRespTime, requestPreSecond,
+			s.TotalProcessed, s.FailedProcessed, processedPreSecond,
+		)
 	}
-	return fmt.Sprintf("%s %s "+
-		"%.2f%%(%s/%s) "+
-		"Records{Finished: %d, Failed: %d, Rate: %.2f/s}, "+
-		"Requests{Finished: %d, Failed: %d, Latency: %s/%s, Rate: %.2f/s}, "+
-		"Processed{Finished: %d, Failed: %d, Rate: %.2f/s}",
-		duration.Truncate(time.Second), remainingTime,
-		percentage, humanize.IBytes(uint64(s.Processed)), humanize.IBytes(uint64(s.Total)),
-		s.TotalRecords, s.FailedRecords, recordsPreSecond,
-		s.TotalRequest, s.FailedRequest, avgLatency, avgRespTime, requestPreSecond,
-		s.TotalProcessed, s.FailedProcessed, processedPreSecond,
-	)
+	if s.Duplicates > 0 {
+		str += fmt.Sprintf(", Duplicates: %d", s.Duplicates)
+	}
+	if s.Unverified > 0 {
+		str += fmt.Sprintf(", Unverified: %d", s.Unverified)
+	}
+	if s.Stale > 0 {
+		str += fmt.Sprintf(", Stale: %d", s.Stale)
+	}
+	return str
 }
@@ -32,5 +32,42 @@ var _ = Describe("Stats", func() {
 			Expect(s.IsFailed()).To(Equal(true))
 			Expect(s.String()).Should(Equal("10s 20s 33.33%(100 KiB/300 KiB) Records{Finished: 1234, Failed: 23, Rate: 123.40/s}, Requests{Finished: 12, Failed: 1, Latency: 1s/2s, Rate: 1.20/s}, Processed{Finished: 5, Failed: 2, Rate: 0.50/s}"))
 		})
+		It("Duplicates is not zero", func() {
+			s := &Stats{
+				StartTime:    time.Now(),
+				RecordStats:  true,
+				Processed:    3,
+				Total:        3,
+				TotalRecords: 3,
+				Duplicates:   2,
+			}
+			Expect(s.IsFailed()).To(Equal(false))
+			Expect(s.String()).Should(HaveSuffix("Processed{Finished: 0, Failed: 0, Rate: 0.00/s}, Duplicates: 2"))
+		})
+		It("Stale is not zero", func() {
+			s := &Stats{
+				StartTime:    time.Now(),
+				RecordStats:  true,
+				Processed:    3,
+				Total:        3,
+				TotalRecords: 3,
+				Stale:        2,
+			}
+			Expect(s.IsFailed()).To(Equal(false))
+			Expect(s.String()).Should(HaveSuffix("Processed{Finished: 0, Failed: 0, Rate: 0.00/s}, Stale: 2"))
+		})
+		It("Unverified is not zero", func() {
+			s := &Stats{
+				StartTime:    time.Now(),
+				RecordStats:  true,
+				Processed:    3,
+				Total:        3,
+				TotalRecords: 3,
+				Duplicates:   1,
+				Unverified:   2,
+			}
+			Expect(s.IsFailed()).To(Equal(false))
+			Expect(s.String()).Should(HaveSuffix(", Duplicates: 1, Unverified: 2"))
+		})
 	})
 })
@@ -1,6 +1,7 @@
 package importer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/lucky-xin/nebula-importer/pkg/task/ecode"
@@ -60,7 +61,7 @@ func StartImport(taskID string) (err error) {
 		}()
 		cfg := task.Client.Cfg
 		// 最终会调用Manager.Import方法开始导入数据
-		if err = cfg.Build(); err != nil {
+		if err = cfg.Build(context.Background()); err != nil {
 			logx.Errorf("build error: %v", err)
 			abort()
 			return
@@ -69,7 +70,8 @@ func StartImport(taskID string) (err error) {
 		logger := cfg.GetLogger()
 		task.Client.Manager = mgr
 		task.Client.Logger = logger
-		if err = mgr.Start(); err != nil {
+		// The manager is canceled by StopTask through Stop.
+		if err = mgr.Start(context.Background()); err != nil {
 			logx.Errorf("start error: %v", err)
 			abort()
 			_ = task.Client.Manager.Stop()
@@ -78,9 +80,10 @@ func StartImport(taskID string) (err error) {
 		task.Client.HasStarted = true
 		err = mgr.Wait()
 		if err != nil {
+			// The manager stops itself and returns ErrTooManyFailures once the failures exceed the thresholds.
 			logx.Errorf("task wait error: %v", err)
-			_ = GetTaskMgr().AbortTask(taskID, err.Error())
 			_ = task.Client.Manager.Stop()
+			abort()
 			return
 		}
 		if task.TaskInfo.TaskStatus == types.Running.String() {
@@ -257,6 +257,9 @@ func (mgr *TaskMgr) AbortTask(taskID, msg string) (err error) {
 		return nil
 	}
 	defer mgr.cache.Delete(taskID)
+	if err = task.UpdateQueryStats(); err != nil {
+		return ecode.WithErrorMessage(ecode.ErrInternalServer, err)
+	}
 	task.TaskInfo.TaskStatus = types.Aborted.String()
 	task.TaskInfo.TaskMessage = msg
 	err = mgr.db.UpdateTaskInfo(task.TaskInfo)
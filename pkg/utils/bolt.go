@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"os"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// OpenTempBolt opens a bolt database in a new temporary file, and creates the bucket in it.
+// It is pure Go, so it works in the binaries built without cgo. No durability is required, so the syncs are disabled.
+func OpenTempBolt(pattern string, bucket []byte) (*bbolt.DB, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return nil, err
+	}
+	path := f.Name()
+	_ = f.Close()
+
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{
+		Timeout:        time.Second,
+		NoSync:         true,
+		NoGrowSync:     true,
+		NoFreelistSync: true,
+	})
+	if err != nil {
+		_ = os.Remove(path)
+		return nil, err
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	}); err != nil {
+		_ = CloseTempBolt(db)
+		return nil, err
+	}
+	return db, nil
+}
+
+// CloseTempBolt closes the bolt database opened by OpenTempBolt, and removes the temporary file.
+func CloseTempBolt(db *bbolt.DB) error {
+	path := db.Path()
+	err := db.Close()
+	if errRemove := os.Remove(path); errRemove != nil && err == nil {
+		err = errRemove
+	}
+	return err
+}
+
+// BoltKey returns the key in bolt, which is prefixed so that the empty key is allowed.
+func BoltKey(key string) []byte {
+	k := make([]byte, 0, len(key)+1)
+	k = append(k, 'k')
+	return append(k, key...)
+}